@@ -6,10 +6,14 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	pb "github.com/botashev/tasks-executor/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func main() {
@@ -17,7 +21,15 @@ func main() {
 	if managerAddr == "" {
 		managerAddr = "localhost:50051"
 	}
-	conn, err := grpc.Dial(managerAddr, grpc.WithInsecure())
+	// The user name is recorded in the change history of executors
+	actor := os.Getenv("USER")
+	conn, err := grpc.Dial(managerAddr, grpc.WithInsecure(), grpc.WithUnaryInterceptor(
+		func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			if actor != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "x-actor", actor)
+			}
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to connect to manager: %v\n", err)
 		os.Exit(1)
@@ -25,10 +37,30 @@ func main() {
 	defer conn.Close()
 	client := pb.NewTaskExecutorManagerClient(conn)
 
-	cmd := flag.String("cmd", "", "command: add-executor | add-task | list-executors")
+	cmd := flag.String("cmd", "", "command: add-executor | add-task | cancel-task | get-task | list-tasks | list-dlq | dlq-summary | purge-dlq | redrive-dlq | list-dlqs | list-executors | delete-executor | undelete-executor | pause-executor | resume-executor | drain-executor | disable-executor | list-revisions | rollback-executor | list-workers | add-schedule | list-schedules | delete-schedule | pause-schedule | resume-schedule")
 	name := flag.String("name", "", "executor name")
+	taskID := flag.String("id", "", "task id")
+	reason := flag.String("reason", "", "reason of the cancellation, DLQ redrive or executor deletion")
 	configFile := flag.String("config", "", "executor config file (json)")
 	taskFile := flag.String("task", "", "task data file (json)")
+	runAt := flag.String("run-at", "", "do not run the task before this time (RFC3339)")
+	delay := flag.Duration("delay", 0, "do not run the task before now + delay (e.g. 24h)")
+	priority := flag.Int("priority", 0, "task priority, higher is dispatched first")
+	idempotencyKey := flag.String("idempotency-key", "", "deduplication key of the task")
+	scheduleName := flag.String("schedule", "", "schedule name")
+	cronExpr := flag.String("cron", "", "cron expression of the schedule (e.g. \"0 9 * * *\" or @hourly)")
+	timezone := flag.String("tz", "", "time zone of the schedule (e.g. Europe/Moscow), UTC by default")
+	taskStatus := flag.String("status", "", "task status filter (pending, in_progress, completed, failed, dlq, cancelled)")
+	pageSize := flag.Int("page-size", 0, "number of tasks or revisions to list (list-tasks, list-dlq, list-revisions), 50 by default")
+	pageToken := flag.String("page-token", "", "page token returned by the previous list-tasks, list-dlq or list-revisions call")
+	revision := flag.String("revision", "", "revision id reported by list-revisions to roll the executor back to")
+	version := flag.Int64("version", 0, "current version of the executor (rollback-executor), see list-executors")
+	target := flag.String("target", "", "executor to redrive DLQ tasks to, the original one by default, or to move tasks of a deleted executor to")
+	policy := flag.String("policy", "refuse", "what to do with tasks of a deleted executor: refuse | cancel | move | archive")
+	deleted := flag.Bool("deleted", false, "list deleted executors that can still be restored (list-executors)")
+	patchFile := flag.String("patch", "", "JSON merge patch file applied to the data of redriven tasks")
+	rate := flag.Float64("rate", 0, "max redriven tasks per second, unlimited by default")
+	errorGroup := flag.String("error-group", "", "normalized error reported by dlq-summary (list-dlq, purge-dlq, redrive-dlq)")
 	flag.Parse()
 
 	switch *cmd {
@@ -65,22 +97,229 @@ func main() {
 			fmt.Println("failed to read task file:", err)
 			os.Exit(1)
 		}
+		req := &pb.AddTaskRequest{
+			ExecutorName:   *name,
+			Data:           f,
+			Metadata:       map[string]string{},
+			Priority:       int32(*priority),
+			IdempotencyKey: *idempotencyKey,
+		}
+		if *runAt != "" && *delay != 0 {
+			fmt.Println("--run-at and --delay are mutually exclusive")
+			os.Exit(1)
+		}
+		if *runAt != "" {
+			t, err := time.Parse(time.RFC3339, *runAt)
+			if err != nil {
+				fmt.Println("invalid --run-at:", err)
+				os.Exit(1)
+			}
+			req.RunAt = timestamppb.New(t)
+		}
+		if *delay != 0 {
+			req.Delay = durationpb.New(*delay)
+		}
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
-		resp, err := client.AddTask(ctx, &pb.AddTaskRequest{
-			ExecutorName: *name,
-			Data:         f,
-			Metadata:     map[string]string{},
-		})
+		resp, err := client.AddTask(ctx, req)
 		if err != nil {
 			fmt.Println("failed to add task:", err)
 			os.Exit(1)
 		}
+		if resp.Deduplicated {
+			fmt.Println("Task already exists! ID:", resp.Task.Id)
+			return
+		}
 		fmt.Println("Task added! ID:", resp.Task.Id)
+	case "cancel-task":
+		if *taskID == "" {
+			fmt.Println("--id required")
+			os.Exit(1)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		resp, err := client.CancelTask(ctx, &pb.CancelTaskRequest{Id: *taskID, Reason: *reason})
+		if err != nil {
+			fmt.Println("failed to cancel task:", err)
+			os.Exit(1)
+		}
+		if resp.Task.CancelRequested {
+			fmt.Println("Cancellation requested, the task will be stopped by its worker")
+			return
+		}
+		fmt.Println("Task cancelled!")
+	case "get-task":
+		if *taskID == "" {
+			fmt.Println("--id required")
+			os.Exit(1)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		resp, err := client.GetTaskStatus(ctx, &pb.GetTaskStatusRequest{Id: *taskID})
+		if err != nil {
+			fmt.Println("failed to get task:", err)
+			os.Exit(1)
+		}
+		fmt.Println("Status:", resp.Status)
+		if resp.Error != "" {
+			fmt.Println("Error:", resp.Error)
+		}
+		if len(resp.Result) > 0 {
+			fmt.Println("Result:", string(resp.Result))
+		}
+	case "list-tasks":
+		req := &pb.ListTasksRequest{
+			ExecutorName: *name,
+			PageSize:     int32(*pageSize),
+			PageToken:    *pageToken,
+		}
+		if *taskStatus != "" {
+			st, ok := pb.TaskStatus_value["TASK_STATUS_"+strings.ToUpper(*taskStatus)]
+			if !ok {
+				fmt.Println("unknown --status:", *taskStatus)
+				os.Exit(1)
+			}
+			req.Statuses = []pb.TaskStatus{pb.TaskStatus(st)}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		resp, err := client.ListTasks(ctx, req)
+		if err != nil {
+			fmt.Println("failed to list tasks:", err)
+			os.Exit(1)
+		}
+		for _, task := range resp.Tasks {
+			fmt.Printf("%s\t%s\t%s\t%s\n", task.Id, task.ExecutorName, task.Status, task.CreatedAt.AsTime().Format(time.RFC3339))
+		}
+		if resp.NextPageToken != "" {
+			fmt.Println("Next page token:", resp.NextPageToken)
+		}
+	case "list-dlq":
+		if *name == "" {
+			fmt.Println("--name required")
+			os.Exit(1)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		resp, err := client.ListDLQTasks(ctx, &pb.ListDLQTasksRequest{
+			ExecutorName: *name,
+			Filter:       &pb.DLQFilter{ErrorGroup: *errorGroup},
+			PageSize:     int32(*pageSize),
+			PageToken:    *pageToken,
+		})
+		if err != nil {
+			fmt.Println("failed to list DLQ tasks:", err)
+			os.Exit(1)
+		}
+		for _, task := range resp.Tasks {
+			fmt.Printf("%s\t%s\t%s\n", task.Id, task.UpdatedAt.AsTime().Format(time.RFC3339), task.Error)
+		}
+		if resp.NextPageToken != "" {
+			fmt.Println("Next page token:", resp.NextPageToken)
+		}
+	case "dlq-summary":
+		if *name == "" {
+			fmt.Println("--name required")
+			os.Exit(1)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		resp, err := client.DLQSummary(ctx, &pb.DLQSummaryRequest{ExecutorName: *name})
+		if err != nil {
+			fmt.Println("failed to summarize DLQ:", err)
+			os.Exit(1)
+		}
+		for _, group := range resp.Groups {
+			fmt.Printf("%d\t%s\t%s\t%s\t%s\n", group.Count, group.FirstSeen.AsTime().Format(time.RFC3339), group.LastSeen.AsTime().Format(time.RFC3339), strings.Join(group.SampleTaskIds, ","), group.ErrorGroup)
+		}
+	case "purge-dlq":
+		if *name == "" {
+			fmt.Println("--name required")
+			os.Exit(1)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		resp, err := client.PurgeDLQ(ctx, &pb.PurgeDLQRequest{
+			ExecutorName: *name,
+			Filter:       &pb.DLQFilter{ErrorGroup: *errorGroup},
+		})
+		if err != nil {
+			fmt.Println("failed to purge DLQ:", err)
+			os.Exit(1)
+		}
+		fmt.Println("DLQ purged! Removed tasks:", resp.PurgedCount)
+	case "redrive-dlq":
+		if *name == "" {
+			fmt.Println("--name required")
+			os.Exit(1)
+		}
+		req := &pb.RedriveDLQRequest{
+			ExecutorName:   *name,
+			Filter:         &pb.DLQFilter{ErrorGroup: *errorGroup},
+			TargetExecutor: *target,
+			RatePerSecond:  *rate,
+			Reason:         *reason,
+		}
+		if *taskID != "" {
+			req.TaskIds = []string{*taskID}
+		}
+		if *patchFile != "" {
+			patch, err := os.ReadFile(*patchFile)
+			if err != nil {
+				fmt.Println("failed to read patch file:", err)
+				os.Exit(1)
+			}
+			req.DataPatch = string(patch)
+		}
+		// Большая DLQ возвращается за несколько вызовов: каждый продолжает с next_page_token предыдущего
+		var redriven int64
+		var duplicates []string
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			resp, err := client.RedriveDLQ(ctx, req)
+			cancel()
+			if err != nil {
+				fmt.Println("failed to redrive DLQ after", redriven, "tasks:", err)
+				os.Exit(1)
+			}
+			redriven += resp.RedrivenCount
+			duplicates = append(duplicates, resp.DuplicateTaskIds...)
+			if resp.NextPageToken == "" {
+				break
+			}
+			fmt.Println("Redriven so far:", redriven)
+			req.PageToken = resp.NextPageToken
+		}
+		fmt.Println("DLQ redriven! Tasks:", redriven)
+		if len(duplicates) > 0 {
+			fmt.Println("Left in DLQ, the executor already has tasks with their idempotency keys:", strings.Join(duplicates, ", "))
+		}
+	case "list-dlqs":
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		resp, err := client.ListDLQs(ctx, &pb.ListDLQsRequest{})
+		if err != nil {
+			fmt.Println("failed to list DLQs:", err)
+			os.Exit(1)
+		}
+		for _, queue := range resp.Queues {
+			fmt.Printf("%s\t%d\t%s\t%s\n", queue.Name, queue.Size, strings.Join(queue.Executors, ","), queue.OldestEntryAt.AsTime().Format(time.RFC3339))
+		}
+	case "list-workers":
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		resp, err := client.ListWorkers(ctx, &pb.ListWorkersRequest{ExecutorName: *name})
+		if err != nil {
+			fmt.Println("failed to list workers:", err)
+			os.Exit(1)
+		}
+		for _, worker := range resp.Workers {
+			fmt.Printf("%s\t%s\t%s\t%s\t%d/%d\t%s\n", worker.Id, worker.ExecutorName, worker.Hostname, worker.Version, worker.ActiveTasks, worker.Concurrency, worker.LastHeartbeatAt.AsTime().Format(time.RFC3339))
+		}
 	case "list-executors":
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
-		resp, err := client.ListExecutors(ctx, &pb.ListExecutorsRequest{})
+		resp, err := client.ListExecutors(ctx, &pb.ListExecutorsRequest{Deleted: *deleted})
 		if err != nil {
 			fmt.Println("failed to list executors:", err)
 			os.Exit(1)
@@ -89,8 +328,179 @@ func main() {
 			b, _ := json.MarshalIndent(exec, "", "  ")
 			fmt.Println(string(b))
 		}
+	case "add-schedule":
+		if *scheduleName == "" || *name == "" || *cronExpr == "" {
+			fmt.Println("--schedule, --name and --cron required")
+			os.Exit(1)
+		}
+		var data []byte
+		if *taskFile != "" {
+			data, err = os.ReadFile(*taskFile)
+			if err != nil {
+				fmt.Println("failed to read task file:", err)
+				os.Exit(1)
+			}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		resp, err := client.CreateSchedule(ctx, &pb.CreateScheduleRequest{
+			Schedule: &pb.Schedule{
+				Name:           *scheduleName,
+				CronExpression: *cronExpr,
+				Timezone:       *timezone,
+				ExecutorName:   *name,
+				Data:           data,
+				Metadata:       map[string]string{},
+			},
+		})
+		if err != nil {
+			fmt.Println("failed to create schedule:", err)
+			os.Exit(1)
+		}
+		fmt.Println("Schedule created! Next run at:", resp.Schedule.NextRunAt.AsTime().Format(time.RFC3339))
+	case "list-schedules":
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		resp, err := client.ListSchedules(ctx, &pb.ListSchedulesRequest{ExecutorName: *name})
+		if err != nil {
+			fmt.Println("failed to list schedules:", err)
+			os.Exit(1)
+		}
+		for _, schedule := range resp.Schedules {
+			b, _ := json.MarshalIndent(schedule, "", "  ")
+			fmt.Println(string(b))
+		}
+	case "delete-schedule":
+		if *scheduleName == "" {
+			fmt.Println("--schedule required")
+			os.Exit(1)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := client.DeleteSchedule(ctx, &pb.DeleteScheduleRequest{Name: *scheduleName}); err != nil {
+			fmt.Println("failed to delete schedule:", err)
+			os.Exit(1)
+		}
+		fmt.Println("Schedule deleted!")
+	case "pause-schedule", "resume-schedule":
+		if *scheduleName == "" {
+			fmt.Println("--schedule required")
+			os.Exit(1)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, err := client.PauseSchedule(ctx, &pb.PauseScheduleRequest{
+			Name:   *scheduleName,
+			Paused: *cmd == "pause-schedule",
+		})
+		if err != nil {
+			fmt.Println("failed to update schedule:", err)
+			os.Exit(1)
+		}
+		fmt.Println("Schedule updated!")
+	case "delete-executor":
+		if *name == "" {
+			fmt.Println("--name required")
+			os.Exit(1)
+		}
+		p, ok := pb.ExecutorDeletionPolicy_value["EXECUTOR_DELETION_POLICY_"+strings.ToUpper(*policy)]
+		if !ok {
+			fmt.Println("unknown --policy:", *policy)
+			os.Exit(1)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		resp, err := client.DeleteExecutor(ctx, &pb.DeleteExecutorRequest{
+			Id:             *name,
+			Policy:         pb.ExecutorDeletionPolicy(p),
+			TargetExecutor: *target,
+			Reason:         *reason,
+		})
+		if err != nil {
+			fmt.Println("failed to delete executor:", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Executor deleted, tasks affected: %d, can be restored until %s\n",
+			resp.AffectedTasks, resp.RestoreUntil.AsTime().Local().Format(time.RFC3339))
+	case "undelete-executor":
+		if *name == "" {
+			fmt.Println("--name required")
+			os.Exit(1)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := client.UndeleteExecutor(ctx, &pb.UndeleteExecutorRequest{Name: *name}); err != nil {
+			fmt.Println("failed to undelete executor:", err)
+			os.Exit(1)
+		}
+		fmt.Println("Executor restored!")
+	case "pause-executor", "resume-executor", "drain-executor", "disable-executor":
+		if *name == "" {
+			fmt.Println("--name required")
+			os.Exit(1)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		var err error
+		switch *cmd {
+		case "pause-executor":
+			_, err = client.PauseExecutor(ctx, &pb.PauseExecutorRequest{Name: *name})
+		case "resume-executor":
+			_, err = client.ResumeExecutor(ctx, &pb.ResumeExecutorRequest{Name: *name})
+		case "drain-executor":
+			var resp *pb.DrainExecutorResponse
+			resp, err = client.DrainExecutor(ctx, &pb.DrainExecutorRequest{Name: *name})
+			if err == nil {
+				fmt.Println("Tasks remaining:", resp.RemainingTasks)
+			}
+		case "disable-executor":
+			_, err = client.DisableExecutor(ctx, &pb.DisableExecutorRequest{Name: *name})
+		}
+		if err != nil {
+			fmt.Println("failed to update executor:", err)
+			os.Exit(1)
+		}
+		fmt.Println("Executor updated!")
+	case "list-revisions":
+		if *name == "" {
+			fmt.Println("--name required")
+			os.Exit(1)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		resp, err := client.ListExecutorRevisions(ctx, &pb.ListExecutorRevisionsRequest{
+			Name:      *name,
+			PageSize:  int32(*pageSize),
+			PageToken: *pageToken,
+		})
+		if err != nil {
+			fmt.Println("failed to list revisions:", err)
+			os.Exit(1)
+		}
+		for _, rev := range resp.Revisions {
+			action := strings.ToLower(strings.TrimPrefix(rev.Action.String(), "EXECUTOR_REVISION_ACTION_"))
+			fmt.Printf("%s\t%s\t%s\t%s\n", rev.Id, rev.CreatedAt.AsTime().Local().Format(time.RFC3339), action, rev.Actor)
+			for _, change := range rev.Changes {
+				fmt.Printf("\t%s: %s -> %s\n", change.Field, change.OldValue, change.NewValue)
+			}
+		}
+		if resp.NextPageToken != "" {
+			fmt.Println("Next page token:", resp.NextPageToken)
+		}
+	case "rollback-executor":
+		if *name == "" || *revision == "" || *version == 0 {
+			fmt.Println("--name, --revision and --version required")
+			os.Exit(1)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := client.RollbackExecutor(ctx, &pb.RollbackExecutorRequest{Name: *name, RevisionId: *revision, Version: *version}); err != nil {
+			fmt.Println("failed to roll back executor:", err)
+			os.Exit(1)
+		}
+		fmt.Println("Executor rolled back!")
 	default:
-		fmt.Println("Unknown or missing --cmd. Use: add-executor | add-task | list-executors")
+		fmt.Println("Unknown or missing --cmd. Use: add-executor | add-task | cancel-task | get-task | list-tasks | list-dlq | dlq-summary | purge-dlq | redrive-dlq | list-dlqs | list-executors | delete-executor | undelete-executor | pause-executor | resume-executor | drain-executor | disable-executor | list-revisions | rollback-executor | list-workers | add-schedule | list-schedules | delete-schedule | pause-schedule | resume-schedule")
 		os.Exit(1)
 	}
 }
@@ -8,6 +8,8 @@ import (
 
 	pb "github.com/botashev/tasks-executor/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func main() {
@@ -23,6 +25,9 @@ func main() {
 	client := pb.NewTaskExecutorManagerClient(conn)
 
 	leaderID := "leader-1"
+	hostname, _ := os.Hostname()
+	// Обработчики, для которых лидер зарегистрирован как процесс-обработчик
+	registered := map[string]bool{}
 	// Пример: опрашиваем список обработчиков и забираем задачи
 	for {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -37,23 +42,44 @@ func main() {
 			if !exec.Enabled {
 				continue
 			}
+			workerID := leaderID + "-" + exec.Name
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			if registered[exec.Name] {
+				_, err = client.HeartbeatWorker(ctx, &pb.HeartbeatWorkerRequest{WorkerId: workerID})
+				if status.Code(err) == codes.NotFound {
+					registered[exec.Name] = false
+				}
+			}
+			if !registered[exec.Name] {
+				_, err = client.RegisterExecutor(ctx, &pb.RegisterExecutorRequest{
+					ExecutorName: exec.Name,
+					WorkerId:     workerID,
+					Hostname:     hostname,
+				})
+				registered[exec.Name] = err == nil
+			}
+			cancel()
+			if err != nil {
+				log.Printf("failed to register with executor %s: %v", exec.Name, err)
+				continue
+			}
+
+			ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
 			taskResp, err := client.GetNextTask(ctx, &pb.GetNextTaskRequest{
 				ExecutorName: exec.Name,
-				LeaderId:     leaderID,
 			})
 			cancel()
 			if err != nil {
 				continue
 			}
-			log.Printf("Got task for executor %s: %s", exec.Name, taskResp.TaskId)
+			log.Printf("Got task for executor %s: %s", exec.Name, taskResp.Task.Id)
 			// Здесь должен быть вызов обработчика задачи (SDK)
 			// После выполнения задачи — сообщить менеджеру о статусе
 			ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
 			_, err = client.UpdateTaskStatus(ctx, &pb.UpdateTaskStatusRequest{
-				TaskId:       taskResp.TaskId,
-				Status:       pb.TaskStatus_COMPLETED, // или FAILED
-				ErrorMessage: "",
+				Id:      taskResp.Task.Id,
+				LeaseId: taskResp.Task.LeaseId,
+				Status:  pb.TaskStatus_TASK_STATUS_COMPLETED, // или FAILED
 			})
 			cancel()
 			if err != nil {
@@ -1,11 +1,15 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,13 +18,17 @@ import (
 	"github.com/botashev/tasks-executor/pkg/manager"
 	"github.com/botashev/tasks-executor/pkg/storage"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, If-Match, X-Actor")
 
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
@@ -31,6 +39,351 @@ func corsMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// httpStatusFromError переводит gRPC-код ошибки сервиса в HTTP-статус
+func httpStatusFromError(err error) int {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted, codes.FailedPrecondition:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+/*
+listTasksRequestFromQuery разбирает параметры GET /tasks:
+executor, status (можно повторять, например status=pending&status=failed),
+created_after, created_before, updated_after, updated_before (RFC3339),
+metadata=ключ:значение (можно повторять), page_size, page_token.
+*/
+func listTasksRequestFromQuery(q url.Values) (*pb.ListTasksRequest, error) {
+	req := &pb.ListTasksRequest{
+		ExecutorName: q.Get("executor"),
+		PageToken:    q.Get("page_token"),
+	}
+	for _, s := range q["status"] {
+		st, ok := pb.TaskStatus_value["TASK_STATUS_"+strings.ToUpper(s)]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown status %q", s)
+		}
+		req.Statuses = append(req.Statuses, pb.TaskStatus(st))
+	}
+	for name, dst := range map[string]**timestamppb.Timestamp{
+		"created_after":  &req.CreatedAfter,
+		"created_before": &req.CreatedBefore,
+		"updated_after":  &req.UpdatedAfter,
+		"updated_before": &req.UpdatedBefore,
+	} {
+		if v := q.Get(name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid %s: %v", name, err)
+			}
+			*dst = timestamppb.New(t)
+		}
+	}
+	for _, kv := range q["metadata"] {
+		key, value, ok := strings.Cut(kv, ":")
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "metadata must be key:value, got %q", kv)
+		}
+		if req.Metadata == nil {
+			req.Metadata = map[string]string{}
+		}
+		req.Metadata[key] = value
+	}
+	if v := q.Get("page_size"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page_size: %v", err)
+		}
+		req.PageSize = int32(n)
+	}
+	return req, nil
+}
+
+// dlqFilterFromQuery разбирает фильтр DLQ: failed_after, failed_before (RFC3339),
+// error (подстрока ошибки), error_group (группа из сводки), metadata=ключ:значение (можно повторять)
+func dlqFilterFromQuery(q url.Values) (*pb.DLQFilter, error) {
+	filter := &pb.DLQFilter{ErrorContains: q.Get("error"), ErrorGroup: q.Get("error_group")}
+	for name, dst := range map[string]**timestamppb.Timestamp{
+		"failed_after":  &filter.FailedAfter,
+		"failed_before": &filter.FailedBefore,
+	} {
+		if v := q.Get(name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid %s: %v", name, err)
+			}
+			*dst = timestamppb.New(t)
+		}
+	}
+	for _, kv := range q["metadata"] {
+		key, value, ok := strings.Cut(kv, ":")
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "metadata must be key:value, got %q", kv)
+		}
+		if filter.Metadata == nil {
+			filter.Metadata = map[string]string{}
+		}
+		filter.Metadata[key] = value
+	}
+	return filter, nil
+}
+
+// dlqScope — задачи DLQ одного обработчика или одной именованной очереди
+type dlqScope struct {
+	executor string
+	queue    string
+}
+
+func (s dlqScope) name() string {
+	if s.queue != "" {
+		return s.queue
+	}
+	return s.executor
+}
+
+func isExecutorStateAction(action string) bool {
+	switch action {
+	case "pause", "resume", "drain", "disable", "undelete":
+		return true
+	}
+	return false
+}
+
+/*
+serveExecutorState переключает состояние обработчика:
+POST /executors/{name}/pause, /resume, /drain, /disable, а также
+восстанавливает удалённый обработчик: POST /executors/{name}/undelete.
+*/
+func serveExecutorState(w http.ResponseWriter, r *http.Request, service *manager.Service, name, action string) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var resp interface{}
+	var err error
+	switch action {
+	case "pause":
+		resp, err = service.PauseExecutor(r.Context(), &pb.PauseExecutorRequest{Name: name})
+	case "resume":
+		resp, err = service.ResumeExecutor(r.Context(), &pb.ResumeExecutorRequest{Name: name})
+	case "drain":
+		resp, err = service.DrainExecutor(r.Context(), &pb.DrainExecutorRequest{Name: name})
+	case "disable":
+		resp, err = service.DisableExecutor(r.Context(), &pb.DisableExecutorRequest{Name: name})
+	case "undelete":
+		resp, err = service.UndeleteExecutor(r.Context(), &pb.UndeleteExecutorRequest{Name: name})
+	}
+	if err != nil {
+		log.Printf("Error changing state of executor %s: %v", name, err)
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+/*
+serveExecutorRevisions обслуживает историю изменений обработчика:
+GET /executors/{name}/revisions - ревизии от новых к старым (page_size, page_token)
+POST /executors/{name}/rollback - откат к ревизии, тело — RollbackExecutorRequest
+*/
+func serveExecutorRevisions(w http.ResponseWriter, r *http.Request, service *manager.Service, name, action string) {
+	var resp interface{}
+	var err error
+	switch {
+	case action == "revisions" && r.Method == http.MethodGet:
+		q := r.URL.Query()
+		req := &pb.ListExecutorRevisionsRequest{Name: name, PageToken: q.Get("page_token")}
+		if v := q.Get("page_size"); v != "" {
+			n, convErr := strconv.Atoi(v)
+			if convErr != nil {
+				http.Error(w, "invalid page_size", http.StatusBadRequest)
+				return
+			}
+			req.PageSize = int32(n)
+		}
+		resp, err = service.ListExecutorRevisions(r.Context(), req)
+	case action == "rollback" && r.Method == http.MethodPost:
+		var req pb.RollbackExecutorRequest
+		if decodeErr := json.NewDecoder(r.Body).Decode(&req); decodeErr != nil {
+			http.Error(w, decodeErr.Error(), http.StatusBadRequest)
+			return
+		}
+		req.Name = name
+		resp, err = service.RollbackExecutor(r.Context(), &req)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err != nil {
+		log.Printf("Error serving %s of executor %s: %v", action, name, err)
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// withActor передаёт сервису, кто делает запрос: заголовок X-Actor,
+// а без него — адрес клиента. Попадает в историю изменений обработчиков
+func withActor(r *http.Request) *http.Request {
+	actor := r.Header.Get("X-Actor")
+	if actor == "" {
+		actor = r.RemoteAddr
+	}
+	ctx := metadata.NewIncomingContext(r.Context(), metadata.Pairs(manager.ActorMetadataKey, actor))
+	return r.WithContext(ctx)
+}
+
+/*
+serveDLQ обслуживает задачи DLQ обработчика (/executors/{name}/dlq)
+или именованной очереди (/dlqs/{name}/tasks). Для пути {base}:
+GET {base} - страница задач (page_size, page_token и фильтр)
+DELETE {base} - удаление задач, подходящих под фильтр
+GET {base}/export - выгрузка всех задач, подходящих под фильтр, в NDJSON
+GET {base}/summary - группы задач по нормализованной ошибке (sample_size и фильтр)
+POST {base}/redrive - возврат задач в очередь, тело — RedriveDLQRequest (page_token — продолжение)
+action — часть пути после {base}: "", "export", "summary" или "redrive".
+*/
+func serveDLQ(w http.ResponseWriter, r *http.Request, service *manager.Service, scope dlqScope, action string) {
+	filter, err := dlqFilterFromQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		req := &pb.ListDLQTasksRequest{
+			ExecutorName: scope.executor,
+			QueueName:    scope.queue,
+			Filter:       filter,
+			PageToken:    r.URL.Query().Get("page_token"),
+		}
+		if v := r.URL.Query().Get("page_size"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				http.Error(w, "invalid page_size", http.StatusBadRequest)
+				return
+			}
+			req.PageSize = int32(n)
+		}
+		resp, err := service.ListDLQTasks(r.Context(), req)
+		if err != nil {
+			log.Printf("Error listing DLQ tasks: %v", err)
+			http.Error(w, err.Error(), httpStatusFromError(err))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	case action == "" && r.Method == http.MethodDelete:
+		resp, err := service.PurgeDLQ(r.Context(), &pb.PurgeDLQRequest{
+			ExecutorName: scope.executor,
+			QueueName:    scope.queue,
+			Filter:       filter,
+		})
+		if err != nil {
+			log.Printf("Error purging DLQ: %v", err)
+			http.Error(w, err.Error(), httpStatusFromError(err))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	case action == "export" && r.Method == http.MethodGet:
+		exportDLQ(w, r, service, scope, filter)
+	case action == "summary" && r.Method == http.MethodGet:
+		req := &pb.DLQSummaryRequest{
+			ExecutorName: scope.executor,
+			QueueName:    scope.queue,
+			Filter:       filter,
+		}
+		if v := r.URL.Query().Get("sample_size"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				http.Error(w, "invalid sample_size", http.StatusBadRequest)
+				return
+			}
+			req.SampleSize = int32(n)
+		}
+		resp, err := service.DLQSummary(r.Context(), req)
+		if err != nil {
+			log.Printf("Error summarizing DLQ: %v", err)
+			http.Error(w, err.Error(), httpStatusFromError(err))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	case action == "redrive" && r.Method == http.MethodPost:
+		var req pb.RedriveDLQRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Printf("Error decoding request: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		req.ExecutorName = scope.executor
+		req.QueueName = scope.queue
+		resp, err := service.RedriveDLQ(r.Context(), &req)
+		if err != nil {
+			log.Printf("Error redriving DLQ: %v", err)
+			http.Error(w, err.Error(), httpStatusFromError(err))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	case action == "" || action == "export" || action == "summary" || action == "redrive":
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// exportDLQ выгружает DLQ постранично, по задаче в строке, не загружая её в память целиком
+func exportDLQ(w http.ResponseWriter, r *http.Request, service *manager.Service, scope dlqScope, filter *pb.DLQFilter) {
+	req := &pb.ListDLQTasksRequest{ExecutorName: scope.executor, QueueName: scope.queue, Filter: filter, PageSize: 500}
+	resp, err := service.ListDLQTasks(r.Context(), req)
+	if err != nil {
+		log.Printf("Error exporting DLQ: %v", err)
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", scope.name()+"_dlq.ndjson"))
+	enc := json.NewEncoder(w)
+	flusher, _ := w.(http.Flusher)
+	for {
+		for _, task := range resp.Tasks {
+			if err := enc.Encode(task); err != nil {
+				log.Printf("Error exporting DLQ: %v", err)
+				return
+			}
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		if resp.NextPageToken == "" {
+			return
+		}
+		req.PageToken = resp.NextPageToken
+		// Заголовки уже отправлены, поэтому ошибку остаётся только записать в лог
+		if resp, err = service.ListDLQTasks(r.Context(), req); err != nil {
+			log.Printf("Error exporting DLQ: %v", err)
+			return
+		}
+	}
+}
+
 func connectToMongoDB(mongoURI string, maxRetries int) (storage.Storage, error) {
 	var store storage.Storage
 	var err error
@@ -42,6 +395,10 @@ func connectToMongoDB(mongoURI string, maxRetries int) (storage.Storage, error)
 			ExecutorsColl: "executors",
 			TasksColl:     "tasks",
 			DLQColl:       "dlq",
+			SchedulesColl: "schedules",
+			WorkersColl:   "workers",
+			ArchiveColl:   "archived_tasks",
+			RevisionsColl: "executor_revisions",
 		}
 		store, err = storage.NewMongoStorage(storageConfig)
 		if err == nil {
@@ -53,6 +410,21 @@ func connectToMongoDB(mongoURI string, maxRetries int) (storage.Storage, error)
 	return nil, err
 }
 
+// durationFromEnv читает длительность из переменной окружения, а при её отсутствии
+// или ошибке разбора возвращает значение по умолчанию
+func durationFromEnv(name string, def time.Duration) time.Duration {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		log.Printf("Invalid %s %q, using %s", name, v, def)
+		return def
+	}
+	return d
+}
+
 func main() {
 	mongoURI := os.Getenv("MONGO_URI")
 	if mongoURI == "" {
@@ -69,6 +441,27 @@ func main() {
 	service := manager.NewService(store)
 	pb.RegisterTaskExecutorManagerServer(grpcServer, service)
 
+	// Возвращаем в очередь задачи, аренда которых истекла
+	go service.RunLeaseReaper(context.Background(), durationFromEnv("LEASE_REAPER_INTERVAL", 30*time.Second))
+	// Ставим задачи по расписаниям
+	go service.RunScheduler(context.Background(), durationFromEnv("SCHEDULER_INTERVAL", 5*time.Second))
+	// Повышаем приоритет долго ожидающих задач
+	go service.RunPriorityAging(context.Background(), durationFromEnv("PRIORITY_AGING_INTERVAL", 30*time.Second))
+	// Дозавершаем переносы в DLQ, прерванные падением (для MongoDB без транзакций)
+	go service.RunPendingOpsReplay(context.Background(), durationFromEnv("PENDING_OPS_REPLAY_INTERVAL", time.Minute))
+	// Удаляем из DLQ устаревшие и лишние задачи согласно настройкам обработчиков
+	archiveDir := os.Getenv("DLQ_ARCHIVE_DIR")
+	if archiveDir == "" {
+		archiveDir = "dlq-archive"
+	}
+	go service.RunDLQRetention(context.Background(), durationFromEnv("DLQ_RETENTION_INTERVAL", time.Minute), archiveDir)
+	// Будим ожидающих задач обработчиков, когда задачи появляются через другие реплики менеджера
+	go service.RunTaskNotifications(context.Background())
+	// Окончательно удаляем обработчики, удалённые больше 7 дней назад
+	go service.RunExecutorPurge(context.Background(), durationFromEnv("EXECUTOR_PURGE_INTERVAL", time.Hour))
+	// Помечаем мёртвыми процессы-обработчики, переставшие присылать heartbeat
+	go service.RunWorkerReaper(context.Background(), durationFromEnv("WORKER_REAPER_INTERVAL", 10*time.Second), durationFromEnv("WORKER_TIMEOUT", 30*time.Second))
+
 	listener, err := net.Listen("tcp", ":50051")
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
@@ -96,8 +489,8 @@ func main() {
 		api.HandleFunc("/executors", func(w http.ResponseWriter, r *http.Request) {
 			log.Printf("Received request: %s %s", r.Method, r.URL.Path)
 			if r.Method == http.MethodGet {
-				// Get all executors
-				resp, err := service.ListExecutors(r.Context(), &pb.ListExecutorsRequest{})
+				// Get all executors, ?deleted=true — удалённые, которые ещё можно восстановить
+				resp, err := service.ListExecutors(r.Context(), &pb.ListExecutorsRequest{Deleted: r.URL.Query().Get("deleted") == "true"})
 				if err != nil {
 					log.Printf("Error listing executors: %v", err)
 					http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -130,7 +523,7 @@ func main() {
 			log.Printf("Received request: %s %s", r.Method, r.URL.Path)
 			if r.URL.Path == "/executors/" {
 				if r.Method == http.MethodGet {
-					resp, err := service.ListExecutors(r.Context(), &pb.ListExecutorsRequest{})
+					resp, err := service.ListExecutors(r.Context(), &pb.ListExecutorsRequest{Deleted: r.URL.Query().Get("deleted") == "true"})
 					if err != nil {
 						log.Printf("Error listing executors: %v", err)
 						http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -151,6 +544,24 @@ func main() {
 				http.Error(w, "Executor id required", http.StatusBadRequest)
 				return
 			}
+			// /executors/{name}/dlq и /executors/{name}/dlq/export
+			if name, rest, ok := strings.Cut(id, "/"); ok {
+				if isExecutorStateAction(rest) {
+					serveExecutorState(w, r, service, name, rest)
+					return
+				}
+				if rest == "revisions" || rest == "rollback" {
+					serveExecutorRevisions(w, r, service, name, rest)
+					return
+				}
+				if rest != "dlq" && !strings.HasPrefix(rest, "dlq/") {
+					http.NotFound(w, r)
+					return
+				}
+				action := strings.TrimPrefix(strings.TrimPrefix(rest, "dlq"), "/")
+				serveDLQ(w, r, service, dlqScope{executor: name}, action)
+				return
+			}
 			log.Printf("Processing request for executor: %s", id)
 
 			switch r.Method {
@@ -162,8 +573,11 @@ func main() {
 					return
 				}
 				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("ETag", strconv.Quote(strconv.FormatInt(resp.Executor.GetConfig().GetVersion(), 10)))
 				json.NewEncoder(w).Encode(resp)
-			case http.MethodPut:
+			case http.MethodPatch, http.MethodPut:
+				// PATCH меняет только поля из update_mask, PUT и PATCH без маски — все поля.
+				// Версию можно передать в теле (version) или в заголовке If-Match
 				var req pb.UpdateExecutorRequest
 				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 					log.Printf("Error decoding request: %v", err)
@@ -171,29 +585,166 @@ func main() {
 					return
 				}
 				req.Id = id
+				if req.Version == 0 {
+					if etag, err := strconv.Unquote(r.Header.Get("If-Match")); err == nil {
+						req.Version, _ = strconv.ParseInt(etag, 10, 64)
+					}
+				}
 				updated, err := service.UpdateExecutor(r.Context(), &req)
 				if err != nil {
 					log.Printf("Error updating executor: %v", err)
-					http.Error(w, err.Error(), http.StatusInternalServerError)
+					http.Error(w, err.Error(), httpStatusFromError(err))
 					return
 				}
 				w.Header().Set("Content-Type", "application/json")
 				json.NewEncoder(w).Encode(updated)
 			case http.MethodDelete:
-				_, err := service.DeleteExecutor(r.Context(), &pb.DeleteExecutorRequest{Id: id})
+				// ?policy=refuse|cancel|move|archive, target — получатель задач при move, reason — при cancel
+				q := r.URL.Query()
+				req := &pb.DeleteExecutorRequest{Id: id, TargetExecutor: q.Get("target"), Reason: q.Get("reason")}
+				if p := q.Get("policy"); p != "" {
+					policy, ok := pb.ExecutorDeletionPolicy_value["EXECUTOR_DELETION_POLICY_"+strings.ToUpper(p)]
+					if !ok {
+						http.Error(w, "unknown policy: "+p, http.StatusBadRequest)
+						return
+					}
+					req.Policy = pb.ExecutorDeletionPolicy(policy)
+				}
+				resp, err := service.DeleteExecutor(r.Context(), req)
 				if err != nil {
 					log.Printf("Error deleting executor: %v", err)
-					http.Error(w, err.Error(), http.StatusInternalServerError)
+					http.Error(w, err.Error(), httpStatusFromError(err))
 					return
 				}
-				w.WriteHeader(http.StatusNoContent)
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(resp)
+			}
+		})
+
+		// Именованные очереди DLQ: GET /dlqs, GET /dlqs/{name}, /dlqs/{name}/tasks[/export|/summary|/redrive]
+		api.HandleFunc("/dlqs", func(w http.ResponseWriter, r *http.Request) {
+			log.Printf("Received request: %s %s", r.Method, r.URL.Path)
+			if r.Method != http.MethodGet {
+				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			resp, err := service.ListDLQs(r.Context(), &pb.ListDLQsRequest{})
+			if err != nil {
+				log.Printf("Error listing DLQs: %v", err)
+				http.Error(w, err.Error(), httpStatusFromError(err))
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(resp)
+		})
+
+		api.HandleFunc("/dlqs/", func(w http.ResponseWriter, r *http.Request) {
+			log.Printf("Received request: %s %s", r.Method, r.URL.Path)
+			name, rest, _ := strings.Cut(strings.Trim(strings.TrimPrefix(r.URL.Path, "/dlqs/"), "/"), "/")
+			if name == "" {
+				http.NotFound(w, r)
+				return
+			}
+			if rest == "" {
+				if r.Method != http.MethodGet {
+					http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+					return
+				}
+				resp, err := service.GetDLQ(r.Context(), &pb.GetDLQRequest{Name: name})
+				if err != nil {
+					log.Printf("Error getting DLQ: %v", err)
+					http.Error(w, err.Error(), httpStatusFromError(err))
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(resp)
+				return
+			}
+			if rest != "tasks" && !strings.HasPrefix(rest, "tasks/") {
+				http.NotFound(w, r)
+				return
+			}
+			action := strings.TrimPrefix(strings.TrimPrefix(rest, "tasks"), "/")
+			serveDLQ(w, r, service, dlqScope{queue: name}, action)
+		})
+
+		// GET /workers?executor=...&include_dead=true
+		api.HandleFunc("/workers", func(w http.ResponseWriter, r *http.Request) {
+			log.Printf("Received request: %s %s", r.Method, r.URL.Path)
+			if r.Method != http.MethodGet {
+				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			resp, err := service.ListWorkers(r.Context(), &pb.ListWorkersRequest{
+				ExecutorName: r.URL.Query().Get("executor"),
+				IncludeDead:  r.URL.Query().Get("include_dead") == "true",
+			})
+			if err != nil {
+				log.Printf("Error listing workers: %v", err)
+				http.Error(w, err.Error(), httpStatusFromError(err))
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(resp)
+		})
+
+		api.HandleFunc("/tasks", func(w http.ResponseWriter, r *http.Request) {
+			log.Printf("Received request: %s %s", r.Method, r.URL.Path)
+			if r.Method != http.MethodGet {
+				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			req, err := listTasksRequestFromQuery(r.URL.Query())
+			if err != nil {
+				http.Error(w, err.Error(), httpStatusFromError(err))
+				return
+			}
+			resp, err := service.ListTasks(r.Context(), req)
+			if err != nil {
+				log.Printf("Error listing tasks: %v", err)
+				http.Error(w, err.Error(), httpStatusFromError(err))
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(resp)
+		})
+
+		api.HandleFunc("/tasks/", func(w http.ResponseWriter, r *http.Request) {
+			log.Printf("Received request: %s %s", r.Method, r.URL.Path)
+			// POST /tasks/{id}/cancel
+			parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/tasks/"), "/"), "/")
+			if len(parts) != 2 || parts[0] == "" || parts[1] != "cancel" {
+				http.NotFound(w, r)
+				return
+			}
+			if r.Method != http.MethodPost {
+				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+
+			var req pb.CancelTaskRequest
+			if r.ContentLength > 0 {
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					log.Printf("Error decoding request: %v", err)
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+			}
+			req.Id = parts[0]
+			resp, err := service.CancelTask(r.Context(), &req)
+			if err != nil {
+				log.Printf("Error cancelling task: %v", err)
+				http.Error(w, err.Error(), httpStatusFromError(err))
+				return
 			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(resp)
 		})
 
 		// Mount API routes with logging
 		apiHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			log.Printf("API request received: %s %s", r.Method, r.URL.Path)
-			http.StripPrefix("/api/v1", corsMiddleware(api)).ServeHTTP(w, r)
+			http.StripPrefix("/api/v1", corsMiddleware(api)).ServeHTTP(w, withActor(r))
 		})
 		mux.Handle("/api/v1/", apiHandler)
 
@@ -14,6 +14,19 @@ async function fetchExecutors() {
     }
 }
 
+// Живые процессы-обработчики всех обработчиков
+async function fetchWorkers() {
+    try {
+        const response = await fetch(`${API_BASE}/workers`);
+        if (!response.ok) throw new Error('Failed to fetch workers');
+        const data = await response.json();
+        return data.workers || [];
+    } catch (error) {
+        console.error('Error fetching workers:', error);
+        return [];
+    }
+}
+
 async function createExecutor(config) {
     try {
         console.log('Creating executor with config:', config);
@@ -36,13 +49,19 @@ async function createExecutor(config) {
     }
 }
 
-async function updateExecutor(executorId, config) {
+// Частичное обновление: меняются только переданные поля config, version — версия,
+// которую видел пользователь; если обработчик изменили после этого, сервер вернёт 409
+async function updateExecutor(executorId, config, version) {
     try {
+        const update_mask = { paths: Object.keys(config).filter(key => key !== 'name') };
         const response = await fetch(`${API_BASE}/executors/${executorId}`, {
-            method: 'PUT',
+            method: 'PATCH',
             headers: { 'Content-Type': 'application/json' },
-            body: JSON.stringify({ config })
+            body: JSON.stringify({ config, version, update_mask })
         });
+        if (response.status === 409) {
+            throw new Error('the executor was changed by someone else, reopen it to see the latest settings');
+        }
         if (!response.ok) throw new Error('Failed to update executor');
         return await response.json();
     } catch (error) {
@@ -69,6 +88,19 @@ async function getExecutor(name) {
     }
 }
 
+// Размер DLQ обработчика и возраст самой старой задачи
+async function getDLQStats(name) {
+    try {
+        const response = await fetch(`${API_BASE}/executors/${name}`);
+        if (!response.ok) throw new Error('Failed to fetch DLQ stats');
+        const data = await response.json();
+        return data.dlq_stats || {};
+    } catch (error) {
+        console.error('Error fetching DLQ stats:', error);
+        throw error;
+    }
+}
+
 async function getDLQTasks(executorName) {
     try {
         const response = await fetch(`${API_BASE}/executors/${executorName}/dlq`);
@@ -80,6 +112,11 @@ async function getDLQTasks(executorName) {
     }
 }
 
+// URL выгрузки DLQ в NDJSON (по задаче в строке)
+function getDLQExportUrl(executorName) {
+    return `${API_BASE}/executors/${encodeURIComponent(executorName)}/dlq/export`;
+}
+
 async function clearDLQ(executorName) {
     try {
         const response = await fetch(`${API_BASE}/executors/${executorName}/dlq`, {
@@ -95,9 +132,12 @@ async function clearDLQ(executorName) {
 
 export {
     fetchExecutors,
+    fetchWorkers,
     createExecutor,
     updateExecutor,
     getExecutor,
     getDLQTasks,
+    getDLQStats,
+    getDLQExportUrl,
     clearDLQ
 }; 
\ No newline at end of file
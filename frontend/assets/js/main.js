@@ -1,10 +1,11 @@
-import { fetchExecutors, createExecutor, updateExecutor, getExecutor, getDLQTasks, clearDLQ } from './api.js';
+import { fetchExecutors, fetchWorkers, createExecutor, updateExecutor, getExecutor, getDLQStats, getDLQExportUrl, clearDLQ } from './api.js';
 import { toSnakeCase, updateRetryPolicyPreview, updatePaginationInfo } from './utils.js';
 
 // Current state
 let currentPage = 1;
 const itemsPerPage = 20;
 let currentExecutorId = null;
+let currentExecutorVersion = null;
 let currentStatusFilter = 'all';
 let currentSearchQuery = '';
 let isAddMode = false;
@@ -30,6 +31,8 @@ let dlqSettingsContainer;
 let retryPolicyType;
 let retryPolicyMaxAttempts;
 let retryPolicyInterval;
+let retryPolicyMaxInterval;
+let retryPolicyJitter;
 let retryPolicyPreview;
 let statusFilter;
 let searchInput;
@@ -39,6 +42,14 @@ let dlqModalTitle;
 let dlqDownloadBtn;
 let dlqClearBtn;
 let writeConcern;
+let leaseDuration;
+let dedupWindow;
+let maxInFlight;
+let rateLimitPerSecond;
+let rateLimitBurst;
+let agingThreshold;
+let agingStep;
+let agingMaxPriority;
 
 // Initialize DOM elements
 function initializeDOMElements() {
@@ -61,6 +72,8 @@ function initializeDOMElements() {
     retryPolicyType = document.getElementById('retryPolicyType');
     retryPolicyMaxAttempts = document.getElementById('retryPolicyMaxAttempts');
     retryPolicyInterval = document.getElementById('retryPolicyInterval');
+    retryPolicyMaxInterval = document.getElementById('retryPolicyMaxInterval');
+    retryPolicyJitter = document.getElementById('retryPolicyJitter');
     retryPolicyPreview = document.getElementById('retryPolicyPreview');
     statusFilter = document.getElementById('statusFilter');
     searchInput = document.getElementById('searchInput');
@@ -70,14 +83,39 @@ function initializeDOMElements() {
     dlqDownloadBtn = document.getElementById('dlqDownloadBtn');
     dlqClearBtn = document.getElementById('dlqClearBtn');
     writeConcern = document.getElementById('writeConcern');
+    leaseDuration = document.getElementById('leaseDuration');
+    dedupWindow = document.getElementById('dedupWindow');
+    maxInFlight = document.getElementById('maxInFlight');
+    rateLimitPerSecond = document.getElementById('rateLimitPerSecond');
+    rateLimitBurst = document.getElementById('rateLimitBurst');
+    agingThreshold = document.getElementById('agingThreshold');
+    agingStep = document.getElementById('agingStep');
+    agingMaxPriority = document.getElementById('agingMaxPriority');
+}
+
+// Состояния обработчика (значения ExecutorState) и их бейджи
+const EXECUTOR_STATES = {
+    1: { label: 'Активен', badge: 'bg-green-100 text-green-800' },
+    2: { label: 'Приостановлен', badge: 'bg-yellow-100 text-yellow-800' },
+    3: { label: 'Завершает работу', badge: 'bg-blue-100 text-blue-800' },
+    4: { label: 'Отключён', badge: 'bg-red-100 text-red-800' }
+};
+
+// У обработчиков, созданных до появления состояний, state не задан
+function executorState(executor) {
+    return executor.state || (executor.enabled ? 1 : 4);
+}
+
+function executorStateBadge(executor) {
+    const state = EXECUTOR_STATES[executorState(executor)];
+    return `<span class="px-2 inline-flex text-sm leading-5 font-semibold rounded-full ${state.badge}">${state.label}</span>`;
 }
 
 // Filter executors based on status and search query
 function filterExecutors(executors) {
     return executors.filter(executor => {
         const statusMatch = currentStatusFilter === 'all' || 
-                          (currentStatusFilter === 'enabled' && executor.enabled) ||
-                          (currentStatusFilter === 'disabled' && !executor.enabled);
+                          String(executorState(executor)) === currentStatusFilter;
         
         const searchMatch = currentSearchQuery === '' || 
                           executor.name.toLowerCase().includes(currentSearchQuery);
@@ -90,7 +128,11 @@ function filterExecutors(executors) {
 async function renderExecutors() {
     executorsTableBody.innerHTML = '';
     
-    const executors = await fetchExecutors();
+    const [executors, workers] = await Promise.all([fetchExecutors(), fetchWorkers()]);
+    const workersByExecutor = {};
+    workers.forEach(worker => {
+        (workersByExecutor[worker.executor_name] = workersByExecutor[worker.executor_name] || []).push(worker);
+    });
     const filteredExecutors = filterExecutors(executors);
     const totalFiltered = filteredExecutors.length;
     
@@ -103,14 +145,19 @@ async function renderExecutors() {
         row.className = 'soft-table-row table-row-hover';
         row.style.userSelect = 'none';
         row.dataset.id = executor.id;
+        const executorWorkers = workersByExecutor[executor.name] || [];
+        const workersTitle = executorWorkers
+            .map(worker => `${worker.hostname || worker.id}${worker.version ? ' ' + worker.version : ''}: ${worker.active_tasks || 0}/${worker.concurrency}`)
+            .join('\n');
         row.innerHTML = `
             <td class="px-6 py-4 whitespace-nowrap table-cell font-semibold text-gray-900 flex items-center gap-2">
                 <i class="fas fa-pen text-blue-400 text-lg mr-2"></i>
                 <span>${executor.name}</span>
             </td>
-            <td class="px-6 py-4 whitespace-nowrap table-cell text-gray-700">${executor.enabled 
-                ? '<span class="px-2 inline-flex text-sm leading-5 font-semibold rounded-full bg-green-100 text-green-800">Включён</span>'
-                : '<span class="px-2 inline-flex text-sm leading-5 font-semibold rounded-full bg-red-100 text-red-800">Отключён</span>'}
+            <td class="px-6 py-4 whitespace-nowrap table-cell text-gray-700">${executorStateBadge(executor)}</td>
+            <td class="px-6 py-4 whitespace-nowrap table-cell text-gray-700" title="${workersTitle}">${executorWorkers.length > 0
+                ? `<span class="px-2 inline-flex text-sm leading-5 font-semibold rounded-full bg-green-100 text-green-800">${executorWorkers.length} активн.</span>`
+                : '<span class="px-2 inline-flex text-sm leading-5 font-semibold rounded-full bg-gray-100 text-gray-600">Нет</span>'}
             </td>
             <td class="px-6 py-4 whitespace-nowrap table-cell text-center">
                 <button title="Посмотреть логи"
@@ -164,6 +211,15 @@ async function renderExecutors() {
             e.stopPropagation();
             currentDlqExecutor = this.dataset.name;
             dlqModalTitle.textContent = `DLQ для ${currentDlqExecutor}`;
+            const dlqModalStats = document.getElementById('dlqModalStats');
+            dlqModalStats.textContent = '';
+            getDLQStats(currentDlqExecutor).then(stats => {
+                const size = parseInt(stats.size) || 0;
+                const age = stats.oldest_entry_age ? parseInt(stats.oldest_entry_age.seconds) || 0 : 0;
+                dlqModalStats.textContent = size > 0
+                    ? `Задач: ${size}, самой старой: ${Math.floor(age / 60)} мин`
+                    : 'Очередь пуста';
+            }).catch(() => {});
             dlqModal.classList.remove('hidden');
         });
     });
@@ -193,12 +249,13 @@ async function openSettingsDrawer(executorName) {
         console.log('Opening settings for executor:', executor);
         
         currentExecutorId = executorName;
+        currentExecutorVersion = executor.config && executor.config.version;
         const nameInput = document.getElementById('executorName');
         nameInput.value = executor.name;
         nameInput.readOnly = true;
         nameInput.classList.add('bg-gray-100', 'cursor-not-allowed');
         document.getElementById('executorNameNote').classList.remove('hidden');
-        document.getElementById('enabled').checked = executor.enabled;
+        document.getElementById('executorState').value = String(executorState(executor));
         
         const config = executor.config;
         console.log('Executor config:', config);
@@ -233,19 +290,39 @@ async function openSettingsDrawer(executorName) {
         
         retryPolicyMaxAttempts.value = config.retry_policy.max_attempts || '';
         retryPolicyInterval.value = parseInt(config.retry_policy.interval.seconds) * 1000 || 1000;
+        retryPolicyMaxInterval.value = config.retry_policy.max_interval
+            ? parseInt(config.retry_policy.max_interval.seconds) * 1000 || ''
+            : '';
+        retryPolicyJitter.value = config.retry_policy.jitter || '';
         retryPolicyPreview.innerHTML = updateRetryPolicyPreview(
             retryPolicyTypeValue,
             config.retry_policy.max_attempts,
-            parseInt(config.retry_policy.interval.seconds) * 1000
+            parseInt(config.retry_policy.interval.seconds) * 1000,
+            parseInt(retryPolicyMaxInterval.value),
+            parseFloat(retryPolicyJitter.value)
         );
         
         dlqEnabled.checked = config.dlq_config.enabled;
         toggleDlqSettings(config.dlq_config.enabled);
         document.getElementById('dlqQueueName').value = config.dlq_config.queue_name || '';
+        document.getElementById('dlqFailureHandler').value = config.dlq_config.failure_handler || '';
+        document.getElementById('dlqMaxAge').value = config.dlq_config.max_age ? parseInt(config.dlq_config.max_age.seconds) || '' : '';
+        document.getElementById('dlqMaxEntries').value = config.dlq_config.max_entries || '';
+        document.getElementById('dlqArchive').checked = !!config.dlq_config.archive;
         
         const writeConcernLevel = config.write_concern.level.toString();
         console.log('Setting write concern level:', writeConcernLevel);
         writeConcern.value = writeConcernLevel;
+        leaseDuration.value = config.lease_duration ? parseInt(config.lease_duration.seconds) || '' : '';
+        dedupWindow.value = config.dedup_window ? parseInt(config.dedup_window.seconds) || '' : '';
+        maxInFlight.value = config.max_in_flight || '';
+        const rateLimit = config.rate_limit || {};
+        rateLimitPerSecond.value = rateLimit.tasks_per_second || '';
+        rateLimitBurst.value = rateLimit.burst || '';
+        const aging = config.priority_aging || {};
+        agingThreshold.value = aging.threshold ? parseInt(aging.threshold.seconds) || '' : '';
+        agingStep.value = aging.step || '';
+        agingMaxPriority.value = aging.max_priority || '';
         
         document.getElementById('modalTitle').textContent = `Настройки для ${executor.name}`;
         settingsDrawer.classList.remove('hidden');
@@ -283,27 +360,56 @@ async function saveExecutorSettings() {
 
         const config = {
             name: document.getElementById('executorName').value,
-            enabled: document.getElementById('enabled').checked,
+            enabled: document.getElementById('executorState').value !== '4',
+            state: parseInt(document.getElementById('executorState').value),
             retry_policy: {
                 type: retryPolicyTypeValue,
                 max_attempts: retryPolicyMaxAttempts.value ? parseInt(retryPolicyMaxAttempts.value) : 0,
                 interval: {
                     seconds: parseInt(retryPolicyInterval.value) / 1000
-                }
+                },
+                max_interval: {
+                    seconds: retryPolicyMaxInterval.value ? parseInt(retryPolicyMaxInterval.value) / 1000 : 0
+                },
+                jitter: retryPolicyJitter.value ? parseFloat(retryPolicyJitter.value) : 0
             },
             dlq_config: {
                 enabled: dlqEnabled.checked,
-                queue_name: dlqEnabled.checked ? document.getElementById('dlqQueueName').value : ''
+                queue_name: dlqEnabled.checked ? document.getElementById('dlqQueueName').value : '',
+                failure_handler: dlqEnabled.checked ? document.getElementById('dlqFailureHandler').value.trim() : '',
+                max_age: {
+                    seconds: dlqEnabled.checked && document.getElementById('dlqMaxAge').value ? parseInt(document.getElementById('dlqMaxAge').value) : 0
+                },
+                max_entries: dlqEnabled.checked && document.getElementById('dlqMaxEntries').value ? parseInt(document.getElementById('dlqMaxEntries').value) : 0,
+                archive: dlqEnabled.checked && document.getElementById('dlqArchive').checked
             },
             write_concern: {
                 level: parseInt(document.getElementById('writeConcern').value)
+            },
+            lease_duration: {
+                seconds: leaseDuration.value ? parseInt(leaseDuration.value) : 0
+            },
+            dedup_window: {
+                seconds: dedupWindow.value ? parseInt(dedupWindow.value) : 0
+            },
+            max_in_flight: maxInFlight.value ? parseInt(maxInFlight.value) : 0,
+            rate_limit: {
+                tasks_per_second: rateLimitPerSecond.value ? parseFloat(rateLimitPerSecond.value) : 0,
+                burst: rateLimitBurst.value ? parseInt(rateLimitBurst.value) : 0
+            },
+            priority_aging: {
+                threshold: {
+                    seconds: agingThreshold.value ? parseInt(agingThreshold.value) : 0
+                },
+                step: agingStep.value ? parseInt(agingStep.value) : 0,
+                max_priority: agingMaxPriority.value ? parseInt(agingMaxPriority.value) : 0
             }
         };
 
         if (isAddMode) {
             await createExecutor(config);
         } else {
-            await updateExecutor(currentExecutorId, config);
+            await updateExecutor(currentExecutorId, config, currentExecutorVersion);
         }
 
         alert('Settings saved successfully');
@@ -356,27 +462,20 @@ function setupEventListeners() {
         toggleDlqSettings(e.target.checked);
     });
     
-    retryPolicyType.addEventListener('change', () => {
+    const refreshRetryPolicyPreview = () => {
         retryPolicyPreview.innerHTML = updateRetryPolicyPreview(
             retryPolicyType.value,
             retryPolicyMaxAttempts.value,
-            parseInt(retryPolicyInterval.value)
+            parseInt(retryPolicyInterval.value),
+            parseInt(retryPolicyMaxInterval.value),
+            parseFloat(retryPolicyJitter.value)
         );
-    });
-    retryPolicyMaxAttempts.addEventListener('input', () => {
-        retryPolicyPreview.innerHTML = updateRetryPolicyPreview(
-            retryPolicyType.value,
-            retryPolicyMaxAttempts.value,
-            parseInt(retryPolicyInterval.value)
-        );
-    });
-    retryPolicyInterval.addEventListener('input', () => {
-        retryPolicyPreview.innerHTML = updateRetryPolicyPreview(
-            retryPolicyType.value,
-            retryPolicyMaxAttempts.value,
-            parseInt(retryPolicyInterval.value)
-        );
-    });
+    };
+    retryPolicyType.addEventListener('change', refreshRetryPolicyPreview);
+    retryPolicyMaxAttempts.addEventListener('input', refreshRetryPolicyPreview);
+    retryPolicyInterval.addEventListener('input', refreshRetryPolicyPreview);
+    retryPolicyMaxInterval.addEventListener('input', refreshRetryPolicyPreview);
+    retryPolicyJitter.addEventListener('input', refreshRetryPolicyPreview);
     
     statusFilter.addEventListener('change', (e) => {
         currentStatusFilter = e.target.value;
@@ -397,22 +496,14 @@ function setupEventListeners() {
         }
     });
 
-    dlqDownloadBtn.addEventListener('click', async () => {
-        try {
-            const tasks = await getDLQTasks(currentDlqExecutor);
-            const blob = new Blob([JSON.stringify(tasks, null, 2)], { type: 'application/json' });
-            const url = window.URL.createObjectURL(blob);
-            const a = document.createElement('a');
-            a.href = url;
-            a.download = `${currentDlqExecutor}_dlq.json`;
-            document.body.appendChild(a);
-            a.click();
-            window.URL.revokeObjectURL(url);
-            document.body.removeChild(a);
-        } catch (error) {
-            console.error('Error downloading DLQ:', error);
-            alert('Failed to download DLQ tasks');
-        }
+    dlqDownloadBtn.addEventListener('click', () => {
+        // Файл отдаётся сервером потоком, поэтому большая DLQ не загружается в память страницы
+        const a = document.createElement('a');
+        a.href = getDLQExportUrl(currentDlqExecutor);
+        a.download = `${currentDlqExecutor}_dlq.ndjson`;
+        document.body.appendChild(a);
+        a.click();
+        document.body.removeChild(a);
     });
 
     dlqClearBtn.addEventListener('click', async () => {
@@ -437,20 +528,35 @@ function setupEventListeners() {
     document.querySelector('button.soft-btn').addEventListener('click', () => {
         isAddMode = true;
         currentExecutorId = null;
+        currentExecutorVersion = null;
         const nameInput = document.getElementById('executorName');
         nameInput.value = '';
         nameInput.readOnly = false;
         nameInput.classList.remove('bg-gray-100', 'cursor-not-allowed');
         document.getElementById('executorNameNote').classList.add('hidden');
-        document.getElementById('enabled').checked = true;
+        document.getElementById('executorState').value = '1';
         retryPolicyType.value = 'constant';
         retryPolicyMaxAttempts.value = '';
         retryPolicyInterval.value = 1000;
+        retryPolicyMaxInterval.value = '';
+        retryPolicyJitter.value = '';
         retryPolicyPreview.innerHTML = updateRetryPolicyPreview('constant', '', 1000);
         dlqEnabled.checked = false;
         toggleDlqSettings(false);
         document.getElementById('dlqQueueName').value = '';
+        document.getElementById('dlqFailureHandler').value = '';
+        document.getElementById('dlqMaxAge').value = '';
+        document.getElementById('dlqMaxEntries').value = '';
+        document.getElementById('dlqArchive').checked = false;
         document.getElementById('writeConcern').value = 'replica';
+        leaseDuration.value = '';
+        dedupWindow.value = '';
+        maxInFlight.value = '';
+        rateLimitPerSecond.value = '';
+        rateLimitBurst.value = '';
+        agingThreshold.value = '';
+        agingStep.value = '';
+        agingMaxPriority.value = '';
         document.getElementById('modalTitle').textContent = 'Добавить обработчик';
         settingsDrawer.classList.remove('hidden');
         setTimeout(() => drawerPanel.classList.add('open'), 10);
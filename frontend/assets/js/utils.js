@@ -9,7 +9,7 @@ export function toSnakeCase(str) {
 }
 
 // Update retry policy preview
-export function updateRetryPolicyPreview(type, maxAttempts, interval) {
+export function updateRetryPolicyPreview(type, maxAttempts, interval, maxInterval, jitter) {
     let previewText = '';
     
     if (type === 'constant') {
@@ -20,6 +20,14 @@ export function updateRetryPolicyPreview(type, maxAttempts, interval) {
         previewText = `После каждой неудачи ожидать <strong>${interval}мс × 2^(номер попытки-1)</strong> перед повтором.`;
     }
     
+    if (maxInterval) {
+        previewText += ` Задержка не превысит <strong>${maxInterval}мс</strong>.`;
+    }
+
+    if (jitter) {
+        previewText += ` Каждая задержка случайно изменяется на <strong>±${Math.round(jitter * 100)}%</strong>.`;
+    }
+    
     if (maxAttempts) {
         previewText += ` Будет предпринято до <strong>${maxAttempts}</strong> попыток, затем задача будет считаться неуспешной.`;
     } else {
@@ -3,7 +3,9 @@ module github.com/botashev/tasks-executor
 go 1.22.12
 
 require (
+	github.com/robfig/cron/v3 v3.0.1
 	go.mongodb.org/mongo-driver v1.13.1
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20241202173237-19429a94021a
 	google.golang.org/grpc v1.70.0
 	google.golang.org/protobuf v1.36.5
 )
@@ -21,5 +23,4 @@ require (
 	golang.org/x/sync v0.11.0 // indirect
 	golang.org/x/sys v0.30.0 // indirect
 	golang.org/x/text v0.22.0 // indirect
-	google.golang.org/genproto/googleapis/rpc v0.0.0-20241202173237-19429a94021a // indirect
 )
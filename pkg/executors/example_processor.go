@@ -1,13 +1,15 @@
 package executors
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"time"
 
 	"github.com/botashev/tasks-executor/pkg/manager"
-	pb "github.com/botashev/tasks-executor/proto"
+	"github.com/botashev/tasks-executor/pkg/models"
+	"github.com/botashev/tasks-executor/pkg/sdk"
 )
 
 type ExampleProcessor struct {
@@ -24,8 +26,12 @@ func (p *ExampleProcessor) Register() error {
 	return p.manager.RegisterExecutor("example_processor")
 }
 
-func (p *ExampleProcessor) ProcessTask(task *pb.Task) error {
-	log.Printf("Processing task %s", task.Id)
+func (p *ExampleProcessor) ProcessTask(task *models.Task) error {
+	return p.ProcessTaskContext(context.Background(), task)
+}
+
+func (p *ExampleProcessor) ProcessTaskContext(ctx context.Context, task *models.Task) error {
+	log.Printf("Processing task %s", task.ID.Hex())
 
 	var data map[string]interface{}
 	if err := json.Unmarshal(task.Data, &data); err != nil {
@@ -34,7 +40,11 @@ func (p *ExampleProcessor) ProcessTask(task *pb.Task) error {
 
 	log.Printf("Task data: %+v", data)
 
-	time.Sleep(1 * time.Second)
+	select {
+	case <-time.After(1 * time.Second):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 
 	return nil
 }
@@ -59,30 +69,5 @@ func (p *ExampleProcessor) GetTaskSchema() string {
 }
 
 func (p *ExampleProcessor) Start() error {
-	for {
-		task, err := p.manager.GetNextTask("example_processor")
-		if err != nil {
-			log.Printf("Error getting next task: %v", err)
-			time.Sleep(5 * time.Second)
-			continue
-		}
-
-		if task == nil {
-			time.Sleep(1 * time.Second)
-			continue
-		}
-
-		err = p.ProcessTask(task)
-		if err != nil {
-			log.Printf("Error processing task %s: %v", task.Id, err)
-			if err := p.manager.UpdateTaskStatus(task.Id, pb.TaskStatus_TASK_STATUS_FAILED, err.Error()); err != nil {
-				log.Printf("Error updating task status: %v", err)
-			}
-			continue
-		}
-
-		if err := p.manager.UpdateTaskStatus(task.Id, pb.TaskStatus_TASK_STATUS_COMPLETED, ""); err != nil {
-			log.Printf("Error updating task status: %v", err)
-		}
-	}
+	return sdk.NewWorker(p.manager, "example_processor", p).Run(context.Background())
 }
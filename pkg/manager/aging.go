@@ -0,0 +1,68 @@
+package manager
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/botashev/tasks-executor/pkg/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+/*
+RunPriorityAging periodically raises the priority of pending tasks that have
+waited longer than the aging threshold of their executor. Executors without
+priority aging configured are skipped.
+It blocks until ctx is cancelled.
+*/
+func (s *Service) RunPriorityAging(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.ageTasks(ctx); err != nil {
+				log.Printf("Error aging task priorities: %v", err)
+			}
+		}
+	}
+}
+
+// validatePriorityAging rejects an enabled aging without max_priority: tasks
+// are only boosted while below it, so those of priority 0 and up never would be.
+func validatePriorityAging(aging models.PriorityAging) error {
+	if aging.Threshold < 0 || aging.Step < 0 {
+		return status.Error(codes.InvalidArgument, "priority_aging.threshold and step must not be negative")
+	}
+	if aging.Threshold > 0 && aging.Step > 0 && aging.MaxPriority == 0 {
+		return status.Error(codes.InvalidArgument, "priority_aging.max_priority is required when aging is enabled")
+	}
+	return nil
+}
+
+func (s *Service) ageTasks(ctx context.Context) error {
+	executors, err := s.storage.ListExecutors(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, executor := range executors {
+		aging := executor.PriorityAging
+		if aging.Threshold <= 0 || aging.Step <= 0 {
+			continue
+		}
+		boosted, err := s.storage.AgeTasks(ctx, executor.Name, aging)
+		if err != nil {
+			log.Printf("Error aging tasks of executor %s: %v", executor.Name, err)
+			continue
+		}
+		if boosted > 0 {
+			log.Printf("Raised priority of %d waiting tasks of executor %s", boosted, executor.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/botashev/tasks-executor/pkg/models"
+	"github.com/botashev/tasks-executor/pkg/storage"
+	pb "github.com/botashev/tasks-executor/proto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+/*
+UpdateTaskStatuses applies several worker reports at once. Every report is
+checked like UpdateTaskStatus and gets its own result; completions, the common
+case, are written to the storage together.
+*/
+func (s *Service) UpdateTaskStatuses(ctx context.Context, req *pb.UpdateTaskStatusesRequest) (*pb.UpdateTaskStatusesResponse, error) {
+	if len(req.Updates) > maxBatchSize {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("at most %d updates are allowed", maxBatchSize))
+	}
+
+	results := make([]*pb.UpdateTaskStatusResult, len(req.Updates))
+	ids := make([]primitive.ObjectID, 0, len(req.Updates))
+	seen := make(map[string]bool, len(req.Updates))
+	for i, update := range req.Updates {
+		results[i] = &pb.UpdateTaskStatusResult{Id: update.Id}
+		objectID, err := primitive.ObjectIDFromHex(update.Id)
+		switch {
+		case err != nil:
+			setResultError(results[i], status.Error(codes.InvalidArgument, "invalid task id"))
+		case seen[update.Id]:
+			setResultError(results[i], status.Error(codes.InvalidArgument, "task is reported more than once"))
+		case len(update.Result) > maxTaskResultSize:
+			setResultError(results[i], status.Error(codes.InvalidArgument, fmt.Sprintf("result exceeds %d bytes", maxTaskResultSize)))
+		default:
+			ids = append(ids, objectID)
+		}
+		seen[update.Id] = true
+	}
+
+	tasks := make(map[string]*models.Task, len(ids))
+	if len(ids) > 0 {
+		loaded, err := s.storage.ListTasks(ctx, storage.TaskFilter{IDs: ids}, nil, len(ids))
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		for _, task := range loaded {
+			tasks[task.ID.Hex()] = task
+		}
+	}
+
+	var completions []storage.TaskCompletion
+	var completed []int
+	executors := make(map[string]*models.ExecutorConfig)
+	for i, update := range req.Updates {
+		if results[i].Code != int32(codes.OK) {
+			continue
+		}
+		task := tasks[update.Id]
+		if task == nil {
+			setResultError(results[i], status.Error(codes.NotFound, "task not found"))
+			continue
+		}
+		taskStatus, errorMsg, err := reportedTaskStatus(task, update)
+		if err != nil {
+			setResultError(results[i], err)
+			continue
+		}
+		executor, ok := executors[task.ExecutorName]
+		if !ok {
+			executor, err = s.storage.GetExecutor(ctx, task.ExecutorName)
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			executors[task.ExecutorName] = executor
+		}
+		if executor == nil && !task.CancelRequested {
+			setResultError(results[i], status.Error(codes.FailedPrecondition, "executor not found"))
+			continue
+		}
+		if taskStatus == models.TaskStatusCompleted {
+			completions = append(completions, storage.TaskCompletion{ID: update.Id, LeaseID: task.LeaseID, Result: update.Result})
+			completed = append(completed, i)
+			continue
+		}
+
+		if err := s.finishTask(ctx, executor, task, taskStatus, errorMsg); err != nil {
+			setResultError(results[i], storageError(err))
+			continue
+		}
+		s.releaseInFlight(executor, 1)
+		results[i].Task = convertTaskToProto(task)
+	}
+
+	if len(completions) > 0 {
+		errs, err := s.storage.CompleteTasks(ctx, models.TaskStatusInProgress, completions)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		released := make(map[string]int)
+		for j, i := range completed {
+			if errs[j] != nil {
+				setResultError(results[i], storageError(errs[j]))
+				continue
+			}
+			task := tasks[req.Updates[i].Id]
+			completeTask(task, req.Updates[i].Result)
+			results[i].Task = convertTaskToProto(task)
+			released[task.ExecutorName]++
+		}
+		for name, n := range released {
+			s.releaseInFlight(executors[name], n)
+		}
+	}
+	return &pb.UpdateTaskStatusesResponse{Results: results}, nil
+}
+
+func setResultError(result *pb.UpdateTaskStatusResult, err error) {
+	st := status.Convert(err)
+	result.Code = int32(st.Code())
+	result.Error = st.Message()
+}
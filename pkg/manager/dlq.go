@@ -0,0 +1,379 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/botashev/tasks-executor/pkg/models"
+	"github.com/botashev/tasks-executor/pkg/storage"
+	pb "github.com/botashev/tasks-executor/proto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// redriveBatchSize is the number of DLQ tasks loaded at once while redriving.
+const redriveBatchSize = 100
+
+// redriveCallBudget bounds the time one RedriveDLQ call spends redriving, so
+// that a large DLQ is redriven in several calls instead of outliving the
+// client's or a proxy's deadline.
+const redriveCallBudget = 20 * time.Second
+
+func (s *Service) ListDLQTasks(ctx context.Context, req *pb.ListDLQTasksRequest) (*pb.ListDLQTasksResponse, error) {
+	if err := s.checkDLQScope(ctx, req.ExecutorName, req.QueueName); err != nil {
+		return nil, err
+	}
+	pageSize, err := normalizePageSize(req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	after, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// One extra task tells whether there is a next page.
+	tasks, err := s.storage.ListDLQTasks(ctx, dlqTaskFilter(req.ExecutorName, req.QueueName, req.Filter), after, pageSize+1)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.ListDLQTasksResponse{}
+	if len(tasks) > pageSize {
+		tasks = tasks[:pageSize]
+		resp.NextPageToken = encodePageToken(tasks[len(tasks)-1])
+	}
+	resp.Tasks = make([]*pb.Task, len(tasks))
+	for i, task := range tasks {
+		resp.Tasks[i] = convertTaskToProto(task)
+	}
+	return resp, nil
+}
+
+func (s *Service) PurgeDLQ(ctx context.Context, req *pb.PurgeDLQRequest) (*pb.PurgeDLQResponse, error) {
+	if err := s.checkDLQScope(ctx, req.ExecutorName, req.QueueName); err != nil {
+		return nil, err
+	}
+	purged, err := s.storage.PurgeDLQ(ctx, dlqTaskFilter(req.ExecutorName, req.QueueName, req.Filter))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.PurgeDLQResponse{PurgedCount: purged}, nil
+}
+
+/*
+RedriveDLQ returns DLQ tasks to the live queue one by one, at most
+req.RatePerSecond tasks per second. A call stops after redriveCallBudget, or
+halfway to the caller's deadline if that comes sooner, and returns
+NextPageToken to continue from; the caller repeats the request with it until
+the token is empty. Tasks redriven before an error remain redriven; the error
+reports how many were processed.
+*/
+func (s *Service) RedriveDLQ(ctx context.Context, req *pb.RedriveDLQRequest) (*pb.RedriveDLQResponse, error) {
+	if err := s.checkDLQScope(ctx, req.ExecutorName, req.QueueName); err != nil {
+		return nil, err
+	}
+	if req.TargetExecutor != "" {
+		target, err := s.storage.GetExecutor(ctx, req.TargetExecutor)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if target == nil {
+			return nil, status.Error(codes.NotFound, "target executor not found")
+		}
+		if err := checkSubmit(target); err != nil {
+			return nil, err
+		}
+	}
+	if req.RatePerSecond < 0 {
+		return nil, status.Error(codes.InvalidArgument, "rate_per_second must not be negative")
+	}
+	var patch interface{}
+	if req.DataPatch != "" {
+		if err := json.Unmarshal([]byte(req.DataPatch), &patch); err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid data_patch: %v", err))
+		}
+	}
+
+	after, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	filter := dlqTaskFilter(req.ExecutorName, req.QueueName, req.Filter)
+	if len(req.TaskIds) > 0 {
+		filter = dlqTaskFilter(req.ExecutorName, req.QueueName, nil)
+		for _, id := range req.TaskIds {
+			objectID, err := primitive.ObjectIDFromHex(id)
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid task id %q", id))
+			}
+			filter.IDs = append(filter.IDs, objectID)
+		}
+	}
+
+	var throttle <-chan time.Time
+	if req.RatePerSecond > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / req.RatePerSecond))
+		defer ticker.Stop()
+		throttle = ticker.C
+	}
+
+	budget := redriveCallBudget
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline)/2 < budget {
+		budget = time.Until(deadline) / 2
+	}
+	stopAt := time.Now().Add(budget)
+
+	resp := &pb.RedriveDLQResponse{}
+	var last *models.Task
+	for {
+		tasks, err := s.storage.ListDLQTasks(ctx, filter, after, redriveBatchSize)
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("redriven %d tasks: %v", resp.RedrivenCount, err))
+		}
+		for _, task := range tasks {
+			// At least one task per call, so that a continuation always makes progress
+			if last != nil && time.Now().After(stopAt) {
+				resp.NextPageToken = encodePageToken(last)
+				return resp, nil
+			}
+			if throttle != nil && last != nil {
+				select {
+				case <-ctx.Done():
+					return nil, status.FromContextError(ctx.Err()).Err()
+				case <-throttle:
+				}
+			}
+			last = task
+			err := s.redriveTask(ctx, task, req.TargetExecutor, patch, req.Reason)
+			if errors.Is(err, storage.ErrStatusConflict) {
+				// Redriven concurrently by another request
+				continue
+			}
+			if errors.Is(err, storage.ErrDuplicateTask) {
+				resp.DuplicateTaskIds = append(resp.DuplicateTaskIds, task.ID.Hex())
+				continue
+			}
+			if err != nil {
+				return nil, status.Error(codes.Internal, fmt.Sprintf("redriven %d tasks, failed on %s: %v", resp.RedrivenCount, task.ID.Hex(), err))
+			}
+			resp.RedrivenCount++
+		}
+		if len(tasks) < redriveBatchSize {
+			break
+		}
+		after = &storage.TaskCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+	return resp, nil
+}
+
+// redriveTask resets a DLQ task to a fresh pending state and records the redrive in its history.
+// An empty targetExecutor returns the task to its own executor.
+func (s *Service) redriveTask(ctx context.Context, task *models.Task, targetExecutor string, patch interface{}, reason string) error {
+	if targetExecutor == "" {
+		targetExecutor = task.ExecutorName
+	}
+	entry := models.TaskHistoryEntry{
+		Event:   models.TaskEventRedriven,
+		At:      time.Now(),
+		Message: reason,
+		Details: map[string]string{
+			"from_executor": task.ExecutorName,
+			"to_executor":   targetExecutor,
+			"retry_count":   strconv.Itoa(task.RetryCount),
+			"error":         task.Error,
+		},
+	}
+	if patch != nil {
+		data, err := applyMergePatch(task.Data, patch)
+		if err != nil {
+			return err
+		}
+		task.Data = data
+		entry.Details["data_patched"] = "true"
+	}
+
+	if targetExecutor != task.ExecutorName {
+		// The key deduplicates submissions to the original executor only
+		task.IdempotencyKey = ""
+	}
+	task.ExecutorName = targetExecutor
+	task.Status = models.TaskStatusPending
+	task.Error = ""
+	task.RetryCount = 0
+	task.UpdatedAt = entry.At
+	task.StartedAt = nil
+	task.CompletedAt = nil
+	task.NextAttemptAt = nil
+	task.LeaseExpiresAt = nil
+	task.LeaseID = ""
+	task.Progress = nil
+	task.CancelRequested = false
+	task.Result = nil
+	task.DLQName = ""
+	task.ExpireAt = nil
+	task.ErrorGroup = ""
+	task.History = append(task.History, entry)
+	if err := s.storage.RedriveTask(ctx, task); err != nil {
+		return err
+	}
+	s.notifier.notify(task.ExecutorName)
+	return nil
+}
+
+// applyMergePatch applies a JSON Merge Patch (RFC 7386) to JSON task data.
+func applyMergePatch(data []byte, patch interface{}) ([]byte, error) {
+	var target interface{}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &target); err != nil {
+			return nil, fmt.Errorf("task data is not JSON: %v", err)
+		}
+	}
+	return json.Marshal(mergePatch(target, patch))
+}
+
+func mergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergePatch(targetObject[key], value)
+	}
+	return targetObject
+}
+
+func (s *Service) ListDLQs(ctx context.Context, req *pb.ListDLQsRequest) (*pb.ListDLQsResponse, error) {
+	queues, err := s.storage.ListDLQs(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	result := make([]*pb.DLQ, len(queues))
+	for i, queue := range queues {
+		result[i] = convertDLQToProto(queue)
+	}
+	return &pb.ListDLQsResponse{Queues: result}, nil
+}
+
+func (s *Service) GetDLQ(ctx context.Context, req *pb.GetDLQRequest) (*pb.GetDLQResponse, error) {
+	if req == nil || req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	queue, err := s.storage.GetDLQ(ctx, req.Name)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if queue == nil {
+		return nil, status.Error(codes.NotFound, "dlq not found or empty")
+	}
+	return &pb.GetDLQResponse{Queue: convertDLQToProto(queue)}, nil
+}
+
+// moveToDLQ dead-letters a task into the executor's queue and forwards a copy
+// to the failure handler executor if one is configured.
+func (s *Service) moveToDLQ(ctx context.Context, executor *models.ExecutorConfig, task *models.Task, from models.TaskStatus) error {
+	task.DLQName = dlqName(executor)
+	task.ErrorGroup = normalizeError(task.Error)
+	if executor.DLQConfig.MaxAge > 0 && !executor.DLQConfig.Archive {
+		// Expired tasks are removed by the TTL index; archived ones by RunDLQRetention
+		expireAt := time.Now().Add(executor.DLQConfig.MaxAge)
+		task.ExpireAt = &expireAt
+	}
+	if err := s.storage.MoveToDLQ(ctx, task, from); err != nil {
+		return err
+	}
+
+	handler := executor.DLQConfig.FailureHandler
+	if handler == "" || handler == task.ExecutorName {
+		return nil
+	}
+	metadata := make(map[string]string, len(task.Metadata)+4)
+	for key, value := range task.Metadata {
+		metadata[key] = value
+	}
+	metadata["dlq.task_id"] = task.ID.Hex()
+	metadata["dlq.executor"] = task.ExecutorName
+	metadata["dlq.queue"] = task.DLQName
+	metadata["dlq.error"] = task.Error
+	// The task is already dead-lettered, a failed forward must not undo that
+	if _, err := s.AddTask(ctx, &pb.AddTaskRequest{
+		ExecutorName: handler,
+		Data:         task.Data,
+		Metadata:     metadata,
+	}); err != nil {
+		log.Printf("Error forwarding task %s to failure handler %s: %v", task.ID.Hex(), handler, err)
+	}
+	return nil
+}
+
+// dlqName returns the name of the executor's Dead Letter Queue.
+func dlqName(executor *models.ExecutorConfig) string {
+	if executor.DLQConfig.QueueName != "" {
+		return executor.DLQConfig.QueueName
+	}
+	return executor.Name
+}
+
+// checkDLQScope validates the executor and/or queue a DLQ request applies to.
+func (s *Service) checkDLQScope(ctx context.Context, executorName, queueName string) error {
+	if executorName == "" && queueName == "" {
+		return status.Error(codes.InvalidArgument, "executor_name or queue_name is required")
+	}
+	if executorName == "" {
+		return nil
+	}
+	return s.checkExecutorExists(ctx, executorName)
+}
+
+func (s *Service) checkExecutorExists(ctx context.Context, name string) error {
+	if name == "" {
+		return status.Error(codes.InvalidArgument, "executor_name is required")
+	}
+	executor, err := s.storage.GetExecutor(ctx, name)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if executor == nil {
+		return status.Error(codes.NotFound, "executor not found")
+	}
+	return nil
+}
+
+// dlqTaskFilter maps a DLQ filter onto the storage task filter; the time a task
+// was moved to the DLQ is stored as its updated_at.
+func dlqTaskFilter(executorName, queueName string, filter *pb.DLQFilter) storage.TaskFilter {
+	result := storage.TaskFilter{ExecutorName: executorName, DLQName: queueName}
+	if filter == nil {
+		return result
+	}
+	result.UpdatedAfter = timeOrZero(filter.FailedAfter)
+	result.UpdatedBefore = timeOrZero(filter.FailedBefore)
+	result.ErrorContains = filter.ErrorContains
+	result.Metadata = filter.Metadata
+	result.ErrorGroup = filter.ErrorGroup
+	return result
+}
+
+func convertDLQToProto(queue *models.DLQInfo) *pb.DLQ {
+	return &pb.DLQ{
+		Name:          queue.Name,
+		Size:          queue.Size,
+		Executors:     queue.Executors,
+		OldestEntryAt: timestamppb.New(queue.OldestEntryAt),
+	}
+}
@@ -0,0 +1,92 @@
+package manager
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/botashev/tasks-executor/pkg/models"
+	pb "github.com/botashev/tasks-executor/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultDLQSampleSize = 5
+	maxDLQSampleSize     = 100
+
+	// maxErrorGroupLength bounds the normalized error so it stays a reasonable index key.
+	maxErrorGroupLength = 512
+)
+
+// Parts of error messages that differ between otherwise identical failures.
+var (
+	uuidPattern   = regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`)
+	hexIDPattern  = regexp.MustCompile(`(?i)\b(?:0x[0-9a-f]+|[0-9a-f]*[0-9][0-9a-f]*[a-f][0-9a-f]*|[0-9a-f]*[a-f][0-9a-f]*[0-9][0-9a-f]*)\b`)
+	numberPattern = regexp.MustCompile(`\b\d+(?:\.\d+)*`)
+	spacePattern  = regexp.MustCompile(`\s+`)
+)
+
+func (s *Service) DLQSummary(ctx context.Context, req *pb.DLQSummaryRequest) (*pb.DLQSummaryResponse, error) {
+	if err := s.checkDLQScope(ctx, req.ExecutorName, req.QueueName); err != nil {
+		return nil, err
+	}
+	samples := int(req.SampleSize)
+	switch {
+	case samples < 0:
+		return nil, status.Error(codes.InvalidArgument, "sample_size must not be negative")
+	case samples == 0:
+		samples = defaultDLQSampleSize
+	case samples > maxDLQSampleSize:
+		samples = maxDLQSampleSize
+	}
+
+	groups, err := s.storage.DLQErrorGroups(ctx, dlqTaskFilter(req.ExecutorName, req.QueueName, req.Filter), samples)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp := &pb.DLQSummaryResponse{Groups: make([]*pb.DLQErrorGroup, len(groups))}
+	for i, group := range groups {
+		resp.Groups[i] = convertDLQErrorGroupToProto(group)
+	}
+	return resp, nil
+}
+
+/*
+normalizeError reduces an error message to the part shared by all failures of
+the same kind: UUIDs and hexadecimal IDs become <id>, numbers become <n> and
+whitespace is collapsed. For example, "task 42: object 665f1c2e8b3a4d0012345678 not found"
+becomes "task <n>: object <id> not found".
+*/
+func normalizeError(message string) string {
+	message = uuidPattern.ReplaceAllString(message, "<id>")
+	message = hexIDPattern.ReplaceAllString(message, "<id>")
+	message = numberPattern.ReplaceAllString(message, "<n>")
+	message = strings.TrimSpace(spacePattern.ReplaceAllString(message, " "))
+	if len(message) > maxErrorGroupLength {
+		cut := maxErrorGroupLength
+		for cut > 0 && !utf8.RuneStart(message[cut]) {
+			cut--
+		}
+		message = message[:cut]
+	}
+	return message
+}
+
+func convertDLQErrorGroupToProto(group *models.DLQErrorGroup) *pb.DLQErrorGroup {
+	ids := make([]string, len(group.SampleTaskIDs))
+	for i, id := range group.SampleTaskIDs {
+		ids[i] = id.Hex()
+	}
+	return &pb.DLQErrorGroup{
+		ExecutorName:  group.ExecutorName,
+		ErrorGroup:    group.ErrorGroup,
+		Count:         group.Count,
+		FirstSeen:     timestamppb.New(group.FirstSeen),
+		LastSeen:      timestamppb.New(group.LastSeen),
+		SampleTaskIds: ids,
+		SampleError:   group.SampleError,
+	}
+}
@@ -0,0 +1,80 @@
+package manager
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestNormalizeError(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{
+			name:    "object id and number",
+			message: "task 42: object 665f1c2e8b3a4d0012345678 not found",
+			want:    "task <n>: object <id> not found",
+		},
+		{
+			name:    "uuid",
+			message: "request 3F2504E0-4F89-11D3-9A0C-0305E82C3301 failed",
+			want:    "request <id> failed",
+		},
+		{
+			name:    "prefixed hex",
+			message: "bad address 0x1f",
+			want:    "bad address <id>",
+		},
+		{
+			name:    "decimal and version numbers",
+			message: "retry in 1.5 seconds, client 2.10.3",
+			want:    "retry in <n> seconds, client <n>",
+		},
+		{
+			name:    "hex-looking words are kept",
+			message: "cafe facade added",
+			want:    "cafe facade added",
+		},
+		{
+			name:    "whitespace is collapsed",
+			message: "  connection\n\treset   by peer ",
+			want:    "connection reset by peer",
+		},
+		{
+			name:    "empty",
+			message: "",
+			want:    "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeError(tt.message); got != tt.want {
+				t.Errorf("normalizeError(%q) = %q, want %q", tt.message, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeErrorTruncates(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		wantLen int
+	}{
+		{name: "ascii", message: strings.Repeat("x", 1000), wantLen: maxErrorGroupLength},
+		{name: "cut inside a rune", message: "x" + strings.Repeat("я", 300), wantLen: maxErrorGroupLength - 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := normalizeError(tt.message)
+			if len(got) != tt.wantLen {
+				t.Errorf("len = %d, want %d", len(got), tt.wantLen)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("result is not valid UTF-8: %q", got)
+			}
+		})
+	}
+}
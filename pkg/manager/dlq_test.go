@@ -0,0 +1,49 @@
+package manager
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestApplyMergePatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		patch   string
+		want    string
+		wantErr bool
+	}{
+		{name: "add field", data: `{"a":1}`, patch: `{"b":2}`, want: `{"a":1,"b":2}`},
+		{name: "replace field", data: `{"a":1}`, patch: `{"a":"x"}`, want: `{"a":"x"}`},
+		{name: "null removes field", data: `{"a":1,"b":2}`, patch: `{"a":null}`, want: `{"b":2}`},
+		{name: "null on missing field", data: `{"a":1}`, patch: `{"c":null}`, want: `{"a":1}`},
+		{name: "nested merge", data: `{"a":{"b":1,"c":2}}`, patch: `{"a":{"b":3,"c":null}}`, want: `{"a":{"b":3}}`},
+		{name: "object replaces scalar", data: `{"a":1}`, patch: `{"a":{"b":1}}`, want: `{"a":{"b":1}}`},
+		{name: "arrays are replaced", data: `{"a":[1,2]}`, patch: `{"a":[3]}`, want: `{"a":[3]}`},
+		{name: "non-object patch replaces data", data: `{"a":1}`, patch: `[1,2]`, want: `[1,2]`},
+		{name: "empty data", data: ``, patch: `{"a":1}`, want: `{"a":1}`},
+		{name: "non-object data", data: `"text"`, patch: `{"a":1}`, want: `{"a":1}`},
+		{name: "data is not JSON", data: `not json`, patch: `{"a":1}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patch interface{}
+			if err := json.Unmarshal([]byte(tt.patch), &patch); err != nil {
+				t.Fatalf("bad patch: %v", err)
+			}
+			got, err := applyMergePatch([]byte(tt.data), patch)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("applyMergePatch() = %s, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("applyMergePatch() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/botashev/tasks-executor/pkg/models"
+	pb "github.com/botashev/tasks-executor/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultDeletionReason is the error of tasks cancelled with their executor,
+// including the tasks in progress when the executor's tasks are moved.
+const defaultDeletionReason = "executor deleted"
+
+// checkDeletionPolicy validates the deletion policy of req and, for the default
+// refuse policy, that the executor has no tasks left to handle.
+func (s *Service) checkDeletionPolicy(ctx context.Context, req *pb.DeleteExecutorRequest) error {
+	switch req.Policy {
+	case pb.ExecutorDeletionPolicy_EXECUTOR_DELETION_POLICY_UNSPECIFIED, pb.ExecutorDeletionPolicy_EXECUTOR_DELETION_POLICY_REFUSE:
+		unfinished, err := s.storage.CountUnfinishedTasks(ctx, req.Id)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		dlqSize, _, err := s.storage.GetDLQStats(ctx, req.Id)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		if unfinished > 0 || dlqSize > 0 {
+			return status.Error(codes.FailedPrecondition, fmt.Sprintf(
+				"executor has %d unfinished and %d DLQ tasks, choose a deletion policy to cancel, move or archive them", unfinished, dlqSize))
+		}
+	case pb.ExecutorDeletionPolicy_EXECUTOR_DELETION_POLICY_CANCEL, pb.ExecutorDeletionPolicy_EXECUTOR_DELETION_POLICY_ARCHIVE:
+	case pb.ExecutorDeletionPolicy_EXECUTOR_DELETION_POLICY_MOVE:
+		if req.TargetExecutor == "" {
+			return status.Error(codes.InvalidArgument, "target_executor is required to move tasks")
+		}
+		if req.TargetExecutor == req.Id {
+			return status.Error(codes.InvalidArgument, "cannot move tasks to the executor being deleted")
+		}
+		target, err := s.storage.GetExecutor(ctx, req.TargetExecutor)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		if target == nil {
+			return status.Error(codes.NotFound, "target executor not found")
+		}
+		if err := checkSubmit(target); err != nil {
+			return err
+		}
+	default:
+		return status.Error(codes.InvalidArgument, "unknown deletion policy")
+	}
+	return nil
+}
+
+// applyDeletionPolicy handles the pending, in-progress and DLQ tasks of a
+// deleted executor and returns how many were affected.
+func (s *Service) applyDeletionPolicy(ctx context.Context, executor *models.ExecutorConfig, req *pb.DeleteExecutorRequest) (int64, error) {
+	reason := req.Reason
+	if reason == "" {
+		reason = defaultDeletionReason
+	}
+	switch req.Policy {
+	case pb.ExecutorDeletionPolicy_EXECUTOR_DELETION_POLICY_CANCEL:
+		return s.storage.CancelExecutorTasks(ctx, executor.Name, reason)
+	case pb.ExecutorDeletionPolicy_EXECUTOR_DELETION_POLICY_MOVE:
+		target, err := s.storage.GetExecutor(ctx, req.TargetExecutor)
+		if err != nil {
+			return 0, err
+		}
+		if target == nil {
+			return 0, fmt.Errorf("target executor %s not found", req.TargetExecutor)
+		}
+		moved, err := s.storage.MoveExecutorTasks(ctx, executor.Name, target.Name, dlqName(target), reason)
+		if err == nil && moved > 0 {
+			s.notifier.notify(req.TargetExecutor)
+		}
+		return moved, err
+	case pb.ExecutorDeletionPolicy_EXECUTOR_DELETION_POLICY_ARCHIVE:
+		return s.storage.ArchiveExecutorTasks(ctx, executor.Name)
+	}
+	return 0, nil
+}
+
+func (s *Service) UndeleteExecutor(ctx context.Context, req *pb.UndeleteExecutorRequest) (*pb.UndeleteExecutorResponse, error) {
+	if req == nil || req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	executor, err := s.storage.UndeleteExecutor(ctx, req.Name, time.Now().Add(-executorUndeleteWindow))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if executor == nil {
+		return nil, status.Error(codes.NotFound, "no executor deleted within the undelete window")
+	}
+	if err := s.recordRevision(ctx, newRevision(ctx, models.ExecutorRevisionUndeleted, executor, executor)); err != nil {
+		return nil, err
+	}
+	return &pb.UndeleteExecutorResponse{
+		Executor: convertExecutorToProto(executor),
+	}, nil
+}
+
+/*
+RunExecutorPurge periodically removes the configurations of executors deleted
+longer than the undelete window ago. It blocks until ctx is cancelled.
+*/
+func (s *Service) RunExecutorPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := s.storage.PurgeDeletedExecutors(ctx, time.Now().Add(-executorUndeleteWindow))
+			if err != nil {
+				log.Printf("Error purging deleted executors: %v", err)
+				continue
+			}
+			if purged > 0 {
+				log.Printf("Purged %d deleted executors", purged)
+			}
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/botashev/tasks-executor/pkg/models"
+	"github.com/botashev/tasks-executor/pkg/storage"
+	pb "github.com/botashev/tasks-executor/proto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ActorMetadataKey is the request metadata key naming who makes a change.
+// Revisions of requests without it record the address of the client.
+const ActorMetadataKey = "x-actor"
+
+// revisionIgnoredFields are the ExecutorConfig fields maintained by the
+// manager itself, left out of the changes of a revision.
+var revisionIgnoredFields = []string{"_id", "version", "deleted_at", "created_at", "updated_at"}
+
+func (s *Service) ListExecutorRevisions(ctx context.Context, req *pb.ListExecutorRevisionsRequest) (*pb.ListExecutorRevisionsResponse, error) {
+	if req == nil || req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	pageSize, err := normalizePageSize(req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	var before primitive.ObjectID
+	if req.PageToken != "" {
+		if before, err = primitive.ObjectIDFromHex(req.PageToken); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+		}
+	}
+
+	// One extra revision tells whether there is a next page.
+	revisions, err := s.storage.ListExecutorRevisions(ctx, req.Name, before, pageSize+1)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.ListExecutorRevisionsResponse{}
+	if len(revisions) > pageSize {
+		revisions = revisions[:pageSize]
+		resp.NextPageToken = revisions[len(revisions)-1].ID.Hex()
+	}
+	resp.Revisions = make([]*pb.ExecutorRevision, len(revisions))
+	for i, revision := range revisions {
+		resp.Revisions[i] = convertExecutorRevisionToProto(revision)
+	}
+	return resp, nil
+}
+
+func (s *Service) RollbackExecutor(ctx context.Context, req *pb.RollbackExecutorRequest) (*pb.RollbackExecutorResponse, error) {
+	if req == nil || req.Name == "" || req.RevisionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "name and revision_id are required")
+	}
+	id, err := primitive.ObjectIDFromHex(req.RevisionId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid revision_id")
+	}
+	target, err := s.storage.GetExecutorRevision(ctx, id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if target == nil || target.ExecutorName != req.Name {
+		return nil, status.Error(codes.NotFound, "revision not found")
+	}
+
+	current, err := s.storage.GetExecutor(ctx, req.Name)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if current == nil {
+		return nil, status.Error(codes.NotFound, "executor not found")
+	}
+	if req.Version <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "version is required")
+	}
+	if req.Version != current.Version {
+		return nil, status.Error(codes.Aborted, storage.ErrVersionConflict.Error())
+	}
+
+	config := target.Config
+	config.ID = current.ID
+	config.Name = current.Name
+	config.Version = current.Version
+	config.DeletedAt = nil
+	config.CreatedAt = current.CreatedAt
+	config.UpdatedAt = time.Now()
+
+	updated, err := s.storage.UpdateExecutor(ctx, &config, executorTopLevelFields)
+	if err != nil {
+		return nil, storageError(err)
+	}
+	if updated == nil {
+		return nil, status.Error(codes.NotFound, "executor not found")
+	}
+	revision := newRevision(ctx, models.ExecutorRevisionRolledBack, current, updated)
+	revision.RolledBackTo = target.ID
+	if err := s.recordRevision(ctx, revision); err != nil {
+		return nil, err
+	}
+
+	return &pb.RollbackExecutorResponse{
+		Executor: convertExecutorToProto(updated),
+	}, nil
+}
+
+// newRevision builds the revision of a change of an executor from before to
+// after. A nil before stands for an executor that did not exist.
+func newRevision(ctx context.Context, action models.ExecutorRevisionAction, before, after *models.ExecutorConfig) *models.ExecutorRevision {
+	if before == nil {
+		before = &models.ExecutorConfig{}
+	}
+	return &models.ExecutorRevision{
+		ExecutorName: after.Name,
+		Action:       action,
+		Actor:        actorFromContext(ctx),
+		Config:       *after,
+		Changes:      diffExecutorConfigs(before, after),
+		CreatedAt:    time.Now(),
+	}
+}
+
+// recordRevision appends a revision to the history of its executor. The change
+// has already been made by then, so the error tells the client that it was
+// applied but is missing from the history.
+func (s *Service) recordRevision(ctx context.Context, revision *models.ExecutorRevision) error {
+	if err := s.storage.AddExecutorRevision(ctx, revision); err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("executor changed, but the change was not recorded in its history: %v", err))
+	}
+	return nil
+}
+
+// actorFromContext returns who makes the request: the ActorMetadataKey
+// metadata if set, the address of the client otherwise.
+func actorFromContext(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(ActorMetadataKey); len(values) > 0 && values[0] != "" {
+			return values[0]
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		return p.Addr.String()
+	}
+	return "unknown"
+}
+
+// diffExecutorConfigs lists the configuration fields that differ between two
+// executor configurations, named by their BSON paths.
+func diffExecutorConfigs(before, after *models.ExecutorConfig) []models.ExecutorFieldChange {
+	var changes []models.ExecutorFieldChange
+	diffFields("", reflect.ValueOf(*before), reflect.ValueOf(*after), &changes)
+	return changes
+}
+
+func diffFields(prefix string, before, after reflect.Value, changes *[]models.ExecutorFieldChange) {
+	for i := 0; i < before.NumField(); i++ {
+		name, _, _ := strings.Cut(before.Type().Field(i).Tag.Get("bson"), ",")
+		if prefix == "" && slices.Contains(revisionIgnoredFields, name) {
+			continue
+		}
+		old, cur := before.Field(i), after.Field(i)
+		if old.Kind() == reflect.Struct {
+			diffFields(prefix+name+".", old, cur, changes)
+			continue
+		}
+		if !old.Equal(cur) {
+			*changes = append(*changes, models.ExecutorFieldChange{
+				Field:    prefix + name,
+				OldValue: fmt.Sprint(old.Interface()),
+				NewValue: fmt.Sprint(cur.Interface()),
+			})
+		}
+	}
+}
+
+func convertExecutorRevisionToProto(revision *models.ExecutorRevision) *pb.ExecutorRevision {
+	result := &pb.ExecutorRevision{
+		Id:           revision.ID.Hex(),
+		ExecutorName: revision.ExecutorName,
+		Action:       convertExecutorRevisionAction(revision.Action),
+		Actor:        revision.Actor,
+		Config:       convertExecutorConfig(&revision.Config),
+		CreatedAt:    timestamppb.New(revision.CreatedAt),
+	}
+	if !revision.RolledBackTo.IsZero() {
+		result.RolledBackTo = revision.RolledBackTo.Hex()
+	}
+	for _, change := range revision.Changes {
+		result.Changes = append(result.Changes, &pb.ExecutorFieldChange{
+			Field:    change.Field,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		})
+	}
+	return result
+}
+
+func convertExecutorRevisionAction(action models.ExecutorRevisionAction) pb.ExecutorRevisionAction {
+	switch action {
+	case models.ExecutorRevisionCreated:
+		return pb.ExecutorRevisionAction_EXECUTOR_REVISION_ACTION_CREATED
+	case models.ExecutorRevisionUpdated:
+		return pb.ExecutorRevisionAction_EXECUTOR_REVISION_ACTION_UPDATED
+	case models.ExecutorRevisionStateChanged:
+		return pb.ExecutorRevisionAction_EXECUTOR_REVISION_ACTION_STATE_CHANGED
+	case models.ExecutorRevisionDeleted:
+		return pb.ExecutorRevisionAction_EXECUTOR_REVISION_ACTION_DELETED
+	case models.ExecutorRevisionUndeleted:
+		return pb.ExecutorRevisionAction_EXECUTOR_REVISION_ACTION_UNDELETED
+	case models.ExecutorRevisionRolledBack:
+		return pb.ExecutorRevisionAction_EXECUTOR_REVISION_ACTION_ROLLED_BACK
+	default:
+		return pb.ExecutorRevisionAction_EXECUTOR_REVISION_ACTION_UNSPECIFIED
+	}
+}
@@ -0,0 +1,100 @@
+package manager
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/botashev/tasks-executor/pkg/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestDiffExecutorConfigs(t *testing.T) {
+	base := func() *models.ExecutorConfig {
+		return &models.ExecutorConfig{
+			Name:    "orders",
+			Enabled: true,
+			State:   models.ExecutorStateActive,
+			RetryPolicy: models.RetryPolicy{
+				Type:        models.RetryPolicyExponential,
+				MaxAttempts: 3,
+				Interval:    5 * time.Second,
+			},
+			DLQConfig:   models.DLQConfig{Enabled: true, QueueName: "orders_dlq"},
+			MaxInFlight: 10,
+		}
+	}
+	deletedAt := time.Now()
+
+	tests := []struct {
+		name   string
+		before *models.ExecutorConfig
+		change func(c *models.ExecutorConfig)
+		want   []models.ExecutorFieldChange
+	}{
+		{
+			name:   "no changes",
+			before: base(),
+			change: func(c *models.ExecutorConfig) {},
+		},
+		{
+			name:   "top-level fields",
+			before: base(),
+			change: func(c *models.ExecutorConfig) {
+				c.State = models.ExecutorStatePaused
+				c.MaxInFlight = 0
+			},
+			want: []models.ExecutorFieldChange{
+				{Field: "state", OldValue: "active", NewValue: "paused"},
+				{Field: "max_in_flight", OldValue: "10", NewValue: "0"},
+			},
+		},
+		{
+			name:   "nested fields are named by path",
+			before: base(),
+			change: func(c *models.ExecutorConfig) {
+				c.RetryPolicy.MaxAttempts = 5
+				c.RetryPolicy.Interval = 500 * time.Millisecond
+				c.DLQConfig.FailureHandler = "alerts"
+			},
+			want: []models.ExecutorFieldChange{
+				{Field: "retry_policy.max_attempts", OldValue: "3", NewValue: "5"},
+				{Field: "retry_policy.interval", OldValue: "5s", NewValue: "500ms"},
+				{Field: "dlq_config.failure_handler", OldValue: "", NewValue: "alerts"},
+			},
+		},
+		{
+			name:   "bookkeeping fields are ignored",
+			before: base(),
+			change: func(c *models.ExecutorConfig) {
+				c.ID = primitive.NewObjectID()
+				c.Version++
+				c.DeletedAt = &deletedAt
+				c.CreatedAt = deletedAt
+				c.UpdatedAt = deletedAt
+			},
+		},
+		{
+			name:   "created from nothing",
+			before: &models.ExecutorConfig{},
+			change: func(c *models.ExecutorConfig) {
+				*c = models.ExecutorConfig{Name: "orders", Enabled: true, LeaseDuration: time.Minute}
+			},
+			want: []models.ExecutorFieldChange{
+				{Field: "name", OldValue: "", NewValue: "orders"},
+				{Field: "enabled", OldValue: "false", NewValue: "true"},
+				{Field: "lease_duration", OldValue: "0s", NewValue: "1m0s"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			after := *tt.before
+			tt.change(&after)
+			got := diffExecutorConfigs(tt.before, &after)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("diffExecutorConfigs() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
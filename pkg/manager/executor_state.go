@@ -0,0 +1,142 @@
+package manager
+
+import (
+	"context"
+
+	"github.com/botashev/tasks-executor/pkg/models"
+	pb "github.com/botashev/tasks-executor/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Service) PauseExecutor(ctx context.Context, req *pb.PauseExecutorRequest) (*pb.PauseExecutorResponse, error) {
+	executor, err := s.setExecutorState(ctx, req.GetName(), models.ExecutorStatePaused)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.PauseExecutorResponse{Executor: convertExecutorToProto(executor)}, nil
+}
+
+func (s *Service) ResumeExecutor(ctx context.Context, req *pb.ResumeExecutorRequest) (*pb.ResumeExecutorResponse, error) {
+	executor, err := s.setExecutorState(ctx, req.GetName(), models.ExecutorStateActive)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ResumeExecutorResponse{Executor: convertExecutorToProto(executor)}, nil
+}
+
+func (s *Service) DrainExecutor(ctx context.Context, req *pb.DrainExecutorRequest) (*pb.DrainExecutorResponse, error) {
+	executor, err := s.setExecutorState(ctx, req.GetName(), models.ExecutorStateDraining)
+	if err != nil {
+		return nil, err
+	}
+	remaining, err := s.storage.CountUnfinishedTasks(ctx, executor.Name)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.DrainExecutorResponse{
+		Executor:       convertExecutorToProto(executor),
+		RemainingTasks: remaining,
+	}, nil
+}
+
+func (s *Service) DisableExecutor(ctx context.Context, req *pb.DisableExecutorRequest) (*pb.DisableExecutorResponse, error) {
+	executor, err := s.setExecutorState(ctx, req.GetName(), models.ExecutorStateDisabled)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DisableExecutorResponse{Executor: convertExecutorToProto(executor)}, nil
+}
+
+func (s *Service) setExecutorState(ctx context.Context, name string, state models.ExecutorState) (*models.ExecutorConfig, error) {
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	// Only read for the revision, the state is changed regardless of it
+	before, err := s.storage.GetExecutor(ctx, name)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	executor, err := s.storage.SetExecutorState(ctx, name, state)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if executor == nil {
+		return nil, status.Error(codes.NotFound, "executor not found")
+	}
+	if err := s.recordRevision(ctx, newRevision(ctx, models.ExecutorRevisionStateChanged, before, executor)); err != nil {
+		return nil, err
+	}
+	return executor, nil
+}
+
+// checkDispatch returns the error GetNextTask reports for an executor whose
+// tasks may not be dispatched: Unavailable while it is paused, as the worker
+// only has to wait, and FailedPrecondition once it is disabled.
+func checkDispatch(executor *models.ExecutorConfig) error {
+	switch executor.CurrentState() {
+	case models.ExecutorStatePaused:
+		return status.Error(codes.Unavailable, "executor is paused")
+	case models.ExecutorStateDisabled:
+		return status.Error(codes.FailedPrecondition, "executor is disabled")
+	}
+	return nil
+}
+
+// checkSubmit returns the error AddTask reports for an executor that does not
+// accept new tasks.
+func checkSubmit(executor *models.ExecutorConfig) error {
+	switch executor.CurrentState() {
+	case models.ExecutorStateDraining:
+		return status.Error(codes.FailedPrecondition, "executor is draining and does not accept new tasks")
+	case models.ExecutorStateDisabled:
+		return status.Error(codes.FailedPrecondition, "executor is disabled")
+	}
+	return nil
+}
+
+// resolveExecutorState picks the state an executor is created or updated with.
+// Without an explicit state Enabled decides, except that updating an enabled
+// executor keeps it paused or draining.
+func resolveExecutorState(requested pb.ExecutorState, enabled bool, current *models.ExecutorConfig) models.ExecutorState {
+	if state := convertProtoExecutorState(requested); state != "" {
+		return state
+	}
+	if !enabled {
+		return models.ExecutorStateDisabled
+	}
+	if current != nil && current.CurrentState() != models.ExecutorStateDisabled {
+		return current.CurrentState()
+	}
+	return models.ExecutorStateActive
+}
+
+func convertExecutorState(state models.ExecutorState) pb.ExecutorState {
+	switch state {
+	case models.ExecutorStateActive:
+		return pb.ExecutorState_EXECUTOR_STATE_ACTIVE
+	case models.ExecutorStatePaused:
+		return pb.ExecutorState_EXECUTOR_STATE_PAUSED
+	case models.ExecutorStateDraining:
+		return pb.ExecutorState_EXECUTOR_STATE_DRAINING
+	case models.ExecutorStateDisabled:
+		return pb.ExecutorState_EXECUTOR_STATE_DISABLED
+	default:
+		return pb.ExecutorState_EXECUTOR_STATE_UNSPECIFIED
+	}
+}
+
+func convertProtoExecutorState(state pb.ExecutorState) models.ExecutorState {
+	switch state {
+	case pb.ExecutorState_EXECUTOR_STATE_ACTIVE:
+		return models.ExecutorStateActive
+	case pb.ExecutorState_EXECUTOR_STATE_PAUSED:
+		return models.ExecutorStatePaused
+	case pb.ExecutorState_EXECUTOR_STATE_DRAINING:
+		return models.ExecutorStateDraining
+	case pb.ExecutorState_EXECUTOR_STATE_DISABLED:
+		return models.ExecutorStateDisabled
+	default:
+		return ""
+	}
+}
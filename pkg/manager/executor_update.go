@@ -0,0 +1,124 @@
+package manager
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/botashev/tasks-executor/pkg/models"
+	pb "github.com/botashev/tasks-executor/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// executorTopLevelFields are the ExecutorConfig fields an update without a
+// field mask replaces. The name identifies the executor and the version is
+// maintained by the storage, so neither can be updated.
+var executorTopLevelFields = []string{
+	"enabled",
+	"state",
+	"write_concern",
+	"retry_policy",
+	"dlq_config",
+	"lease_duration",
+	"priority_aging",
+	"dedup_window",
+	"max_in_flight",
+	"rate_limit",
+}
+
+// executorNestedFields are the paths below the top-level fields a field mask
+// may name. They match the BSON field names of models.ExecutorConfig.
+var executorNestedFields = []string{
+	"write_concern.level",
+	"retry_policy.type",
+	"retry_policy.max_attempts",
+	"retry_policy.interval",
+	"retry_policy.max_interval",
+	"retry_policy.jitter",
+	"dlq_config.enabled",
+	"dlq_config.queue_name",
+	"dlq_config.failure_handler",
+	"dlq_config.max_age",
+	"dlq_config.max_entries",
+	"dlq_config.archive",
+	"priority_aging.threshold",
+	"priority_aging.step",
+	"priority_aging.max_priority",
+	"rate_limit.tasks_per_second",
+	"rate_limit.burst",
+}
+
+// executorUpdatePaths validates the field mask of an update and returns its
+// normalized paths, all updatable fields if the mask is empty.
+func executorUpdatePaths(mask *fieldmaskpb.FieldMask) ([]string, error) {
+	if len(mask.GetPaths()) == 0 {
+		return slices.Clone(executorTopLevelFields), nil
+	}
+	for _, path := range mask.Paths {
+		if !slices.Contains(executorTopLevelFields, path) && !slices.Contains(executorNestedFields, path) {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("field %q cannot be updated", path))
+		}
+	}
+	normalized := &fieldmaskpb.FieldMask{Paths: slices.Clone(mask.Paths)}
+	normalized.Normalize()
+	return normalized.Paths, nil
+}
+
+// applyFieldMask copies the fields named by paths from src to dst. A field
+// unset in src is cleared in dst. The paths must be valid for both messages.
+func applyFieldMask(dst, src proto.Message, paths []string) {
+	for _, path := range paths {
+		to, from := dst.ProtoReflect(), src.ProtoReflect()
+		names := strings.Split(path, ".")
+		for _, name := range names[:len(names)-1] {
+			field := to.Descriptor().Fields().ByName(protoreflect.Name(name))
+			to = to.Mutable(field).Message()
+			from = from.Get(field).Message()
+		}
+
+		field := to.Descriptor().Fields().ByName(protoreflect.Name(names[len(names)-1]))
+		if from.Has(field) {
+			to.Set(field, from.Get(field))
+		} else {
+			to.Clear(field)
+		}
+	}
+}
+
+// executorConfigFromProto converts a complete executor configuration, unset
+// messages become zero values.
+func executorConfigFromProto(config *pb.ExecutorConfig) *models.ExecutorConfig {
+	return &models.ExecutorConfig{
+		Name:    config.GetName(),
+		Enabled: config.GetEnabled(),
+		State:   convertProtoExecutorState(config.GetState()),
+		WriteConcern: models.WriteConcern{
+			Level: convertProtoWriteConcernLevel(config.GetWriteConcern().GetLevel()),
+		},
+		RetryPolicy: models.RetryPolicy{
+			Type:        convertProtoRetryPolicyType(config.GetRetryPolicy().GetType()),
+			MaxAttempts: int(config.GetRetryPolicy().GetMaxAttempts()),
+			Interval:    config.GetRetryPolicy().GetInterval().AsDuration(),
+			MaxInterval: config.GetRetryPolicy().GetMaxInterval().AsDuration(),
+			Jitter:      config.GetRetryPolicy().GetJitter(),
+		},
+		DLQConfig: models.DLQConfig{
+			Enabled:        config.GetDlqConfig().GetEnabled(),
+			QueueName:      config.GetDlqConfig().GetQueueName(),
+			FailureHandler: config.GetDlqConfig().GetFailureHandler(),
+			MaxAge:         config.GetDlqConfig().GetMaxAge().AsDuration(),
+			MaxEntries:     config.GetDlqConfig().GetMaxEntries(),
+			Archive:        config.GetDlqConfig().GetArchive(),
+		},
+		LeaseDuration: config.GetLeaseDuration().AsDuration(),
+		PriorityAging: convertProtoPriorityAging(config.GetPriorityAging()),
+		DedupWindow:   config.GetDedupWindow().AsDuration(),
+		MaxInFlight:   int(config.GetMaxInFlight()),
+		RateLimit:     convertProtoRateLimit(config.GetRateLimit()),
+		Version:       config.GetVersion(),
+	}
+}
@@ -0,0 +1,188 @@
+package manager
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/botashev/tasks-executor/pkg/models"
+	pb "github.com/botashev/tasks-executor/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestExecutorUpdatePaths(t *testing.T) {
+	tests := []struct {
+		name  string
+		paths []string
+		want  []string
+		code  codes.Code
+	}{
+		{name: "empty mask updates all fields", paths: nil, want: executorTopLevelFields},
+		{name: "nested path", paths: []string{"retry_policy.max_attempts"}, want: []string{"retry_policy.max_attempts"}},
+		{name: "parent covers child", paths: []string{"retry_policy.jitter", "retry_policy"}, want: []string{"retry_policy"}},
+		{name: "sorted", paths: []string{"rate_limit", "enabled"}, want: []string{"enabled", "rate_limit"}},
+		{name: "name", paths: []string{"name"}, code: codes.InvalidArgument},
+		{name: "version", paths: []string{"version"}, code: codes.InvalidArgument},
+		{name: "unknown nested field", paths: []string{"retry_policy.unknown"}, code: codes.InvalidArgument},
+		{name: "one bad path rejects the mask", paths: []string{"enabled", "name"}, code: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mask *fieldmaskpb.FieldMask
+			if tt.paths != nil {
+				mask = &fieldmaskpb.FieldMask{Paths: tt.paths}
+			}
+			got, err := executorUpdatePaths(mask)
+			if tt.code != codes.OK {
+				if status.Code(err) != tt.code {
+					t.Fatalf("error = %v, want code %v", err, tt.code)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("paths = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyFieldMask(t *testing.T) {
+	current := func() *pb.ExecutorConfig {
+		return &pb.ExecutorConfig{
+			Name:    "orders",
+			Enabled: true,
+			RetryPolicy: &pb.RetryPolicy{
+				Type:        pb.RetryPolicyType_RETRY_POLICY_EXPONENTIAL,
+				MaxAttempts: 3,
+				Interval:    durationpb.New(5 * time.Second),
+			},
+			DlqConfig: &pb.DLQConfig{
+				Enabled:        true,
+				QueueName:      "orders_dlq",
+				FailureHandler: "alerts",
+			},
+			MaxInFlight: 10,
+			RateLimit:   &pb.RateLimit{TasksPerSecond: 5, Burst: 10},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		src   *pb.ExecutorConfig
+		paths []string
+		check func(t *testing.T, got *pb.ExecutorConfig)
+	}{
+		{
+			name:  "nested path keeps siblings",
+			src:   &pb.ExecutorConfig{RetryPolicy: &pb.RetryPolicy{MaxAttempts: 7, Jitter: 0.5}},
+			paths: []string{"retry_policy.max_attempts"},
+			check: func(t *testing.T, got *pb.ExecutorConfig) {
+				if got.RetryPolicy.MaxAttempts != 7 {
+					t.Errorf("max_attempts = %d, want 7", got.RetryPolicy.MaxAttempts)
+				}
+				if got.RetryPolicy.Jitter != 0 {
+					t.Errorf("jitter = %v, not in the mask but changed", got.RetryPolicy.Jitter)
+				}
+				if got.RetryPolicy.Interval.AsDuration() != 5*time.Second {
+					t.Errorf("interval = %v, want 5s", got.RetryPolicy.Interval.AsDuration())
+				}
+			},
+		},
+		{
+			name:  "unset nested field is cleared",
+			src:   &pb.ExecutorConfig{},
+			paths: []string{"dlq_config.failure_handler"},
+			check: func(t *testing.T, got *pb.ExecutorConfig) {
+				if got.DlqConfig.FailureHandler != "" {
+					t.Errorf("failure_handler = %q, want cleared", got.DlqConfig.FailureHandler)
+				}
+				if got.DlqConfig.QueueName != "orders_dlq" {
+					t.Errorf("queue_name = %q, want orders_dlq", got.DlqConfig.QueueName)
+				}
+			},
+		},
+		{
+			name:  "unset message is cleared",
+			src:   &pb.ExecutorConfig{},
+			paths: []string{"rate_limit", "max_in_flight"},
+			check: func(t *testing.T, got *pb.ExecutorConfig) {
+				if got.RateLimit != nil {
+					t.Errorf("rate_limit = %v, want cleared", got.RateLimit)
+				}
+				if got.MaxInFlight != 0 {
+					t.Errorf("max_in_flight = %d, want 0", got.MaxInFlight)
+				}
+			},
+		},
+		{
+			name:  "whole message is replaced",
+			src:   &pb.ExecutorConfig{RetryPolicy: &pb.RetryPolicy{MaxAttempts: 1}},
+			paths: []string{"retry_policy"},
+			check: func(t *testing.T, got *pb.ExecutorConfig) {
+				want := &pb.RetryPolicy{MaxAttempts: 1}
+				if !proto.Equal(got.RetryPolicy, want) {
+					t.Errorf("retry_policy = %v, want %v", got.RetryPolicy, want)
+				}
+			},
+		},
+		{
+			name:  "fields outside the mask are kept",
+			src:   &pb.ExecutorConfig{Name: "renamed", Enabled: false, MaxInFlight: 1},
+			paths: []string{"enabled"},
+			check: func(t *testing.T, got *pb.ExecutorConfig) {
+				if got.Enabled || got.Name != "orders" || got.MaxInFlight != 10 {
+					t.Errorf("got enabled=%v name=%q max_in_flight=%d, want false orders 10", got.Enabled, got.Name, got.MaxInFlight)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := current()
+			applyFieldMask(got, tt.src, tt.paths)
+			tt.check(t, got)
+		})
+	}
+}
+
+// The allow-list doubles as the list of BSON paths UpdateExecutor sets, so every
+// path must exist both in the proto message and in models.ExecutorConfig.
+func TestExecutorUpdatableFieldsExist(t *testing.T) {
+	for _, path := range append(slices.Clone(executorTopLevelFields), executorNestedFields...) {
+		message := (&pb.ExecutorConfig{}).ProtoReflect().Descriptor()
+		typ := reflect.TypeOf(models.ExecutorConfig{})
+		for _, name := range strings.Split(path, ".") {
+			if message == nil {
+				t.Fatalf("%s: %s is not a message field", path, name)
+			}
+			field := message.Fields().ByName(protoreflect.Name(name))
+			if field == nil {
+				t.Fatalf("%s: no proto field %s", path, name)
+			}
+			message = field.Message()
+
+			found := false
+			for i := 0; i < typ.NumField(); i++ {
+				tag, _, _ := strings.Cut(typ.Field(i).Tag.Get("bson"), ",")
+				if tag == name {
+					typ = typ.Field(i).Type
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Fatalf("%s: no BSON field %s", path, name)
+			}
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package manager
+
+import (
+	"github.com/botashev/tasks-executor/pkg/models"
+)
+
+// releaseInFlight wakes up to n workers waiting for a max_in_flight slot of the
+// executor after n of its tasks have left the in_progress status. The slots
+// are held by the tasks themselves and are free once they leave it.
+func (s *Service) releaseInFlight(executor *models.ExecutorConfig, n int) {
+	if executor == nil || executor.MaxInFlight <= 0 {
+		return
+	}
+	for i := 0; i < n; i++ {
+		s.notifier.notify(executor.Name)
+	}
+}
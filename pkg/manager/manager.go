@@ -2,13 +2,44 @@ package manager
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	pb "github.com/botashev/tasks-executor/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
+// ErrTaskCancelled is returned by Heartbeat when the task has been cancelled
+// and its processing should stop.
+var ErrTaskCancelled = errors.New("task cancelled")
+
+// RateLimitedError is returned when no task was leased because of the
+// executor's rate limit. Asking for tasks again before RetryAfter is pointless.
+type RateLimitedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string {
+	return fmt.Sprintf("executor is rate limited, retry after %v", e.RetryAfter)
+}
+
+// rateLimited converts a ResourceExhausted error carrying RetryInfo into a
+// RateLimitedError and returns other errors unchanged.
+func rateLimited(err error) error {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok && status.Code(err) == codes.ResourceExhausted {
+			return &RateLimitedError{RetryAfter: info.RetryDelay.AsDuration()}
+		}
+	}
+	return err
+}
+
 type Manager struct {
 	client pb.TaskExecutorManagerClient
 }
@@ -35,6 +66,48 @@ func (m *Manager) RegisterExecutor(executorName string) error {
 	return err
 }
 
+// WorkerInfo describes a worker process registered with RegisterWorker.
+type WorkerInfo struct {
+	ID           string   // Unique ID of the worker process
+	Hostname     string   // Host the worker runs on
+	Version      string   // Version of the worker build
+	Capabilities []string // Free-form features the worker supports
+	Concurrency  int      // How many tasks the worker processes at once
+}
+
+// RegisterWorker registers a worker process of the executor and returns how
+// often it has to call HeartbeatWorker to stay alive.
+func (m *Manager) RegisterWorker(executorName string, info WorkerInfo) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := m.client.RegisterExecutor(ctx, &pb.RegisterExecutorRequest{
+		ExecutorName: executorName,
+		WorkerId:     info.ID,
+		Hostname:     info.Hostname,
+		Version:      info.Version,
+		Capabilities: info.Capabilities,
+		Concurrency:  int32(info.Concurrency),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return resp.HeartbeatInterval.AsDuration(), nil
+}
+
+// HeartbeatWorker reports that a registered worker is alive. A NotFound error
+// means the worker has been marked dead and has to register again.
+func (m *Manager) HeartbeatWorker(workerID string, activeTasks int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := m.client.HeartbeatWorker(ctx, &pb.HeartbeatWorkerRequest{
+		WorkerId:    workerID,
+		ActiveTasks: int32(activeTasks),
+	})
+	return err
+}
+
 func (m *Manager) GetNextTask(executorName string) (*pb.Task, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -42,20 +115,156 @@ func (m *Manager) GetNextTask(executorName string) (*pb.Task, error) {
 	resp, err := m.client.GetNextTask(ctx, &pb.GetNextTaskRequest{
 		ExecutorName: executorName,
 	})
+	if err != nil {
+		return nil, rateLimited(err)
+	}
+	return resp.Task, nil
+}
+
+// WaitNextTask leases the next task of the executor, waiting up to wait for one
+// to become available. It returns a nil task if none has appeared in time and
+// a RateLimitedError if the rate limit will not allow one in time.
+// Unlike the other calls it takes a context, so that a long wait can be interrupted.
+func (m *Manager) WaitNextTask(ctx context.Context, executorName string, wait time.Duration) (*pb.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, wait+5*time.Second)
+	defer cancel()
+
+	resp, err := m.client.GetNextTask(ctx, &pb.GetNextTaskRequest{
+		ExecutorName: executorName,
+		WaitTimeout:  durationpb.New(wait),
+	})
 	if err != nil {
 		return nil, err
 	}
+	if resp.Task == nil && resp.RetryAfter != nil {
+		return nil, &RateLimitedError{RetryAfter: resp.RetryAfter.AsDuration()}
+	}
 	return resp.Task, nil
 }
 
-func (m *Manager) UpdateTaskStatus(taskID string, status pb.TaskStatus, errorMsg string) error {
+// WaitNextTasks leases up to maxTasks tasks of the executor in one call, waiting
+// up to wait for some to become available. Every task is leased to this caller only.
+func (m *Manager) WaitNextTasks(ctx context.Context, executorName string, maxTasks int, wait time.Duration) ([]*pb.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, wait+5*time.Second)
+	defer cancel()
+
+	resp, err := m.client.GetNextTask(ctx, &pb.GetNextTaskRequest{
+		ExecutorName: executorName,
+		WaitTimeout:  durationpb.New(wait),
+		MaxTasks:     int32(maxTasks),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Tasks) == 0 && resp.RetryAfter != nil {
+		return nil, &RateLimitedError{RetryAfter: resp.RetryAfter.AsDuration()}
+	}
+	return resp.Tasks, nil
+}
+
+// UpdateTaskStatuses reports the outcome of several tasks at once. The results
+// follow the order of updates; a non-zero Code means that update was rejected.
+func (m *Manager) UpdateTaskStatuses(updates []*pb.UpdateTaskStatusRequest) ([]*pb.UpdateTaskStatusResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := m.client.UpdateTaskStatuses(ctx, &pb.UpdateTaskStatusesRequest{Updates: updates})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Results, nil
+}
+
+// UpdateTaskStatus reports the outcome of a task leased under leaseID (the
+// LeaseId of the leased task). An Aborted error means the task is no longer
+// held under that lease, e.g. it was reclaimed after the lease expired.
+func (m *Manager) UpdateTaskStatus(taskID, leaseID string, status pb.TaskStatus, errorMsg string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	_, err := m.client.UpdateTaskStatus(ctx, &pb.UpdateTaskStatusRequest{
-		Id:     taskID,
-		Status: status,
-		Error:  errorMsg,
+		Id:      taskID,
+		LeaseId: leaseID,
+		Status:  status,
+		Error:   errorMsg,
+	})
+	return err
+}
+
+// CompleteTask reports successful processing of a task leased under leaseID
+// together with its result.
+func (m *Manager) CompleteTask(taskID, leaseID string, result []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := m.client.UpdateTaskStatus(ctx, &pb.UpdateTaskStatusRequest{
+		Id:      taskID,
+		LeaseId: leaseID,
+		Status:  pb.TaskStatus_TASK_STATUS_COMPLETED,
+		Result:  result,
+	})
+	return err
+}
+
+// Heartbeat extends the lease leaseID of a task that is still being processed
+// and returns the new lease expiration time. If the task has been cancelled the
+// lease is still extended and ErrTaskCancelled is returned.
+func (m *Manager) Heartbeat(taskID, leaseID string) (time.Time, error) {
+	return m.heartbeat(&pb.HeartbeatTaskRequest{Id: taskID, LeaseId: leaseID})
+}
+
+// HeartbeatWithProgress extends the lease of a task like Heartbeat and
+// records how far the processing has got.
+func (m *Manager) HeartbeatWithProgress(taskID, leaseID string, percent float64, message string) (time.Time, error) {
+	return m.heartbeat(&pb.HeartbeatTaskRequest{
+		Id:      taskID,
+		LeaseId: leaseID,
+		Progress: &pb.TaskProgress{
+			Percent: percent,
+			Message: message,
+		},
+	})
+}
+
+func (m *Manager) heartbeat(req *pb.HeartbeatTaskRequest) (time.Time, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := m.client.HeartbeatTask(ctx, req)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if resp.CancelRequested {
+		return resp.LeaseExpiresAt.AsTime(), ErrTaskCancelled
+	}
+	return resp.LeaseExpiresAt.AsTime(), nil
+}
+
+// ReleaseTask returns a task leased under leaseID that the caller has not
+// finished, e.g. because it is shutting down, to the queue without counting a
+// failed attempt.
+func (m *Manager) ReleaseTask(taskID, leaseID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := m.client.ReleaseTask(ctx, &pb.ReleaseTaskRequest{
+		Id:      taskID,
+		LeaseId: leaseID,
 	})
 	return err
 }
+
+// CancelTask cancels a pending task or asks the worker of a running task to stop it.
+func (m *Manager) CancelTask(taskID string, reason string) (*pb.Task, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := m.client.CancelTask(ctx, &pb.CancelTaskRequest{
+		Id:     taskID,
+		Reason: reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Task, nil
+}
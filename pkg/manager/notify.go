@@ -0,0 +1,97 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/botashev/tasks-executor/pkg/storage"
+)
+
+/*
+taskNotifier wakes GetNextTask calls waiting for tasks of an executor.
+Every notification wakes at most one waiter, so a single new task does not
+send all waiting workers to the database at once.
+*/
+type taskNotifier struct {
+	mu      sync.Mutex
+	waiters map[string][]chan struct{}
+}
+
+func newTaskNotifier() *taskNotifier {
+	return &taskNotifier{waiters: make(map[string][]chan struct{})}
+}
+
+// subscribe registers a waiter for tasks of the executor. The returned channel
+// receives a value when a task may have become available; cancel must be
+// called once the waiter is done.
+func (n *taskNotifier) subscribe(executorName string) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	n.mu.Lock()
+	n.waiters[executorName] = append(n.waiters[executorName], ch)
+	n.mu.Unlock()
+
+	return ch, func() {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		waiters := n.waiters[executorName]
+		for i, waiter := range waiters {
+			if waiter == ch {
+				waiters = append(waiters[:i], waiters[i+1:]...)
+				break
+			}
+		}
+		if len(waiters) == 0 {
+			delete(n.waiters, executorName)
+		} else {
+			n.waiters[executorName] = waiters
+		}
+	}
+}
+
+// notify wakes the longest waiting waiter of the executor that has not been woken yet.
+func (n *taskNotifier) notify(executorName string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, waiter := range n.waiters[executorName] {
+		select {
+		case waiter <- struct{}{}:
+			return
+		default:
+		}
+	}
+}
+
+/*
+RunTaskNotifications wakes waiting GetNextTask calls when another manager
+replica makes a task available, using MongoDB change streams. Tasks made
+available by this replica wake waiters directly. On deployments without
+change streams it returns at once and waiters of other replicas find new
+tasks by re-polling.
+It blocks until ctx is cancelled.
+*/
+func (s *Service) RunTaskNotifications(ctx context.Context) {
+	for {
+		err := s.storage.WatchPendingTasks(ctx, s.notifier.notify)
+		if errors.Is(err, storage.ErrWatchUnsupported) {
+			log.Println("MongoDB deployment does not support change streams, waiting workers rely on polling")
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("Error watching tasks, restarting: %v", err)
+		sleepContext(ctx, 5*time.Second)
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
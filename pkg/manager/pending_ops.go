@@ -0,0 +1,33 @@
+package manager
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+/*
+RunPendingOpsReplay periodically completes DLQ transitions that were interrupted
+by a crash on MongoDB deployments without transactions. Operations younger than
+interval are considered in flight and left for the next run.
+It blocks until ctx is cancelled.
+*/
+func (s *Service) RunPendingOpsReplay(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			replayed, err := s.storage.ReplayPendingOps(ctx, time.Now().Add(-interval))
+			if err != nil {
+				log.Printf("Error replaying pending operations: %v", err)
+			}
+			if replayed > 0 {
+				log.Printf("Replayed %d interrupted DLQ transitions", replayed)
+			}
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package manager
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/botashev/tasks-executor/pkg/models"
+	"github.com/botashev/tasks-executor/pkg/storage"
+	pb "github.com/botashev/tasks-executor/proto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+// pageToken is the decoded form of the opaque page tokens returned by list RPCs.
+type pageToken struct {
+	CreatedAt time.Time          `json:"c"`
+	ID        primitive.ObjectID `json:"i"`
+}
+
+func (s *Service) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
+	pageSize, err := normalizePageSize(req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	after, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := storage.TaskFilter{
+		ExecutorName:  req.ExecutorName,
+		CreatedAfter:  timeOrZero(req.CreatedAfter),
+		CreatedBefore: timeOrZero(req.CreatedBefore),
+		UpdatedAfter:  timeOrZero(req.UpdatedAfter),
+		UpdatedBefore: timeOrZero(req.UpdatedBefore),
+		Metadata:      req.Metadata,
+	}
+	for _, st := range req.Statuses {
+		if st == pb.TaskStatus_TASK_STATUS_UNSPECIFIED {
+			return nil, status.Error(codes.InvalidArgument, "statuses must not contain TASK_STATUS_UNSPECIFIED")
+		}
+		filter.Statuses = append(filter.Statuses, convertProtoTaskStatus(st))
+	}
+
+	// One extra task tells whether there is a next page.
+	tasks, err := s.storage.ListTasks(ctx, filter, after, pageSize+1)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.ListTasksResponse{}
+	if len(tasks) > pageSize {
+		tasks = tasks[:pageSize]
+		resp.NextPageToken = encodePageToken(tasks[len(tasks)-1])
+	}
+	resp.Tasks = make([]*pb.Task, len(tasks))
+	for i, task := range tasks {
+		resp.Tasks[i] = convertTaskToProto(task)
+	}
+	return resp, nil
+}
+
+func normalizePageSize(pageSize int32) (int, error) {
+	switch {
+	case pageSize < 0:
+		return 0, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	case pageSize == 0:
+		return defaultPageSize, nil
+	case pageSize > maxPageSize:
+		return maxPageSize, nil
+	default:
+		return int(pageSize), nil
+	}
+}
+
+func encodePageToken(task *models.Task) string {
+	b, _ := json.Marshal(pageToken{CreatedAt: task.CreatedAt, ID: task.ID})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodePageToken(token string) (*storage.TaskCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+	var t pageToken
+	if err := json.Unmarshal(b, &t); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+	return &storage.TaskCursor{CreatedAt: t.CreatedAt, ID: t.ID}, nil
+}
+
+// timeOrZero returns the zero time for an unset timestamp.
+func timeOrZero(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
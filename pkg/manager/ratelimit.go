@@ -0,0 +1,78 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/botashev/tasks-executor/pkg/models"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// minRetryAfter keeps throttled workers from coming back in a busy loop when
+// the next token is due almost immediately.
+const minRetryAfter = 10 * time.Millisecond
+
+// rateLimitError is returned by leaseTasks when the executor's rate limit
+// bucket is empty.
+type rateLimitError struct {
+	retryAfter time.Duration
+}
+
+func (e *rateLimitError) Error() string {
+	return fmt.Sprintf("executor has reached its rate limit, retry after %v", e.retryAfter)
+}
+
+// GRPCStatus lets status.Convert report the wait to the client as RetryInfo.
+func (e *rateLimitError) GRPCStatus() *status.Status {
+	st := status.New(codes.ResourceExhausted, e.Error())
+	if withDetails, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(e.retryAfter)}); err == nil {
+		return withDetails
+	}
+	return st
+}
+
+func validateRateLimit(limit models.RateLimit) error {
+	if math.IsNaN(limit.TasksPerSecond) || math.IsInf(limit.TasksPerSecond, 0) || limit.TasksPerSecond < 0 {
+		return status.Error(codes.InvalidArgument, "rate_limit.tasks_per_second must be a non-negative number")
+	}
+	if limit.Burst < 0 {
+		return status.Error(codes.InvalidArgument, "rate_limit.burst must not be negative")
+	}
+	return nil
+}
+
+// takeRateTokens takes up to n tokens from the executor's rate limit bucket.
+// Executors without a rate limit always get n.
+func (s *Service) takeRateTokens(ctx context.Context, executor *models.ExecutorConfig, n int) (int, error) {
+	if executor.RateLimit.TasksPerSecond <= 0 {
+		return n, nil
+	}
+	taken, wait, err := s.storage.TakeRateTokens(ctx, executor.Name, executor.RateLimit, n)
+	if err != nil {
+		return 0, err
+	}
+	if taken == 0 {
+		if wait < minRetryAfter {
+			wait = minRetryAfter
+		}
+		return 0, &rateLimitError{retryAfter: wait}
+	}
+	return taken, nil
+}
+
+// returnRateTokens puts back the tokens of n tasks that were not dispatched,
+// so an empty queue does not use up the bucket.
+func (s *Service) returnRateTokens(ctx context.Context, executor *models.ExecutorConfig, n int) {
+	if executor.RateLimit.TasksPerSecond <= 0 || n <= 0 {
+		return
+	}
+	if err := s.storage.ReturnRateTokens(ctx, executor.Name, executor.RateLimit, n); err != nil {
+		log.Printf("Error returning rate limit tokens of executor %s: %v", executor.Name, err)
+	}
+}
@@ -0,0 +1,73 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/botashev/tasks-executor/pkg/models"
+	"github.com/botashev/tasks-executor/pkg/storage"
+)
+
+// leaseExpiredError is recorded on tasks that were reclaimed after their lease expired.
+const leaseExpiredError = "task lease expired"
+
+/*
+RunLeaseReaper periodically returns in-progress tasks with an expired lease back
+to the queue. Every expiry counts as a failed attempt, so the executor's retry
+policy and DLQ settings apply just as if the worker had reported a failure.
+A task whose cancellation was requested is cancelled instead, also when its
+executor has been deleted. It blocks until ctx is cancelled.
+*/
+func (s *Service) RunLeaseReaper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.reapExpiredTasks(ctx); err != nil {
+				log.Printf("Error reclaiming expired tasks: %v", err)
+			}
+		}
+	}
+}
+
+func (s *Service) reapExpiredTasks(ctx context.Context) error {
+	tasks, err := s.storage.GetExpiredTasks(ctx)
+	if err != nil {
+		return err
+	}
+
+	executors := make(map[string]*models.ExecutorConfig)
+	for _, task := range tasks {
+		executor, ok := executors[task.ExecutorName]
+		if !ok {
+			executor, err = s.storage.GetExecutor(ctx, task.ExecutorName)
+			if err != nil {
+				return err
+			}
+			executors[task.ExecutorName] = executor
+		}
+		if executor == nil && !task.CancelRequested {
+			log.Printf("Skipping expired task %s: executor %s not found", task.ID.Hex(), task.ExecutorName)
+			continue
+		}
+
+		err := s.failTask(ctx, executor, task, leaseExpiredError)
+		if errors.Is(err, storage.ErrStatusConflict) {
+			// The worker reported the outcome after the task was fetched
+			continue
+		}
+		if err != nil {
+			log.Printf("Error reclaiming task %s: %v", task.ID.Hex(), err)
+			continue
+		}
+		s.releaseInFlight(executor, 1)
+		log.Printf("Reclaimed task %s of executor %s after lease expiry", task.ID.Hex(), task.ExecutorName)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/botashev/tasks-executor/pkg/models"
+	"github.com/botashev/tasks-executor/pkg/storage"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// dlqRetentionBatchSize is the number of DLQ tasks evicted at once.
+const dlqRetentionBatchSize = 500
+
+// dlqEvictionClaimTTL is how long DLQ tasks claimed for archiving are left to
+// the replica that claimed them before another one takes them over.
+const dlqEvictionClaimTTL = 10 * time.Minute
+
+/*
+RunDLQRetention periodically enforces the DLQ retention settings of every executor:
+tasks older than MaxAge and tasks above MaxEntries (oldest first) are removed.
+Expired tasks of executors without archiving are normally removed by the TTL index
+already; this loop catches tasks that were dead-lettered before MaxAge was set.
+With Archive enabled the tasks are appended to an NDJSON file in archiveDir first.
+It blocks until ctx is cancelled.
+*/
+func (s *Service) RunDLQRetention(ctx context.Context, interval time.Duration, archiveDir string) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.enforceDLQRetention(ctx, archiveDir); err != nil {
+				log.Printf("Error enforcing DLQ retention: %v", err)
+			}
+		}
+	}
+}
+
+func (s *Service) enforceDLQRetention(ctx context.Context, archiveDir string) error {
+	executors, err := s.storage.ListExecutors(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, executor := range executors {
+		config := executor.DLQConfig
+		if !config.Enabled || (config.MaxAge <= 0 && config.MaxEntries <= 0) {
+			continue
+		}
+		if err := s.enforceExecutorDLQRetention(ctx, executor, archiveDir); err != nil {
+			log.Printf("Error enforcing DLQ retention of executor %s: %v", executor.Name, err)
+		}
+	}
+	return nil
+}
+
+func (s *Service) enforceExecutorDLQRetention(ctx context.Context, executor *models.ExecutorConfig, archiveDir string) error {
+	config := executor.DLQConfig
+	if config.MaxAge > 0 {
+		movedBefore := time.Now().Add(-config.MaxAge)
+		for {
+			tasks, err := s.storage.OldestDLQTasks(ctx, executor.Name, movedBefore, dlqRetentionBatchSize)
+			if err != nil {
+				return err
+			}
+			if len(tasks) == 0 {
+				break
+			}
+			evicted, err := s.evictDLQTasks(ctx, executor, tasks, archiveDir)
+			if err != nil {
+				return err
+			}
+			// Nothing evicted: another replica is evicting the same tasks
+			if evicted == 0 || len(tasks) < dlqRetentionBatchSize {
+				break
+			}
+		}
+	}
+
+	if config.MaxEntries > 0 {
+		size, _, err := s.storage.GetDLQStats(ctx, executor.Name)
+		if err != nil {
+			return err
+		}
+		for excess := size - config.MaxEntries; excess > 0; {
+			tasks, err := s.storage.OldestDLQTasks(ctx, executor.Name, time.Time{}, int(min(excess, dlqRetentionBatchSize)))
+			if err != nil {
+				return err
+			}
+			if len(tasks) == 0 {
+				break
+			}
+			evicted, err := s.evictDLQTasks(ctx, executor, tasks, archiveDir)
+			if err != nil {
+				return err
+			}
+			if evicted == 0 {
+				break
+			}
+			excess -= evicted
+		}
+	}
+	return nil
+}
+
+/*
+evictDLQTasks removes tasks from the DLQ and returns how many were removed.
+If the executor asks for archiving, the tasks are claimed first and only the
+claimed ones are archived, so replicas evicting at the same time do not
+archive a task twice.
+*/
+func (s *Service) evictDLQTasks(ctx context.Context, executor *models.ExecutorConfig, tasks []*models.Task, archiveDir string) (int64, error) {
+	ids := make([]primitive.ObjectID, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+
+	var evicted int64
+	var err error
+	if executor.DLQConfig.Archive {
+		claim := primitive.NewObjectID().Hex()
+		tasks, err = s.storage.ClaimDLQTasks(ctx, ids, claim, time.Now().Add(-dlqEvictionClaimTTL))
+		if err != nil {
+			return 0, err
+		}
+		if len(tasks) == 0 {
+			return 0, nil
+		}
+		if err := archiveDLQTasks(archiveDir, executor.Name, tasks); err != nil {
+			return 0, fmt.Errorf("failed to archive DLQ tasks: %v", err)
+		}
+		evicted, err = s.storage.DeleteClaimedDLQTasks(ctx, claim)
+	} else {
+		evicted, err = s.storage.PurgeDLQ(ctx, storage.TaskFilter{ExecutorName: executor.Name, IDs: ids})
+	}
+	if err != nil {
+		return 0, err
+	}
+	log.Printf("Evicted %d DLQ tasks of executor %s", evicted, executor.Name)
+	return evicted, nil
+}
+
+// archiveDLQTasks appends tasks to the executor's daily NDJSON archive and syncs it to disk.
+func archiveDLQTasks(dir, executorName string, tasks []*models.Task) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	name := strings.ReplaceAll(executorName, string(os.PathSeparator), "_")
+	path := filepath.Join(dir, fmt.Sprintf("%s_dlq_%s.ndjson", name, time.Now().UTC().Format("2006-01-02")))
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	err = writeArchive(f, tasks)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// writeArchive encodes tasks as NDJSON into f and syncs it to disk.
+func writeArchive(f *os.File, tasks []*models.Task) error {
+	enc := json.NewEncoder(f)
+	for _, task := range tasks {
+		if err := enc.Encode(convertTaskToProto(task)); err != nil {
+			return err
+		}
+	}
+	return f.Sync()
+}
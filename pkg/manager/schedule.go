@@ -0,0 +1,256 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"text/template"
+	"time"
+
+	"github.com/botashev/tasks-executor/pkg/models"
+	"github.com/botashev/tasks-executor/pkg/storage"
+	pb "github.com/botashev/tasks-executor/proto"
+	"github.com/robfig/cron/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// cronParser accepts standard five-field expressions and descriptors such as @daily.
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// scheduleTemplateData is available to the payload template of a schedule.
+type scheduleTemplateData struct {
+	ScheduledAt  string // Tick time in RFC3339
+	ScheduleName string
+}
+
+func (s *Service) CreateSchedule(ctx context.Context, req *pb.CreateScheduleRequest) (*pb.CreateScheduleResponse, error) {
+	if req == nil || req.Schedule == nil {
+		return nil, status.Error(codes.InvalidArgument, "request or schedule is nil")
+	}
+	if req.Schedule.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	executor, err := s.storage.GetExecutor(ctx, req.Schedule.ExecutorName)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if executor == nil {
+		return nil, status.Error(codes.NotFound, "executor not found")
+	}
+
+	schedule := &models.Schedule{
+		Name:           req.Schedule.Name,
+		CronExpression: req.Schedule.CronExpression,
+		Timezone:       req.Schedule.Timezone,
+		ExecutorName:   req.Schedule.ExecutorName,
+		Data:           req.Schedule.Data,
+		Metadata:       req.Schedule.Metadata,
+		Paused:         req.Schedule.Paused,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	if _, err := template.New(schedule.Name).Parse(string(schedule.Data)); err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid data template: %v", err))
+	}
+	nextRunAt, err := nextScheduleRun(schedule, time.Now())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	schedule.NextRunAt = nextRunAt
+
+	if err := s.storage.CreateSchedule(ctx, schedule); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to create schedule: %v", err))
+	}
+
+	return &pb.CreateScheduleResponse{
+		Schedule: convertScheduleToProto(schedule),
+	}, nil
+}
+
+func (s *Service) ListSchedules(ctx context.Context, req *pb.ListSchedulesRequest) (*pb.ListSchedulesResponse, error) {
+	schedules, err := s.storage.ListSchedules(ctx, req.ExecutorName)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	result := make([]*pb.Schedule, len(schedules))
+	for i, schedule := range schedules {
+		result[i] = convertScheduleToProto(schedule)
+	}
+	return &pb.ListSchedulesResponse{
+		Schedules: result,
+	}, nil
+}
+
+func (s *Service) DeleteSchedule(ctx context.Context, req *pb.DeleteScheduleRequest) (*pb.DeleteScheduleResponse, error) {
+	if req == nil || req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	err := s.storage.DeleteSchedule(ctx, req.Name)
+	if errors.Is(err, storage.ErrScheduleNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.DeleteScheduleResponse{}, nil
+}
+
+func (s *Service) PauseSchedule(ctx context.Context, req *pb.PauseScheduleRequest) (*pb.PauseScheduleResponse, error) {
+	if req == nil || req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	schedule, err := s.storage.GetSchedule(ctx, req.Name)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if schedule == nil {
+		return nil, status.Error(codes.NotFound, "schedule not found")
+	}
+
+	// Ticks missed while the schedule was paused are skipped on resume.
+	nextRunAt, err := nextScheduleRun(schedule, time.Now())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	schedule, err = s.storage.SetSchedulePaused(ctx, req.Name, req.Paused, nextRunAt)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if schedule == nil {
+		return nil, status.Error(codes.NotFound, "schedule not found")
+	}
+	return &pb.PauseScheduleResponse{
+		Schedule: convertScheduleToProto(schedule),
+	}, nil
+}
+
+/*
+RunScheduler periodically fires due schedules by adding tasks through AddTask.
+Each tick is claimed in storage before the task is added, so a schedule fires
+at most once per tick even when several manager replicas run the scheduler.
+It blocks until ctx is cancelled.
+*/
+func (s *Service) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.fireDueSchedules(ctx); err != nil {
+				log.Printf("Error firing schedules: %v", err)
+			}
+		}
+	}
+}
+
+func (s *Service) fireDueSchedules(ctx context.Context) error {
+	now := time.Now()
+	schedules, err := s.storage.GetDueSchedules(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for _, schedule := range schedules {
+		if err := s.fireSchedule(ctx, schedule, now); err != nil {
+			log.Printf("Error firing schedule %s: %v", schedule.Name, err)
+		}
+	}
+	return nil
+}
+
+func (s *Service) fireSchedule(ctx context.Context, schedule *models.Schedule, now time.Time) error {
+	scheduledAt := schedule.NextRunAt
+	// Only the latest missed tick is fired, the next one is computed from now.
+	nextRunAt, err := nextScheduleRun(schedule, now)
+	if err != nil {
+		return err
+	}
+
+	claimed, err := s.storage.ClaimScheduleRun(ctx, schedule.ID, scheduledAt, nextRunAt)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil
+	}
+
+	data, err := renderScheduleData(schedule, scheduledAt)
+	if err != nil {
+		return err
+	}
+	resp, err := s.AddTask(ctx, &pb.AddTaskRequest{
+		ExecutorName: schedule.ExecutorName,
+		Data:         data,
+		Metadata:     schedule.Metadata,
+	})
+	if err != nil {
+		return err
+	}
+	return s.storage.SetScheduleLastTask(ctx, schedule.ID, resp.Task.Id)
+}
+
+// nextScheduleRun returns the first tick of the schedule strictly after from.
+func nextScheduleRun(schedule *models.Schedule, from time.Time) (time.Time, error) {
+	expr, err := cronParser.Parse(schedule.CronExpression)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid cron expression: %v", err)
+	}
+	location := time.UTC
+	if schedule.Timezone != "" {
+		location, err = time.LoadLocation(schedule.Timezone)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid timezone: %v", err)
+		}
+	}
+	next := expr.Next(from.In(location))
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("cron expression %q never fires", schedule.CronExpression)
+	}
+	return next.UTC(), nil
+}
+
+func renderScheduleData(schedule *models.Schedule, scheduledAt time.Time) ([]byte, error) {
+	tmpl, err := template.New(schedule.Name).Parse(string(schedule.Data))
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, scheduleTemplateData{
+		ScheduledAt:  scheduledAt.Format(time.RFC3339),
+		ScheduleName: schedule.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func convertScheduleToProto(schedule *models.Schedule) *pb.Schedule {
+	if schedule == nil {
+		return nil
+	}
+	return &pb.Schedule{
+		Id:             schedule.ID.Hex(),
+		Name:           schedule.Name,
+		CronExpression: schedule.CronExpression,
+		Timezone:       schedule.Timezone,
+		ExecutorName:   schedule.ExecutorName,
+		Data:           schedule.Data,
+		Metadata:       schedule.Metadata,
+		Paused:         schedule.Paused,
+		NextRunAt:      timestamppb.New(schedule.NextRunAt),
+		LastRunAt:      timestamppb.New(zeroOrTime(schedule.LastRunAt)),
+		LastTaskId:     schedule.LastTaskID,
+		CreatedAt:      timestamppb.New(schedule.CreatedAt),
+		UpdatedAt:      timestamppb.New(schedule.UpdatedAt),
+	}
+}
@@ -2,7 +2,11 @@ package manager
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"math/rand"
+	"slices"
 	"time"
 
 	"github.com/botashev/tasks-executor/pkg/models"
@@ -14,36 +18,142 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+const (
+	// defaultLeaseDuration is used for executors that do not configure their own lease.
+	defaultLeaseDuration = 5 * time.Minute
+	// defaultDedupWindow is used for executors that do not configure their own dedup window.
+	defaultDedupWindow = 24 * time.Hour
+	// maxTaskResultSize limits the result a worker can store on a completed task.
+	maxTaskResultSize = 1 << 20
+	// maxBatchSize limits the number of tasks leased or updated by one batch call.
+	maxBatchSize = 100
+	// executorUndeleteWindow is how long a deleted executor can be restored.
+	executorUndeleteWindow = 7 * 24 * time.Hour
+	// maxWaitTimeout limits how long GetNextTask waits for a task.
+	maxWaitTimeout = time.Minute
+	// waitPollInterval is how often a waiting GetNextTask looks for tasks without
+	// being notified, e.g. for delayed tasks that have become due.
+	waitPollInterval = 5 * time.Second
+)
+
 type Service struct {
 	pb.UnimplementedTaskExecutorManagerServer
-	storage storage.Storage
+	storage  storage.Storage
+	notifier *taskNotifier
 }
 
 func NewService(storage storage.Storage) *Service {
 	return &Service{
-		storage: storage,
+		storage:  storage,
+		notifier: newTaskNotifier(),
 	}
 }
 
 func (s *Service) AddTask(ctx context.Context, req *pb.AddTaskRequest) (*pb.AddTaskResponse, error) {
+	if req.RunAt != nil && req.Delay != nil {
+		return nil, status.Error(codes.InvalidArgument, "only one of run_at and delay may be set")
+	}
+
 	task := &models.Task{
-		ExecutorName: req.ExecutorName,
-		Data:         req.Data,
-		Metadata:     req.Metadata,
-		Status:       models.TaskStatusPending,
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
+		ExecutorName:   req.ExecutorName,
+		Data:           req.Data,
+		Metadata:       req.Metadata,
+		Status:         models.TaskStatusPending,
+		Priority:       int(req.Priority),
+		IdempotencyKey: req.IdempotencyKey,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
 	}
 
-	if err := s.storage.AddTask(ctx, task); err != nil {
+	var runAt time.Time
+	if req.RunAt != nil {
+		if err := req.RunAt.CheckValid(); err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid run_at: %v", err))
+		}
+		runAt = req.RunAt.AsTime()
+	}
+	if req.Delay != nil {
+		if err := req.Delay.CheckValid(); err != nil || req.Delay.AsDuration() < 0 {
+			return nil, status.Error(codes.InvalidArgument, "delay must be a non-negative duration")
+		}
+		runAt = time.Now().Add(req.Delay.AsDuration())
+	}
+	if !runAt.IsZero() {
+		task.RunAt = &runAt
+		task.NextAttemptAt = &runAt
+	}
+
+	// Checked before deduplication, so that an executor which no longer accepts
+	// tasks does not answer a retried submission with its earlier task
+	executor, err := s.storage.GetExecutor(ctx, task.ExecutorName)
+	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	if executor == nil {
+		return nil, status.Error(codes.NotFound, "executor not found")
+	}
+	if err := checkSubmit(executor); err != nil {
+		return nil, err
+	}
+
+	if task.IdempotencyKey != "" {
+		existing, err := s.findDuplicateTask(ctx, executor, task.IdempotencyKey)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if existing != nil {
+			return &pb.AddTaskResponse{
+				Task:         convertTaskToProto(existing),
+				Deduplicated: true,
+			}, nil
+		}
+	}
+
+	if err := s.storage.AddTask(ctx, task); err != nil {
+		if !errors.Is(err, storage.ErrDuplicateTask) {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		// A concurrent submission with the same key has been inserted first.
+		existing, err := s.storage.GetTaskByIdempotencyKey(ctx, task.ExecutorName, task.IdempotencyKey)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if existing == nil {
+			return nil, status.Error(codes.Aborted, "concurrent submission with the same idempotency key, retry the request")
+		}
+		return &pb.AddTaskResponse{
+			Task:         convertTaskToProto(existing),
+			Deduplicated: true,
+		}, nil
+	}
+	if task.NextAttemptAt == nil || !task.NextAttemptAt.After(time.Now()) {
+		s.notifier.notify(task.ExecutorName)
+	}
 
 	return &pb.AddTaskResponse{
 		Task: convertTaskToProto(task),
 	}, nil
 }
 
+// findDuplicateTask returns the task previously submitted with the idempotency key
+// if it is still within the executor's dedup window. A key whose window is over
+// is released so that it can be reused.
+func (s *Service) findDuplicateTask(ctx context.Context, executor *models.ExecutorConfig, key string) (*models.Task, error) {
+	existing, err := s.storage.GetTaskByIdempotencyKey(ctx, executor.Name, key)
+	if err != nil || existing == nil {
+		return nil, err
+	}
+
+	window := defaultDedupWindow
+	if executor.DedupWindow > 0 {
+		window = executor.DedupWindow
+	}
+	if time.Since(existing.CreatedAt) < window {
+		return existing, nil
+	}
+	return nil, s.storage.ReleaseIdempotencyKey(ctx, existing.ID)
+}
+
 func (s *Service) GetTaskStatus(ctx context.Context, req *pb.GetTaskStatusRequest) (*pb.GetTaskStatusResponse, error) {
 	task, err := s.storage.GetTask(ctx, req.Id)
 	if err != nil {
@@ -56,6 +166,7 @@ func (s *Service) GetTaskStatus(ctx context.Context, req *pb.GetTaskStatusReques
 	return &pb.GetTaskStatusResponse{
 		Status: convertTaskStatus(task.Status),
 		Error:  task.Error,
+		Result: task.Result,
 	}, nil
 }
 
@@ -67,12 +178,28 @@ func (s *Service) RegisterExecutor(ctx context.Context, req *pb.RegisterExecutor
 	if executor == nil {
 		return nil, status.Error(codes.NotFound, "executor not found")
 	}
-	if !executor.Enabled {
+	if executor.CurrentState() == models.ExecutorStateDisabled {
 		return nil, status.Error(codes.FailedPrecondition, "executor is disabled")
 	}
 
+	workerID := req.WorkerId
+	if workerID == "" {
+		workerID = req.LeaderId
+	}
+	if workerID == "" {
+		// Clients that do not identify themselves are only checked, not tracked
+		return &pb.RegisterExecutorResponse{
+			Success: true,
+		}, nil
+	}
+	if err := s.registerWorker(ctx, workerID, req); err != nil {
+		return nil, err
+	}
+
 	return &pb.RegisterExecutorResponse{
-		Success: true,
+		Success:           true,
+		WorkerId:          workerID,
+		HeartbeatInterval: durationpb.New(workerHeartbeatInterval),
 	}, nil
 }
 
@@ -81,22 +208,144 @@ func (s *Service) GetNextTask(ctx context.Context, req *pb.GetNextTaskRequest) (
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	if executor == nil || !executor.Enabled {
-		return nil, status.Error(codes.NotFound, "executor not found or disabled")
+	if executor == nil {
+		return nil, status.Error(codes.NotFound, "executor not found")
+	}
+	if err := checkDispatch(executor); err != nil {
+		return nil, err
+	}
+	if req.MaxTasks < 0 {
+		return nil, status.Error(codes.InvalidArgument, "max_tasks must not be negative")
+	}
+	maxTasks := int(req.MaxTasks)
+	if maxTasks > maxBatchSize {
+		maxTasks = maxBatchSize
+	}
+	if req.WaitTimeout != nil {
+		if err := req.WaitTimeout.CheckValid(); err != nil || req.WaitTimeout.AsDuration() < 0 {
+			return nil, status.Error(codes.InvalidArgument, "wait_timeout must be a non-negative duration")
+		}
+		return s.waitNextTasks(ctx, executor, maxTasks, req.WaitTimeout.AsDuration())
+	}
+	tasks, err := s.leaseTasks(ctx, executor, maxTasks)
+	if errors.Is(err, storage.ErrInFlightLimit) {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+	var limited *rateLimitError
+	if errors.As(err, &limited) {
+		return nil, limited
 	}
-	task, err := s.storage.GetNextTask(ctx, req.ExecutorName)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	if task == nil {
+	if len(tasks) == 0 {
 		return nil, status.Error(codes.NotFound, "no tasks available")
 	}
-	return &pb.GetNextTaskResponse{
-		Task: convertTaskToProto(task),
-	}, nil
+	return nextTasksResponse(tasks), nil
+}
+
+// leaseTasks leases up to maxTasks tasks of the executor, one if maxTasks is zero,
+// within the executor's max_in_flight and rate limits.
+func (s *Service) leaseTasks(ctx context.Context, executor *models.ExecutorConfig, maxTasks int) ([]*models.Task, error) {
+	n := maxTasks
+	if n < 1 {
+		n = 1
+	}
+	n, err := s.takeRateTokens(ctx, executor, n)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []*models.Task
+	if n > 1 {
+		tasks, err = s.storage.GetNextTasks(ctx, executor.Name, leaseDuration(executor), n, executor.MaxInFlight)
+	} else {
+		var task *models.Task
+		task, err = s.storage.GetNextTask(ctx, executor.Name, leaseDuration(executor), executor.MaxInFlight)
+		if task != nil {
+			tasks = []*models.Task{task}
+		}
+	}
+	if len(tasks) < n {
+		s.returnRateTokens(ctx, executor, n-len(tasks))
+	}
+	return tasks, err
+}
+
+func nextTasksResponse(tasks []*models.Task) *pb.GetNextTaskResponse {
+	resp := &pb.GetNextTaskResponse{Tasks: make([]*pb.Task, len(tasks))}
+	for i, task := range tasks {
+		resp.Tasks[i] = convertTaskToProto(task)
+	}
+	resp.Task = resp.Tasks[0]
+	return resp
+}
+
+// waitNextTasks leases up to maxTasks tasks of the executor, waiting up to wait
+// for some to become available. An empty response means the wait has timed out;
+// its retry_after is set if the rate limit will not allow a task before then.
+func (s *Service) waitNextTasks(ctx context.Context, executor *models.ExecutorConfig, maxTasks int, wait time.Duration) (*pb.GetNextTaskResponse, error) {
+	if wait > maxWaitTimeout {
+		wait = maxWaitTimeout
+	}
+	until := time.Now().Add(wait)
+	deadline := time.NewTimer(wait)
+	defer deadline.Stop()
+	// Subscribing before the first attempt ensures a task added in between is not missed
+	notified, unsubscribe := s.notifier.subscribe(executor.Name)
+	defer unsubscribe()
+
+	for {
+		tasks, err := s.leaseTasks(ctx, executor, maxTasks)
+		var limited *rateLimitError
+		switch {
+		case errors.As(err, &limited):
+		case err != nil && !errors.Is(err, storage.ErrInFlightLimit):
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if len(tasks) > 0 {
+			return nextTasksResponse(tasks), nil
+		}
+
+		next := waitPollInterval
+		if limited != nil {
+			// New tasks cannot be dispatched before the next token is due
+			if time.Until(until) < limited.retryAfter {
+				return &pb.GetNextTaskResponse{RetryAfter: durationpb.New(limited.retryAfter)}, nil
+			}
+			next = limited.retryAfter
+		}
+		poll := time.NewTimer(next)
+		select {
+		case <-ctx.Done():
+			poll.Stop()
+			return nil, status.FromContextError(ctx.Err()).Err()
+		case <-deadline.C:
+			poll.Stop()
+			return &pb.GetNextTaskResponse{}, nil
+		case <-notified:
+		case <-poll.C:
+		}
+		poll.Stop()
+
+		// Pick up a pause or a configuration change made during the wait
+		executor, err = s.storage.GetExecutor(ctx, executor.Name)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if executor == nil {
+			return nil, status.Error(codes.NotFound, "executor not found")
+		}
+		if err := checkDispatch(executor); err != nil {
+			return nil, err
+		}
+	}
 }
 
 func (s *Service) UpdateTaskStatus(ctx context.Context, req *pb.UpdateTaskStatusRequest) (*pb.UpdateTaskStatusResponse, error) {
+	if len(req.Result) > maxTaskResultSize {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("result exceeds %d bytes", maxTaskResultSize))
+	}
 	task, err := s.storage.GetTask(ctx, req.Id)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
@@ -108,22 +357,243 @@ func (s *Service) UpdateTaskStatus(ctx context.Context, req *pb.UpdateTaskStatus
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	taskStatus, errorMsg, err := reportedTaskStatus(task, req)
+	if err != nil {
+		return nil, err
+	}
+	if executor == nil && !task.CancelRequested {
+		// Deleted while the task was running; the deletion policy requests the
+		// cancellation of the tasks in progress, which their reports then finish
+		return nil, status.Error(codes.FailedPrecondition, "executor not found")
+	}
+	if taskStatus == models.TaskStatusCompleted {
+		if err := s.storage.CompleteTask(ctx, req.Id, task.LeaseID, models.TaskStatusInProgress, req.Result); err != nil {
+			return nil, storageError(err)
+		}
+		completeTask(task, req.Result)
+		s.releaseInFlight(executor, 1)
+		return &pb.UpdateTaskStatusResponse{Task: convertTaskToProto(task)}, nil
+	}
+	if err := s.finishTask(ctx, executor, task, taskStatus, errorMsg); err != nil {
+		return nil, storageError(err)
+	}
+	s.releaseInFlight(executor, 1)
+	return &pb.UpdateTaskStatusResponse{Task: convertTaskToProto(task)}, nil
+}
+
+// reportedTaskStatus returns the status and error a worker report moves the task to.
+func reportedTaskStatus(task *models.Task, req *pb.UpdateTaskStatusRequest) (models.TaskStatus, string, error) {
+	switch req.Status {
+	case pb.TaskStatus_TASK_STATUS_COMPLETED, pb.TaskStatus_TASK_STATUS_FAILED, pb.TaskStatus_TASK_STATUS_CANCELLED:
+	default:
+		// A report ends the attempt; a task is returned to the queue with ReleaseTask
+		return "", "", status.Error(codes.InvalidArgument, "status must be COMPLETED, FAILED or CANCELLED")
+	}
+	// Only the worker holding the task's current lease reports its outcome. A
+	// task reclaimed by the reaper and leased again carries a new lease ID and
+	// is rejected here; one that changes hands after it was loaded is rejected
+	// by the storage, which matches both the status and the lease ID.
+	if err := checkTaskLease(task, req.LeaseId); err != nil {
+		return "", "", err
+	}
 	taskStatus := convertProtoTaskStatus(req.Status)
+	errorMsg := req.Error
+	if task.CancelRequested && taskStatus != models.TaskStatusCompleted {
+		// The task was cancelled while running, so it is not retried.
+		taskStatus = models.TaskStatusCancelled
+		if errorMsg == "" {
+			errorMsg = task.Error
+		}
+	}
+	return taskStatus, errorMsg, nil
+}
+
+// checkTaskLease checks that the task is in progress under the lease a worker presents.
+func checkTaskLease(task *models.Task, leaseID string) error {
+	if leaseID == "" {
+		return status.Error(codes.InvalidArgument, "lease_id is required")
+	}
+	if task.Status != models.TaskStatusInProgress {
+		return status.Error(codes.Aborted, fmt.Sprintf("task is %s, not in progress", task.Status))
+	}
+	if leaseID != task.LeaseID {
+		return status.Error(codes.Aborted, "task has been leased again since")
+	}
+	return nil
+}
+
+// finishTask moves an in-progress task to a status other than completed.
+func (s *Service) finishTask(ctx context.Context, executor *models.ExecutorConfig, task *models.Task, taskStatus models.TaskStatus, errorMsg string) error {
 	if taskStatus == models.TaskStatusFailed {
-		if shouldRetry(executor.RetryPolicy, task.RetryCount) {
-			taskStatus = models.TaskStatusPending
-			task.RetryCount++
-		} else if executor.DLQConfig.Enabled {
-			if err := s.storage.MoveToDLQ(ctx, task); err != nil {
-				return nil, status.Error(codes.Internal, err.Error())
-			}
-			return &pb.UpdateTaskStatusResponse{Task: convertTaskToProto(task)}, nil
+		return s.failTask(ctx, executor, task, errorMsg)
+	}
+	if err := s.storage.UpdateTaskStatus(ctx, task.ID.Hex(), task.LeaseID, models.TaskStatusInProgress, taskStatus, errorMsg); err != nil {
+		return err
+	}
+	task.Status = taskStatus
+	task.Error = errorMsg
+	return nil
+}
+
+// completeTask reflects a stored completion on the loaded task.
+func completeTask(task *models.Task, result []byte) {
+	task.Status = models.TaskStatusCompleted
+	task.Error = ""
+	task.Result = result
+}
+
+func (s *Service) CancelTask(ctx context.Context, req *pb.CancelTaskRequest) (*pb.CancelTaskResponse, error) {
+	if req == nil || req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	reason := req.Reason
+	if reason == "" {
+		reason = "task cancelled"
+	}
+
+	task, err := s.storage.CancelTask(ctx, req.Id, reason)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if task == nil {
+		return nil, status.Error(codes.NotFound, "task not found")
+	}
+	switch {
+	case task.Status == models.TaskStatusCancelled:
+	case task.Status == models.TaskStatusInProgress && task.CancelRequested:
+	default:
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("task is already %s", task.Status))
+	}
+	return &pb.CancelTaskResponse{
+		Task: convertTaskToProto(task),
+	}, nil
+}
+
+func (s *Service) HeartbeatTask(ctx context.Context, req *pb.HeartbeatTaskRequest) (*pb.HeartbeatTaskResponse, error) {
+	if req == nil || req.Id == "" || req.LeaseId == "" {
+		return nil, status.Error(codes.InvalidArgument, "id and lease_id are required")
+	}
+	task, err := s.storage.GetTask(ctx, req.Id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if task == nil {
+		return nil, status.Error(codes.NotFound, "task not found")
+	}
+	executor, err := s.storage.GetExecutor(ctx, task.ExecutorName)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if executor == nil {
+		if task.CancelRequested && task.Status == models.TaskStatusInProgress && task.LeaseID == req.LeaseId {
+			// Cancelled with its deleted executor: the worker stops it without a longer lease
+			return &pb.HeartbeatTaskResponse{
+				LeaseExpiresAt:  timestamppb.New(*task.LeaseExpiresAt),
+				CancelRequested: true,
+			}, nil
+		}
+		return nil, status.Error(codes.NotFound, "executor not found")
+	}
+
+	var progress *models.TaskProgress
+	if req.Progress != nil {
+		if req.Progress.Percent < 0 || req.Progress.Percent > 100 {
+			return nil, status.Error(codes.InvalidArgument, "progress percent must be between 0 and 100")
+		}
+		progress = &models.TaskProgress{
+			Percent:   req.Progress.Percent,
+			Message:   req.Progress.Message,
+			UpdatedAt: time.Now(),
 		}
 	}
-	if err := s.storage.UpdateTaskStatus(ctx, req.Id, taskStatus, req.Error); err != nil {
+
+	leaseExpiresAt := time.Now().Add(leaseDuration(executor))
+	task, err = s.storage.ExtendLease(ctx, req.Id, req.LeaseId, leaseExpiresAt, progress)
+	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	return &pb.UpdateTaskStatusResponse{Task: convertTaskToProto(task)}, nil
+	if task == nil {
+		return nil, status.Error(codes.FailedPrecondition, "task is not in progress under this lease")
+	}
+	return &pb.HeartbeatTaskResponse{
+		LeaseExpiresAt:  timestamppb.New(leaseExpiresAt),
+		CancelRequested: task.CancelRequested,
+	}, nil
+}
+
+/*
+ReleaseTask returns a task its worker has not finished to the queue without
+counting a failed attempt, e.g. because the worker is shutting down. A task
+whose cancellation was requested is cancelled instead.
+*/
+func (s *Service) ReleaseTask(ctx context.Context, req *pb.ReleaseTaskRequest) (*pb.ReleaseTaskResponse, error) {
+	if req == nil || req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	task, err := s.storage.GetTask(ctx, req.Id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if task == nil {
+		return nil, status.Error(codes.NotFound, "task not found")
+	}
+	if err := checkTaskLease(task, req.LeaseId); err != nil {
+		return nil, err
+	}
+	executor, err := s.storage.GetExecutor(ctx, task.ExecutorName)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if task.CancelRequested {
+		if err := s.finishTask(ctx, executor, task, models.TaskStatusCancelled, task.Error); err != nil {
+			return nil, storageError(err)
+		}
+	} else {
+		if err := s.storage.ReleaseTask(ctx, req.Id, task.LeaseID); err != nil {
+			return nil, storageError(err)
+		}
+		task.Status = models.TaskStatusPending
+		task.LeaseExpiresAt = nil
+		task.LeaseID = ""
+		task.NextAttemptAt = nil
+		task.Progress = nil
+		s.notifier.notify(task.ExecutorName)
+	}
+	s.releaseInFlight(executor, 1)
+	return &pb.ReleaseTaskResponse{Task: convertTaskToProto(task)}, nil
+}
+
+// failTask records a failed attempt of the task according to the executor's
+// retry policy: the task is scheduled for another attempt, moved to the DLQ
+// or marked as failed for good.
+func (s *Service) failTask(ctx context.Context, executor *models.ExecutorConfig, task *models.Task, errorMsg string) error {
+	from := task.Status
+	if task.CancelRequested {
+		task.Status = models.TaskStatusCancelled
+		return s.storage.UpdateTaskStatus(ctx, task.ID.Hex(), task.LeaseID, from, models.TaskStatusCancelled, task.Error)
+	}
+	task.Error = errorMsg
+	if shouldRetry(executor.RetryPolicy, task.RetryCount) {
+		task.RetryCount++
+		nextAttemptAt := time.Now().Add(retryDelay(executor.RetryPolicy, task.RetryCount))
+		task.Status = models.TaskStatusPending
+		task.NextAttemptAt = &nextAttemptAt
+		return s.storage.RetryTask(ctx, task, from)
+	}
+	if executor.DLQConfig.Enabled {
+		return s.moveToDLQ(ctx, executor, task, from)
+	}
+	task.Status = models.TaskStatusFailed
+	return s.storage.UpdateTaskStatus(ctx, task.ID.Hex(), task.LeaseID, from, models.TaskStatusFailed, errorMsg)
+}
+
+// storageError converts a storage error into a gRPC status error.
+func storageError(err error) error {
+	if errors.Is(err, storage.ErrStatusConflict) || errors.Is(err, storage.ErrVersionConflict) {
+		return status.Error(codes.Aborted, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
 }
 
 func (s *Service) CreateExecutor(ctx context.Context, req *pb.CreateExecutorRequest) (*pb.CreateExecutorResponse, error) {
@@ -133,10 +603,11 @@ func (s *Service) CreateExecutor(ctx context.Context, req *pb.CreateExecutorRequ
 
 	config := &models.ExecutorConfig{
 		Name:      req.Config.Name,
-		Enabled:   req.Config.Enabled,
+		State:     resolveExecutorState(req.Config.State, req.Config.Enabled, nil),
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
+	config.Enabled = config.State != models.ExecutorStateDisabled
 
 	if req.Config.WriteConcern != nil {
 		config.WriteConcern = models.WriteConcern{
@@ -149,19 +620,56 @@ func (s *Service) CreateExecutor(ctx context.Context, req *pb.CreateExecutorRequ
 			Type:        convertProtoRetryPolicyType(req.Config.RetryPolicy.Type),
 			MaxAttempts: int(req.Config.RetryPolicy.MaxAttempts),
 			Interval:    req.Config.RetryPolicy.Interval.AsDuration(),
+			MaxInterval: req.Config.RetryPolicy.MaxInterval.AsDuration(),
+			Jitter:      req.Config.RetryPolicy.Jitter,
 		}
 	}
 
 	if req.Config.DlqConfig != nil {
 		config.DLQConfig = models.DLQConfig{
-			Enabled:   req.Config.DlqConfig.Enabled,
-			QueueName: req.Config.DlqConfig.QueueName,
+			Enabled:        req.Config.DlqConfig.Enabled,
+			QueueName:      req.Config.DlqConfig.QueueName,
+			FailureHandler: req.Config.DlqConfig.FailureHandler,
+			MaxAge:         req.Config.DlqConfig.MaxAge.AsDuration(),
+			MaxEntries:     req.Config.DlqConfig.MaxEntries,
+			Archive:        req.Config.DlqConfig.Archive,
 		}
 	}
 
+	if req.Config.LeaseDuration != nil {
+		config.LeaseDuration = req.Config.LeaseDuration.AsDuration()
+	}
+
+	if req.Config.PriorityAging != nil {
+		config.PriorityAging = convertProtoPriorityAging(req.Config.PriorityAging)
+	}
+
+	if req.Config.DedupWindow != nil {
+		config.DedupWindow = req.Config.DedupWindow.AsDuration()
+	}
+
+	config.MaxInFlight = int(req.Config.MaxInFlight)
+	config.RateLimit = convertProtoRateLimit(req.Config.RateLimit)
+
+	if config.DLQConfig.FailureHandler == config.Name && config.Name != "" {
+		return nil, status.Error(codes.InvalidArgument, "executor cannot be its own failure handler")
+	}
+	if config.MaxInFlight < 0 {
+		return nil, status.Error(codes.InvalidArgument, "max_in_flight must not be negative")
+	}
+	if err := validateRateLimit(config.RateLimit); err != nil {
+		return nil, err
+	}
+	if err := validatePriorityAging(config.PriorityAging); err != nil {
+		return nil, err
+	}
+
 	if err := s.storage.CreateExecutor(ctx, config); err != nil {
 		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to create executor: %v", err))
 	}
+	if err := s.recordRevision(ctx, newRevision(ctx, models.ExecutorRevisionCreated, nil, config)); err != nil {
+		return nil, err
+	}
 
 	return &pb.CreateExecutorResponse{
 		Executor: convertExecutorToProto(config),
@@ -172,31 +680,85 @@ func (s *Service) UpdateExecutor(ctx context.Context, req *pb.UpdateExecutorRequ
 	if req == nil || req.Config == nil {
 		return nil, status.Error(codes.InvalidArgument, "request or config is nil")
 	}
-
-	config := &models.ExecutorConfig{
-		Name:    req.Config.Name,
-		Enabled: req.Config.Enabled,
-		WriteConcern: models.WriteConcern{
-			Level: convertProtoWriteConcernLevel(req.Config.WriteConcern.Level),
-		},
-		RetryPolicy: models.RetryPolicy{
-			Type:        convertProtoRetryPolicyType(req.Config.RetryPolicy.Type),
-			MaxAttempts: int(req.Config.RetryPolicy.MaxAttempts),
-			Interval:    req.Config.RetryPolicy.Interval.AsDuration(),
-		},
-		DLQConfig: models.DLQConfig{
-			Enabled:   req.Config.DlqConfig.Enabled,
-			QueueName: req.Config.DlqConfig.QueueName,
-		},
-		UpdatedAt: time.Now(),
+	name := req.Id
+	if name == "" {
+		name = req.Config.Name
+	}
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if req.Config.Name != "" && req.Config.Name != name {
+		return nil, status.Error(codes.InvalidArgument, "executor name cannot be changed")
+	}
+	if req.Version <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "version is required")
+	}
+	paths, err := executorUpdatePaths(req.UpdateMask)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.storage.UpdateExecutor(ctx, config); err != nil {
+	current, err := s.storage.GetExecutor(ctx, name)
+	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	if current == nil {
+		return nil, status.Error(codes.NotFound, "executor not found")
+	}
+	if current.Version != req.Version {
+		return nil, status.Error(codes.Aborted, storage.ErrVersionConflict.Error())
+	}
+
+	merged := convertExecutorConfig(current)
+	applyFieldMask(merged, req.Config, paths)
+	if slices.Contains(paths, "state") || slices.Contains(paths, "enabled") {
+		requested := pb.ExecutorState_EXECUTOR_STATE_UNSPECIFIED
+		if slices.Contains(paths, "state") {
+			requested = merged.State
+		}
+		state := resolveExecutorState(requested, merged.Enabled, current)
+		merged.State = convertExecutorState(state)
+		merged.Enabled = state != models.ExecutorStateDisabled
+		for _, path := range []string{"state", "enabled"} {
+			if !slices.Contains(paths, path) {
+				paths = append(paths, path)
+			}
+		}
+	}
+
+	config := executorConfigFromProto(merged)
+	config.ID = current.ID
+	config.Name = current.Name
+	config.Version = current.Version
+	config.CreatedAt = current.CreatedAt
+	config.UpdatedAt = time.Now()
+
+	if config.DLQConfig.FailureHandler == config.Name && config.Name != "" {
+		return nil, status.Error(codes.InvalidArgument, "executor cannot be its own failure handler")
+	}
+	if config.MaxInFlight < 0 {
+		return nil, status.Error(codes.InvalidArgument, "max_in_flight must not be negative")
+	}
+	if err := validateRateLimit(config.RateLimit); err != nil {
+		return nil, err
+	}
+	if err := validatePriorityAging(config.PriorityAging); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.storage.UpdateExecutor(ctx, config, paths)
+	if err != nil {
+		return nil, storageError(err)
+	}
+	if updated == nil {
+		return nil, status.Error(codes.NotFound, "executor not found")
+	}
+	if err := s.recordRevision(ctx, newRevision(ctx, models.ExecutorRevisionUpdated, current, updated)); err != nil {
+		return nil, err
+	}
 
 	return &pb.UpdateExecutorResponse{
-		Executor: convertExecutorToProto(config),
+		Executor: convertExecutorToProto(updated),
 	}, nil
 }
 
@@ -209,13 +771,27 @@ func (s *Service) GetExecutor(ctx context.Context, req *pb.GetExecutorRequest) (
 		return nil, status.Error(codes.NotFound, "executor not found")
 	}
 
+	size, oldestEntryAt, err := s.storage.GetDLQStats(ctx, executor.Name)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	dlqStats := &pb.DLQStats{Size: size}
+	if !oldestEntryAt.IsZero() {
+		dlqStats.OldestEntryAge = durationpb.New(time.Since(oldestEntryAt))
+	}
+
 	return &pb.GetExecutorResponse{
 		Executor: convertExecutorToProto(executor),
+		DlqStats: dlqStats,
 	}, nil
 }
 
 func (s *Service) ListExecutors(ctx context.Context, req *pb.ListExecutorsRequest) (*pb.ListExecutorsResponse, error) {
-	executors, err := s.storage.ListExecutors(ctx)
+	list := s.storage.ListExecutors
+	if req.GetDeleted() {
+		list = s.storage.ListDeletedExecutors
+	}
+	executors, err := list(ctx)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -232,10 +808,34 @@ func (s *Service) DeleteExecutor(ctx context.Context, req *pb.DeleteExecutorRequ
 	if req == nil || req.Id == "" {
 		return nil, status.Error(codes.InvalidArgument, "id is required")
 	}
-	if err := s.storage.DeleteExecutor(ctx, req.Id); err != nil {
+	if err := s.checkDeletionPolicy(ctx, req); err != nil {
+		return nil, err
+	}
+
+	// The executor is deleted before its tasks are handled so that no new task
+	// is dispatched meanwhile. A failed policy can be retried, as deleting a
+	// deleted executor applies the policy again.
+	executor, err := s.storage.DeleteExecutor(ctx, req.Id)
+	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	return &pb.DeleteExecutorResponse{}, nil
+	if executor == nil {
+		return nil, status.Error(codes.NotFound, "executor not found")
+	}
+	// Deleting again records the revision and applies the policy if this fails
+	if err := s.recordRevision(ctx, newRevision(ctx, models.ExecutorRevisionDeleted, executor, executor)); err != nil {
+		return nil, err
+	}
+	affected, err := s.applyDeletionPolicy(ctx, executor, req)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("executor deleted, but its tasks were not handled: %v", err))
+	}
+
+	return &pb.DeleteExecutorResponse{
+		AffectedTasks: affected,
+		DeletedAt:     timestamppb.New(*executor.DeletedAt),
+		RestoreUntil:  timestamppb.New(executor.DeletedAt.Add(executorUndeleteWindow)),
+	}, nil
 }
 
 func convertTaskStatus(status models.TaskStatus) pb.TaskStatus {
@@ -250,6 +850,8 @@ func convertTaskStatus(status models.TaskStatus) pb.TaskStatus {
 		return pb.TaskStatus_TASK_STATUS_FAILED
 	case models.TaskStatusDLQ:
 		return pb.TaskStatus_TASK_STATUS_DLQ
+	case models.TaskStatusCancelled:
+		return pb.TaskStatus_TASK_STATUS_CANCELLED
 	default:
 		return pb.TaskStatus_TASK_STATUS_PENDING
 	}
@@ -267,6 +869,8 @@ func convertProtoTaskStatus(status pb.TaskStatus) models.TaskStatus {
 		return models.TaskStatusFailed
 	case pb.TaskStatus_TASK_STATUS_DLQ:
 		return models.TaskStatusDLQ
+	case pb.TaskStatus_TASK_STATUS_CANCELLED:
+		return models.TaskStatusCancelled
 	default:
 		return models.TaskStatusPending
 	}
@@ -276,6 +880,7 @@ func convertExecutorConfig(config *models.ExecutorConfig) *pb.ExecutorConfig {
 	return &pb.ExecutorConfig{
 		Name:    config.Name,
 		Enabled: config.Enabled,
+		State:   convertExecutorState(config.CurrentState()),
 		WriteConcern: &pb.WriteConcern{
 			Level: convertWriteConcernLevel(config.WriteConcern.Level),
 		},
@@ -283,11 +888,23 @@ func convertExecutorConfig(config *models.ExecutorConfig) *pb.ExecutorConfig {
 			Type:        convertRetryPolicyType(config.RetryPolicy.Type),
 			MaxAttempts: int32(config.RetryPolicy.MaxAttempts),
 			Interval:    durationpb.New(config.RetryPolicy.Interval),
+			MaxInterval: durationpb.New(config.RetryPolicy.MaxInterval),
+			Jitter:      config.RetryPolicy.Jitter,
 		},
 		DlqConfig: &pb.DLQConfig{
-			Enabled:   config.DLQConfig.Enabled,
-			QueueName: config.DLQConfig.QueueName,
+			Enabled:        config.DLQConfig.Enabled,
+			QueueName:      config.DLQConfig.QueueName,
+			FailureHandler: config.DLQConfig.FailureHandler,
+			MaxAge:         durationpb.New(config.DLQConfig.MaxAge),
+			MaxEntries:     config.DLQConfig.MaxEntries,
+			Archive:        config.DLQConfig.Archive,
 		},
+		LeaseDuration: durationpb.New(config.LeaseDuration),
+		PriorityAging: convertPriorityAging(config.PriorityAging),
+		DedupWindow:   durationpb.New(config.DedupWindow),
+		MaxInFlight:   int32(config.MaxInFlight),
+		RateLimit:     convertRateLimit(config.RateLimit),
+		Version:       config.Version,
 	}
 }
 
@@ -354,22 +971,142 @@ func shouldRetry(policy models.RetryPolicy, retryCount int) bool {
 	return retryCount < policy.MaxAttempts
 }
 
+// leaseDuration returns how long a dispatched task of the executor stays leased.
+func leaseDuration(config *models.ExecutorConfig) time.Duration {
+	if config.LeaseDuration > 0 {
+		return config.LeaseDuration
+	}
+	return defaultLeaseDuration
+}
+
+// retryDelay returns how long a task should wait before the given attempt
+// (starting from 1) according to the policy type, cap and jitter.
+func retryDelay(policy models.RetryPolicy, attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	delay := policy.Interval
+	switch policy.Type {
+	case models.RetryPolicyLinear:
+		delay = policy.Interval * time.Duration(attempt)
+	case models.RetryPolicyExponential:
+		for i := 1; i < attempt; i++ {
+			if delay > math.MaxInt64/2 {
+				delay = math.MaxInt64
+				break
+			}
+			delay *= 2
+		}
+	}
+
+	if policy.MaxInterval > 0 && delay > policy.MaxInterval {
+		delay = policy.MaxInterval
+	}
+
+	if policy.Jitter > 0 && delay > 0 {
+		jitter := math.Min(policy.Jitter, 1)
+		spread := float64(delay) * jitter
+		delay = time.Duration(float64(delay) - spread + rand.Float64()*2*spread)
+	}
+
+	if delay < 0 {
+		delay = 0
+	}
+	return delay
+}
+
 func convertTaskToProto(task *models.Task) *pb.Task {
 	if task == nil {
 		return nil
 	}
 	return &pb.Task{
-		Id:           task.ID.Hex(),
-		ExecutorName: task.ExecutorName,
-		Data:         task.Data,
-		Metadata:     task.Metadata,
-		Status:       convertTaskStatus(task.Status),
-		Error:        task.Error,
-		RetryCount:   int32(task.RetryCount),
-		CreatedAt:    timestamppb.New(task.CreatedAt),
-		UpdatedAt:    timestamppb.New(task.UpdatedAt),
-		StartedAt:    timestamppb.New(zeroOrTime(task.StartedAt)),
-		CompletedAt:  timestamppb.New(zeroOrTime(task.CompletedAt)),
+		Id:              task.ID.Hex(),
+		ExecutorName:    task.ExecutorName,
+		Data:            task.Data,
+		Metadata:        task.Metadata,
+		Status:          convertTaskStatus(task.Status),
+		Error:           task.Error,
+		RetryCount:      int32(task.RetryCount),
+		CreatedAt:       timestamppb.New(task.CreatedAt),
+		UpdatedAt:       timestamppb.New(task.UpdatedAt),
+		StartedAt:       timestamppb.New(zeroOrTime(task.StartedAt)),
+		CompletedAt:     timestamppb.New(zeroOrTime(task.CompletedAt)),
+		NextAttemptAt:   timestamppb.New(zeroOrTime(task.NextAttemptAt)),
+		LeaseExpiresAt:  timestamppb.New(zeroOrTime(task.LeaseExpiresAt)),
+		LeaseId:         task.LeaseID,
+		Progress:        convertTaskProgressToProto(task.Progress),
+		RunAt:           timestamppb.New(zeroOrTime(task.RunAt)),
+		Priority:        int32(task.Priority),
+		IdempotencyKey:  task.IdempotencyKey,
+		CancelRequested: task.CancelRequested,
+		Result:          task.Result,
+		History:         convertTaskHistoryToProto(task.History),
+		DlqName:         task.DLQName,
+		ErrorGroup:      task.ErrorGroup,
+	}
+}
+
+func convertTaskHistoryToProto(history []models.TaskHistoryEntry) []*pb.TaskHistoryEntry {
+	if len(history) == 0 {
+		return nil
+	}
+	result := make([]*pb.TaskHistoryEntry, len(history))
+	for i, entry := range history {
+		result[i] = &pb.TaskHistoryEntry{
+			Event:   entry.Event,
+			At:      timestamppb.New(entry.At),
+			Message: entry.Message,
+			Details: entry.Details,
+		}
+	}
+	return result
+}
+
+func convertPriorityAging(aging models.PriorityAging) *pb.PriorityAging {
+	return &pb.PriorityAging{
+		Threshold:   durationpb.New(aging.Threshold),
+		Step:        int32(aging.Step),
+		MaxPriority: int32(aging.MaxPriority),
+	}
+}
+
+func convertProtoPriorityAging(aging *pb.PriorityAging) models.PriorityAging {
+	if aging == nil {
+		return models.PriorityAging{}
+	}
+	return models.PriorityAging{
+		Threshold:   aging.Threshold.AsDuration(),
+		Step:        int(aging.Step),
+		MaxPriority: int(aging.MaxPriority),
+	}
+}
+
+func convertRateLimit(limit models.RateLimit) *pb.RateLimit {
+	return &pb.RateLimit{
+		TasksPerSecond: limit.TasksPerSecond,
+		Burst:          int32(limit.Burst),
+	}
+}
+
+func convertProtoRateLimit(limit *pb.RateLimit) models.RateLimit {
+	if limit == nil {
+		return models.RateLimit{}
+	}
+	return models.RateLimit{
+		TasksPerSecond: limit.TasksPerSecond,
+		Burst:          int(limit.Burst),
+	}
+}
+
+func convertTaskProgressToProto(progress *models.TaskProgress) *pb.TaskProgress {
+	if progress == nil {
+		return nil
+	}
+	return &pb.TaskProgress{
+		Percent:   progress.Percent,
+		Message:   progress.Message,
+		UpdatedAt: timestamppb.New(progress.UpdatedAt),
 	}
 }
 
@@ -377,13 +1114,15 @@ func convertExecutorToProto(config *models.ExecutorConfig) *pb.Executor {
 	if config == nil {
 		return nil
 	}
-	return &pb.Executor{
+	executor := &pb.Executor{
 		Id:      config.ID.Hex(),
 		Name:    config.Name,
 		Enabled: config.Enabled,
+		State:   convertExecutorState(config.CurrentState()),
 		Config: &pb.ExecutorConfig{
 			Name:    config.Name,
 			Enabled: config.Enabled,
+			State:   convertExecutorState(config.CurrentState()),
 			WriteConcern: &pb.WriteConcern{
 				Level: convertWriteConcernLevel(config.WriteConcern.Level),
 			},
@@ -391,15 +1130,31 @@ func convertExecutorToProto(config *models.ExecutorConfig) *pb.Executor {
 				Type:        convertRetryPolicyType(config.RetryPolicy.Type),
 				MaxAttempts: int32(config.RetryPolicy.MaxAttempts),
 				Interval:    durationpb.New(config.RetryPolicy.Interval),
+				MaxInterval: durationpb.New(config.RetryPolicy.MaxInterval),
+				Jitter:      config.RetryPolicy.Jitter,
 			},
 			DlqConfig: &pb.DLQConfig{
-				Enabled:   config.DLQConfig.Enabled,
-				QueueName: config.DLQConfig.QueueName,
+				Enabled:        config.DLQConfig.Enabled,
+				QueueName:      config.DLQConfig.QueueName,
+				FailureHandler: config.DLQConfig.FailureHandler,
+				MaxAge:         durationpb.New(config.DLQConfig.MaxAge),
+				MaxEntries:     config.DLQConfig.MaxEntries,
+				Archive:        config.DLQConfig.Archive,
 			},
+			LeaseDuration: durationpb.New(config.LeaseDuration),
+			PriorityAging: convertPriorityAging(config.PriorityAging),
+			DedupWindow:   durationpb.New(config.DedupWindow),
+			MaxInFlight:   int32(config.MaxInFlight),
+			RateLimit:     convertRateLimit(config.RateLimit),
+			Version:       config.Version,
 		},
 		CreatedAt: timestamppb.New(config.CreatedAt),
 		UpdatedAt: timestamppb.New(config.UpdatedAt),
 	}
+	if config.DeletedAt != nil {
+		executor.DeletedAt = timestamppb.New(*config.DeletedAt)
+	}
+	return executor
 }
 
 func zeroOrTime(t *time.Time) time.Time {
@@ -417,6 +1172,8 @@ func convertProtoExecutorConfig(config *models.ExecutorConfig) *pb.ExecutorConfi
 	result := &pb.ExecutorConfig{
 		Name:    config.Name,
 		Enabled: config.Enabled,
+		State:   convertExecutorState(config.CurrentState()),
+		Version: config.Version,
 	}
 
 	result.WriteConcern = &pb.WriteConcern{
@@ -427,12 +1184,28 @@ func convertProtoExecutorConfig(config *models.ExecutorConfig) *pb.ExecutorConfi
 		Type:        convertRetryPolicyType(config.RetryPolicy.Type),
 		MaxAttempts: int32(config.RetryPolicy.MaxAttempts),
 		Interval:    durationpb.New(config.RetryPolicy.Interval),
+		MaxInterval: durationpb.New(config.RetryPolicy.MaxInterval),
+		Jitter:      config.RetryPolicy.Jitter,
 	}
 
 	result.DlqConfig = &pb.DLQConfig{
-		Enabled:   config.DLQConfig.Enabled,
-		QueueName: config.DLQConfig.QueueName,
+		Enabled:        config.DLQConfig.Enabled,
+		QueueName:      config.DLQConfig.QueueName,
+		FailureHandler: config.DLQConfig.FailureHandler,
+		MaxAge:         durationpb.New(config.DLQConfig.MaxAge),
+		MaxEntries:     config.DLQConfig.MaxEntries,
+		Archive:        config.DLQConfig.Archive,
 	}
 
+	result.LeaseDuration = durationpb.New(config.LeaseDuration)
+
+	result.PriorityAging = convertPriorityAging(config.PriorityAging)
+
+	result.DedupWindow = durationpb.New(config.DedupWindow)
+
+	result.MaxInFlight = int32(config.MaxInFlight)
+
+	result.RateLimit = convertRateLimit(config.RateLimit)
+
 	return result
 }
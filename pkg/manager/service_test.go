@@ -0,0 +1,195 @@
+package manager
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/botashev/tasks-executor/pkg/models"
+	pb "github.com/botashev/tasks-executor/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  models.RetryPolicy
+		attempt int
+		want    time.Duration
+	}{
+		{
+			name:    "constant",
+			policy:  models.RetryPolicy{Type: models.RetryPolicyConstant, Interval: time.Second},
+			attempt: 4,
+			want:    time.Second,
+		},
+		{
+			name:    "linear",
+			policy:  models.RetryPolicy{Type: models.RetryPolicyLinear, Interval: time.Second},
+			attempt: 3,
+			want:    3 * time.Second,
+		},
+		{
+			name:    "exponential first attempt",
+			policy:  models.RetryPolicy{Type: models.RetryPolicyExponential, Interval: time.Second},
+			attempt: 1,
+			want:    time.Second,
+		},
+		{
+			name:    "exponential",
+			policy:  models.RetryPolicy{Type: models.RetryPolicyExponential, Interval: time.Second},
+			attempt: 4,
+			want:    8 * time.Second,
+		},
+		{
+			name:    "exponential does not overflow",
+			policy:  models.RetryPolicy{Type: models.RetryPolicyExponential, Interval: time.Second},
+			attempt: 200,
+			want:    math.MaxInt64,
+		},
+		{
+			name:    "max interval caps the delay",
+			policy:  models.RetryPolicy{Type: models.RetryPolicyExponential, Interval: time.Second, MaxInterval: 5 * time.Second},
+			attempt: 10,
+			want:    5 * time.Second,
+		},
+		{
+			name:    "attempt below one counts as the first",
+			policy:  models.RetryPolicy{Type: models.RetryPolicyLinear, Interval: time.Second},
+			attempt: 0,
+			want:    time.Second,
+		},
+		{
+			name:    "unknown type falls back to constant",
+			policy:  models.RetryPolicy{Interval: 2 * time.Second},
+			attempt: 5,
+			want:    2 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryDelay(tt.policy, tt.attempt); got != tt.want {
+				t.Errorf("retryDelay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryDelayJitter(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   models.RetryPolicy
+		min, max time.Duration
+	}{
+		{
+			name:   "spread around the delay",
+			policy: models.RetryPolicy{Type: models.RetryPolicyConstant, Interval: 10 * time.Second, Jitter: 0.2},
+			min:    8 * time.Second,
+			max:    12 * time.Second,
+		},
+		{
+			name:   "jitter above one is capped",
+			policy: models.RetryPolicy{Type: models.RetryPolicyConstant, Interval: 10 * time.Second, Jitter: 3},
+			min:    0,
+			max:    20 * time.Second,
+		},
+		{
+			name:   "applied after max interval",
+			policy: models.RetryPolicy{Type: models.RetryPolicyLinear, Interval: 10 * time.Second, MaxInterval: 10 * time.Second, Jitter: 0.5},
+			min:    5 * time.Second,
+			max:    15 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				if got := retryDelay(tt.policy, 3); got < tt.min || got > tt.max {
+					t.Fatalf("retryDelay() = %v, want within [%v, %v]", got, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestReportedTaskStatus(t *testing.T) {
+	running := func() *models.Task {
+		return &models.Task{Status: models.TaskStatusInProgress, LeaseID: "lease"}
+	}
+	tests := []struct {
+		name       string
+		task       *models.Task
+		req        *pb.UpdateTaskStatusRequest
+		wantStatus models.TaskStatus
+		wantError  string
+		code       codes.Code
+	}{
+		{
+			name:       "completed",
+			task:       running(),
+			req:        &pb.UpdateTaskStatusRequest{Status: pb.TaskStatus_TASK_STATUS_COMPLETED, LeaseId: "lease"},
+			wantStatus: models.TaskStatusCompleted,
+		},
+		{
+			name:       "failed",
+			task:       running(),
+			req:        &pb.UpdateTaskStatusRequest{Status: pb.TaskStatus_TASK_STATUS_FAILED, LeaseId: "lease", Error: "boom"},
+			wantStatus: models.TaskStatusFailed,
+			wantError:  "boom",
+		},
+		{
+			name:       "failure of a cancelled task cancels it",
+			task:       &models.Task{Status: models.TaskStatusInProgress, LeaseID: "lease", CancelRequested: true, Error: "cancelled by user"},
+			req:        &pb.UpdateTaskStatusRequest{Status: pb.TaskStatus_TASK_STATUS_FAILED, LeaseId: "lease"},
+			wantStatus: models.TaskStatusCancelled,
+			wantError:  "cancelled by user",
+		},
+		{
+			name: "in progress",
+			task: running(),
+			req:  &pb.UpdateTaskStatusRequest{Status: pb.TaskStatus_TASK_STATUS_IN_PROGRESS, LeaseId: "lease"},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "unspecified",
+			task: running(),
+			req:  &pb.UpdateTaskStatusRequest{LeaseId: "lease"},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "missing lease",
+			task: running(),
+			req:  &pb.UpdateTaskStatusRequest{Status: pb.TaskStatus_TASK_STATUS_COMPLETED},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "leased again",
+			task: running(),
+			req:  &pb.UpdateTaskStatusRequest{Status: pb.TaskStatus_TASK_STATUS_COMPLETED, LeaseId: "old"},
+			code: codes.Aborted,
+		},
+		{
+			name: "not in progress",
+			task: &models.Task{Status: models.TaskStatusPending},
+			req:  &pb.UpdateTaskStatusRequest{Status: pb.TaskStatus_TASK_STATUS_COMPLETED, LeaseId: "lease"},
+			code: codes.Aborted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStatus, gotError, err := reportedTaskStatus(tt.task, tt.req)
+			if tt.code != codes.OK {
+				if status.Code(err) != tt.code {
+					t.Fatalf("error = %v, want code %v", err, tt.code)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gotStatus != tt.wantStatus || gotError != tt.wantError {
+				t.Errorf("got %s %q, want %s %q", gotStatus, gotError, tt.wantStatus, tt.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package manager
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/botashev/tasks-executor/pkg/models"
+	pb "github.com/botashev/tasks-executor/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// workerHeartbeatInterval is how often registered workers are asked to send heartbeats.
+const workerHeartbeatInterval = 10 * time.Second
+
+// registerWorker records a worker process of the executor req.ExecutorName as alive.
+func (s *Service) registerWorker(ctx context.Context, workerID string, req *pb.RegisterExecutorRequest) error {
+	if req.Concurrency < 0 {
+		return status.Error(codes.InvalidArgument, "concurrency must not be negative")
+	}
+	concurrency := int(req.Concurrency)
+	if concurrency == 0 {
+		concurrency = 1
+	}
+
+	now := time.Now()
+	worker := &models.Worker{
+		ID:              workerID,
+		ExecutorName:    req.ExecutorName,
+		Hostname:        req.Hostname,
+		Version:         req.Version,
+		Capabilities:    req.Capabilities,
+		Concurrency:     concurrency,
+		Status:          models.WorkerStatusAlive,
+		RegisteredAt:    now,
+		LastHeartbeatAt: now,
+	}
+	if err := s.storage.RegisterWorker(ctx, worker); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return nil
+}
+
+func (s *Service) HeartbeatWorker(ctx context.Context, req *pb.HeartbeatWorkerRequest) (*pb.HeartbeatWorkerResponse, error) {
+	if req.WorkerId == "" {
+		return nil, status.Error(codes.InvalidArgument, "worker_id is required")
+	}
+	if req.ActiveTasks < 0 {
+		return nil, status.Error(codes.InvalidArgument, "active_tasks must not be negative")
+	}
+	found, err := s.storage.HeartbeatWorker(ctx, req.WorkerId, int(req.ActiveTasks), time.Now())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if !found {
+		return nil, status.Error(codes.NotFound, "worker not registered or marked dead")
+	}
+	return &pb.HeartbeatWorkerResponse{}, nil
+}
+
+func (s *Service) ListWorkers(ctx context.Context, req *pb.ListWorkersRequest) (*pb.ListWorkersResponse, error) {
+	workers, err := s.storage.ListWorkers(ctx, req.ExecutorName, req.IncludeDead)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp := &pb.ListWorkersResponse{Workers: make([]*pb.Worker, len(workers))}
+	for i, worker := range workers {
+		resp.Workers[i] = convertWorkerToProto(worker)
+	}
+	return resp, nil
+}
+
+/*
+RunWorkerReaper periodically marks workers that have not sent a heartbeat
+for longer than timeout as dead. Tasks leased by such workers are reclaimed
+separately, when their leases expire.
+It blocks until ctx is cancelled.
+*/
+func (s *Service) RunWorkerReaper(ctx context.Context, interval, timeout time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			marked, err := s.storage.MarkDeadWorkers(ctx, time.Now().Add(-timeout))
+			if err != nil {
+				log.Printf("Error marking dead workers: %v", err)
+				continue
+			}
+			if marked > 0 {
+				log.Printf("Marked %d workers dead after %s without heartbeats", marked, timeout)
+			}
+		}
+	}
+}
+
+func convertWorkerToProto(worker *models.Worker) *pb.Worker {
+	workerStatus := pb.WorkerStatus_WORKER_STATUS_ALIVE
+	if worker.Status == models.WorkerStatusDead {
+		workerStatus = pb.WorkerStatus_WORKER_STATUS_DEAD
+	}
+	return &pb.Worker{
+		Id:              worker.ID,
+		ExecutorName:    worker.ExecutorName,
+		Hostname:        worker.Hostname,
+		Version:         worker.Version,
+		Capabilities:    worker.Capabilities,
+		Concurrency:     int32(worker.Concurrency),
+		ActiveTasks:     int32(worker.ActiveTasks),
+		Status:          workerStatus,
+		RegisteredAt:    timestamppb.New(worker.RegisteredAt),
+		LastHeartbeatAt: timestamppb.New(worker.LastHeartbeatAt),
+	}
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"math"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -13,14 +14,81 @@ and dead letter queue settings. This configuration is stored in the database and
 can be modified at runtime.
 */
 type ExecutorConfig struct {
-	ID           primitive.ObjectID `bson:"_id,omitempty"` // Unique identifier in the database
-	Name         string             `bson:"name"`          // Unique name of the executor
-	Enabled      bool               `bson:"enabled"`       // Whether the executor is active
-	WriteConcern WriteConcern       `bson:"write_concern"` // Data durability settings
-	RetryPolicy  RetryPolicy        `bson:"retry_policy"`  // Task retry configuration
-	DLQConfig    DLQConfig          `bson:"dlq_config"`    // Dead letter queue settings
-	CreatedAt    time.Time          `bson:"created_at"`    // Creation timestamp
-	UpdatedAt    time.Time          `bson:"updated_at"`    // Last update timestamp
+	ID            primitive.ObjectID `bson:"_id,omitempty"`        // Unique identifier in the database
+	Name          string             `bson:"name"`                 // Unique name of the executor
+	Enabled       bool               `bson:"enabled"`              // Whether the executor is not disabled
+	State         ExecutorState      `bson:"state,omitempty"`      // Whether tasks are accepted and dispatched, see CurrentState
+	WriteConcern  WriteConcern       `bson:"write_concern"`        // Data durability settings
+	RetryPolicy   RetryPolicy        `bson:"retry_policy"`         // Task retry configuration
+	DLQConfig     DLQConfig          `bson:"dlq_config"`           // Dead letter queue settings
+	LeaseDuration time.Duration      `bson:"lease_duration"`       // How long a dispatched task stays leased to a worker
+	PriorityAging PriorityAging      `bson:"priority_aging"`       // Priority boost for long-waiting tasks
+	DedupWindow   time.Duration      `bson:"dedup_window"`         // How long idempotency keys of tasks are remembered
+	MaxInFlight   int                `bson:"max_in_flight"`        // How many tasks may be in progress at once (0 - no limit)
+	RateLimit     RateLimit          `bson:"rate_limit"`           // How fast tasks may be dispatched
+	Version       int64              `bson:"version"`              // Incremented on every change, guards concurrent updates
+	DeletedAt     *time.Time         `bson:"deleted_at,omitempty"` // When the executor was deleted, nil unless deleted
+	CreatedAt     time.Time          `bson:"created_at"`           // Creation timestamp
+	UpdatedAt     time.Time          `bson:"updated_at"`           // Last update timestamp
+}
+
+/*
+CurrentState returns the state of the executor. Executors created before states
+were introduced have none stored and are active or disabled according to Enabled.
+*/
+func (c *ExecutorConfig) CurrentState() ExecutorState {
+	if c.State != "" {
+		return c.State
+	}
+	if c.Enabled {
+		return ExecutorStateActive
+	}
+	return ExecutorStateDisabled
+}
+
+type ExecutorState string
+
+const (
+	ExecutorStateActive   ExecutorState = "active"   // Tasks are accepted and dispatched
+	ExecutorStatePaused   ExecutorState = "paused"   // Tasks are accepted but not dispatched
+	ExecutorStateDraining ExecutorState = "draining" // New tasks are rejected, the backlog is dispatched
+	ExecutorStateDisabled ExecutorState = "disabled" // Tasks are neither accepted nor dispatched
+)
+
+/*
+ExecutorRevision is an entry of the append-only change history of an executor.
+Each revision keeps the complete configuration, so any of them can be restored.
+*/
+type ExecutorRevision struct {
+	ID           primitive.ObjectID     `bson:"_id,omitempty"`            // Unique identifier, increases with time
+	ExecutorName string                 `bson:"executor_name"`            // Executor that was changed
+	Action       ExecutorRevisionAction `bson:"action"`                   // Kind of the change
+	Actor        string                 `bson:"actor"`                    // Who made the change
+	Config       ExecutorConfig         `bson:"config"`                   // Configuration after the change, at deletion for deletions
+	Changes      []ExecutorFieldChange  `bson:"changes,omitempty"`        // Fields whose values were changed
+	RolledBackTo primitive.ObjectID     `bson:"rolled_back_to,omitempty"` // Revision restored by a rollback
+	CreatedAt    time.Time              `bson:"created_at"`               // When the change was made
+}
+
+type ExecutorRevisionAction string
+
+const (
+	ExecutorRevisionCreated      ExecutorRevisionAction = "created"
+	ExecutorRevisionUpdated      ExecutorRevisionAction = "updated"
+	ExecutorRevisionStateChanged ExecutorRevisionAction = "state_changed" // Paused, resumed, drained or disabled
+	ExecutorRevisionDeleted      ExecutorRevisionAction = "deleted"
+	ExecutorRevisionUndeleted    ExecutorRevisionAction = "undeleted"
+	ExecutorRevisionRolledBack   ExecutorRevisionAction = "rolled_back"
+)
+
+/*
+ExecutorFieldChange is a configuration field changed by a revision, with its
+values formatted for display.
+*/
+type ExecutorFieldChange struct {
+	Field    string `bson:"field"`     // BSON path of the field, e.g. "retry_policy.max_attempts"
+	OldValue string `bson:"old_value"` // Value before the change
+	NewValue string `bson:"new_value"` // Value after the change
 }
 
 /*
@@ -43,11 +111,15 @@ const (
 /*
 RetryPolicy defines how failed tasks should be retried.
 It specifies the retry strategy, maximum number of attempts, and delay between retries.
+The delay grows according to Type, is capped by MaxInterval and is then spread by Jitter
+so that tasks failed together do not come back at the same moment.
 */
 type RetryPolicy struct {
 	Type        RetryPolicyType `bson:"type"`         // The retry strategy to use
 	MaxAttempts int             `bson:"max_attempts"` // Maximum number of retry attempts
 	Interval    time.Duration   `bson:"interval"`     // Base delay between retries
+	MaxInterval time.Duration   `bson:"max_interval"` // Upper bound for the delay (0 - no limit)
+	Jitter      float64         `bson:"jitter"`       // Random spread of the delay as a fraction in [0, 1]
 }
 
 type RetryPolicyType string
@@ -58,13 +130,75 @@ const (
 	RetryPolicyExponential RetryPolicyType = "exponential" // Exponentially increasing delay
 )
 
+/*
+PriorityAging raises the priority of pending tasks that have been waiting for too long,
+so low-priority work is not starved by a steady stream of high-priority tasks.
+Every Threshold of waiting adds Step to the task priority, up to MaxPriority.
+Aging is disabled when Threshold or Step is zero.
+*/
+type PriorityAging struct {
+	Threshold   time.Duration `bson:"threshold"`    // Waiting time after which a task is boosted
+	Step        int           `bson:"step"`         // Priority added on every boost
+	MaxPriority int           `bson:"max_priority"` // Priority a task can be boosted up to
+}
+
+/*
+RateLimit is a token bucket limiting how fast tasks of an executor are dispatched
+to all its workers together. The bucket holds up to Burst tokens and is refilled
+at TasksPerSecond; every dispatched task takes one token.
+Rate limiting is disabled when TasksPerSecond is zero.
+*/
+type RateLimit struct {
+	TasksPerSecond float64 `bson:"tasks_per_second"` // Average dispatch rate
+	Burst          int     `bson:"burst"`            // Bucket size, TasksPerSecond rounded up if zero
+}
+
+/*
+BucketSize returns the number of tokens the bucket holds.
+*/
+func (r RateLimit) BucketSize() int {
+	if r.Burst > 0 {
+		return r.Burst
+	}
+	return int(math.Ceil(r.TasksPerSecond))
+}
+
 /*
 DLQConfig defines the configuration for the Dead Letter Queue.
 The Dead Letter Queue is used to store tasks that have failed after all retry attempts.
+Several executors may share a queue by using the same QueueName.
 */
 type DLQConfig struct {
-	Enabled   bool   `bson:"enabled"`    // Whether DLQ is enabled for this executor
-	QueueName string `bson:"queue_name"` // Name of the DLQ collection
+	Enabled        bool          `bson:"enabled"`                   // Whether DLQ is enabled for this executor
+	QueueName      string        `bson:"queue_name"`                // Name of the DLQ, the executor name if empty
+	FailureHandler string        `bson:"failure_handler,omitempty"` // Executor that gets a copy of every dead-lettered task
+	MaxAge         time.Duration `bson:"max_age,omitempty"`         // How long a task is kept in the DLQ, unlimited if zero
+	MaxEntries     int64         `bson:"max_entries,omitempty"`     // How many tasks of the executor the DLQ keeps, oldest are evicted first
+	Archive        bool          `bson:"archive,omitempty"`         // Append evicted tasks to an NDJSON file before deleting them
+}
+
+/*
+DLQInfo describes a named Dead Letter Queue.
+*/
+type DLQInfo struct {
+	Name          string    `bson:"_id"`             // Queue name
+	Size          int64     `bson:"size"`            // Number of tasks in the queue
+	Executors     []string  `bson:"executors"`       // Executors whose tasks are in the queue
+	OldestEntryAt time.Time `bson:"oldest_entry_at"` // When the oldest task was moved to the queue
+}
+
+/*
+DLQErrorGroup is a set of DLQ tasks of one executor that failed with the same
+normalized error.
+*/
+type DLQErrorGroup struct {
+	ExecutorName  string               `bson:"executor_name"`   // Executor of the tasks
+	ErrorGroup    string               `bson:"error_group"`     // Normalized error shared by the tasks
+	Count         int64                `bson:"count"`           // Number of tasks in the group
+	FirstSeen     time.Time            `bson:"first_seen"`      // When the first task was moved to the DLQ
+	LastSeen      time.Time            `bson:"last_seen"`       // When the last task was moved to the DLQ
+	SampleTaskIDs []primitive.ObjectID `bson:"sample_task_ids"` // Most recently dead-lettered tasks of the group
+	SampleError   string               `bson:"sample_error"`    // Original error of the most recent task
 }
 
 /*
@@ -72,19 +206,59 @@ Task represents a unit of work to be processed by an executor.
 It contains the task data, metadata, and state information.
 */
 type Task struct {
-	ID           primitive.ObjectID `bson:"_id,omitempty"`          // Unique identifier in the database
-	ExecutorName string             `bson:"executor_name"`          // Name of the executor that should process this task
-	Status       TaskStatus         `bson:"status"`                 // Current state of the task
-	Data         []byte             `bson:"data"`                   // Task payload (JSON)
-	Metadata     map[string]string  `bson:"metadata"`               // Additional task metadata
-	Error        string             `bson:"error,omitempty"`        // Error message if task failed
-	RetryCount   int                `bson:"retry_count"`            // Number of retry attempts
-	CreatedAt    time.Time          `bson:"created_at"`             // Creation timestamp
-	UpdatedAt    time.Time          `bson:"updated_at"`             // Last update timestamp
-	StartedAt    *time.Time         `bson:"started_at,omitempty"`   // When processing started
-	CompletedAt  *time.Time         `bson:"completed_at,omitempty"` // When processing completed
+	ID              primitive.ObjectID `bson:"_id,omitempty"`              // Unique identifier in the database
+	ExecutorName    string             `bson:"executor_name"`              // Name of the executor that should process this task
+	Status          TaskStatus         `bson:"status"`                     // Current state of the task
+	Data            []byte             `bson:"data"`                       // Task payload (JSON)
+	Metadata        map[string]string  `bson:"metadata"`                   // Additional task metadata
+	Error           string             `bson:"error,omitempty"`            // Error message if task failed
+	RetryCount      int                `bson:"retry_count"`                // Number of retry attempts
+	CreatedAt       time.Time          `bson:"created_at"`                 // Creation timestamp
+	UpdatedAt       time.Time          `bson:"updated_at"`                 // Last update timestamp
+	StartedAt       *time.Time         `bson:"started_at,omitempty"`       // When processing started
+	CompletedAt     *time.Time         `bson:"completed_at,omitempty"`     // When processing completed
+	NextAttemptAt   *time.Time         `bson:"next_attempt_at,omitempty"`  // Earliest time the task may be dispatched
+	LeaseExpiresAt  *time.Time         `bson:"lease_expires_at,omitempty"` // When an in-progress task is reclaimed if not finished
+	LeaseID         string             `bson:"lease_id,omitempty"`         // Lease of the in-progress task, new on every dispatch
+	InFlightSlot    *int               `bson:"in_flight_slot,omitempty"`   // max_in_flight slot held while the task is in progress
+	Progress        *TaskProgress      `bson:"progress,omitempty"`         // Last progress reported by the worker
+	RunAt           *time.Time         `bson:"run_at,omitempty"`           // Requested start time for delayed or scheduled tasks
+	Priority        int                `bson:"priority"`                   // Dispatch priority, higher goes first
+	AgedAt          *time.Time         `bson:"aged_at,omitempty"`          // When the priority was last raised by aging
+	IdempotencyKey  string             `bson:"idempotency_key,omitempty"`  // Producer-supplied key used to deduplicate submissions
+	CancelRequested bool               `bson:"cancel_requested,omitempty"` // Cancellation requested while the task is in progress
+	Result          []byte             `bson:"result,omitempty"`           // Output payload returned by the worker on completion
+	History         []TaskHistoryEntry `bson:"history,omitempty"`          // Notable events in the life of the task, oldest first
+	DLQName         string             `bson:"dlq_name,omitempty"`         // Dead Letter Queue the task was moved to
+	PendingOp       string             `bson:"pending_op,omitempty"`       // Unfinished cross-collection write, replayed after a crash on standalone deployments
+	ExpireAt        *time.Time         `bson:"expire_at,omitempty"`        // When the DLQ copy is removed by the TTL index
+	ErrorGroup      string             `bson:"error_group,omitempty"`      // Normalized error of a DLQ task, groups similar failures
+	BatchID         string             `bson:"batch_id,omitempty"`         // Batch lease or batch update that last changed the task
+}
+
+/*
+TaskProgress is an optional progress report sent by a worker along with a heartbeat.
+*/
+type TaskProgress struct {
+	Percent   float64   `bson:"percent"`    // Completion percentage in [0, 100]
+	Message   string    `bson:"message"`    // Human-readable progress description
+	UpdatedAt time.Time `bson:"updated_at"` // When the progress was reported
+}
+
+/*
+TaskHistoryEntry records an operator action or another notable event on a task.
+*/
+type TaskHistoryEntry struct {
+	Event   string            `bson:"event"`             // Kind of the event, see TaskEvent* constants
+	At      time.Time         `bson:"at"`                // When the event happened
+	Message string            `bson:"message,omitempty"` // Free-form comment, e.g. the reason given by the operator
+	Details map[string]string `bson:"details,omitempty"` // Event-specific attributes
 }
 
+const (
+	TaskEventRedriven = "redriven" // Task was returned from the DLQ to the live queue
+)
+
 type TaskStatus string
 
 const (
@@ -93,6 +267,7 @@ const (
 	TaskStatusCompleted  TaskStatus = "completed"   // Task was successfully processed
 	TaskStatusFailed     TaskStatus = "failed"      // Task processing failed
 	TaskStatusDLQ        TaskStatus = "dlq"         // Task was moved to Dead Letter Queue
+	TaskStatusCancelled  TaskStatus = "cancelled"   // Task was cancelled before completion
 )
 
 /*
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+/*
+Schedule describes a recurring submission of tasks to an executor.
+On every tick of its cron expression the manager renders the payload template
+and adds a new task for the target executor. A schedule fires at most once
+per tick, no matter how many manager replicas are running.
+*/
+type Schedule struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty"`          // Unique identifier in the database
+	Name           string             `bson:"name"`                   // Unique name of the schedule
+	CronExpression string             `bson:"cron_expression"`        // Standard cron expression or descriptor
+	Timezone       string             `bson:"timezone"`               // IANA time zone the expression is evaluated in
+	ExecutorName   string             `bson:"executor_name"`          // Executor that receives the tasks
+	Data           []byte             `bson:"data"`                   // Task payload template
+	Metadata       map[string]string  `bson:"metadata"`               // Metadata copied to every task
+	Paused         bool               `bson:"paused"`                 // Whether firing is suspended
+	NextRunAt      time.Time          `bson:"next_run_at"`            // Time of the next tick
+	LastRunAt      *time.Time         `bson:"last_run_at,omitempty"`  // Time of the last fired tick
+	LastTaskID     string             `bson:"last_task_id,omitempty"` // Task created by the last tick
+	CreatedAt      time.Time          `bson:"created_at"`             // Creation timestamp
+	UpdatedAt      time.Time          `bson:"updated_at"`             // Last update timestamp
+}
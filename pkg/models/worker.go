@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+/*
+Worker is a process that fetches and processes tasks of an executor.
+Workers register on start and then send heartbeats; a worker that has not sent
+a heartbeat for longer than the worker timeout is marked dead.
+*/
+type Worker struct {
+	ID              string       `bson:"_id"`               // Worker ID chosen by the worker process
+	ExecutorName    string       `bson:"executor_name"`     // Executor whose tasks the worker processes
+	Hostname        string       `bson:"hostname"`          // Host the worker runs on
+	Version         string       `bson:"version"`           // Version of the worker build
+	Capabilities    []string     `bson:"capabilities"`      // Free-form features the worker supports
+	Concurrency     int          `bson:"concurrency"`       // How many tasks the worker processes at once
+	ActiveTasks     int          `bson:"active_tasks"`      // Tasks being processed as of the last heartbeat
+	Status          WorkerStatus `bson:"status"`            // Liveness of the worker
+	RegisteredAt    time.Time    `bson:"registered_at"`     // When the worker last registered
+	LastHeartbeatAt time.Time    `bson:"last_heartbeat_at"` // When the last heartbeat was received
+}
+
+type WorkerStatus string
+
+const (
+	WorkerStatusAlive WorkerStatus = "alive" // Worker sends heartbeats
+	WorkerStatusDead  WorkerStatus = "dead"  // Worker missed its heartbeats
+)
@@ -1,6 +1,8 @@
 package sdk
 
 import (
+	"context"
+
 	"github.com/botashev/tasks-executor/pkg/models"
 )
 
@@ -24,6 +26,26 @@ type TaskProcessor interface {
 	GetTaskSchema() string
 }
 
+/*
+ContextTaskProcessor can be implemented in addition to TaskProcessor by processors
+that support cooperative cancellation. The Worker calls ProcessTaskContext instead of
+ProcessTask and cancels ctx when the task is cancelled through the manager or the
+worker is shutting down, so the processor should return as soon as ctx is done.
+*/
+type ContextTaskProcessor interface {
+	ProcessTaskContext(ctx context.Context, task *models.Task) error
+}
+
+/*
+ResultTaskProcessor can be implemented in addition to TaskProcessor by processors
+that produce an output payload. The Worker calls ProcessTaskResult instead of
+ProcessTask (or ProcessTaskContext) and stores the returned result on the completed
+task, where it is available through GetTaskStatus. ctx behaves as in ContextTaskProcessor.
+*/
+type ResultTaskProcessor interface {
+	ProcessTaskResult(ctx context.Context, task *models.Task) ([]byte, error)
+}
+
 // registry maintains a mapping of processor names to their implementations
 var registry = map[string]TaskProcessor{}
 
@@ -0,0 +1,293 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/botashev/tasks-executor/pkg/manager"
+	"github.com/botashev/tasks-executor/pkg/models"
+	pb "github.com/botashev/tasks-executor/proto"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// minHeartbeatInterval bounds how often a running task's lease is extended.
+const minHeartbeatInterval = time.Second
+
+// pollWait is how long a single request for the next task waits for one to appear.
+const pollWait = 30 * time.Second
+
+// defaultWorkerHeartbeatInterval is used until the manager tells the worker its heartbeat interval.
+const defaultWorkerHeartbeatInterval = 10 * time.Second
+
+/*
+Worker runs the processing loop of a single executor: it fetches tasks from the
+manager, hands them to the TaskProcessor and reports the outcome back.
+While a task is being processed the worker keeps its lease alive in the
+background, so long-running handlers are not reclaimed by the manager.
+When the task is cancelled, processors implementing ContextTaskProcessor
+or ResultTaskProcessor get their context cancelled. A task interrupted by the
+worker shutting down is returned to the queue without counting as a failed attempt.
+The worker registers itself with the manager and sends heartbeats, so it is
+listed by ListWorkers while it is running.
+*/
+type Worker struct {
+	manager      *manager.Manager
+	executorName string
+	processor    TaskProcessor
+	info         manager.WorkerInfo
+	activeTasks  atomic.Int32
+}
+
+/*
+NewWorker creates a worker that processes tasks of the given executor.
+
+Parameters:
+- m: Client of the task manager
+- executorName: Name of the executor whose tasks are processed
+- processor: Implementation of the task business logic
+*/
+func NewWorker(m *manager.Manager, executorName string, processor TaskProcessor) *Worker {
+	hostname, _ := os.Hostname()
+	return &Worker{
+		manager:      m,
+		executorName: executorName,
+		processor:    processor,
+		info: manager.WorkerInfo{
+			ID:          hostname + "-" + primitive.NewObjectID().Hex(),
+			Hostname:    hostname,
+			Concurrency: 1,
+		},
+	}
+}
+
+/*
+WithVersion sets the version and capabilities the worker reports when it
+registers with the manager. It must be called before Run.
+*/
+func (w *Worker) WithVersion(version string, capabilities ...string) *Worker {
+	w.info.Version = version
+	w.info.Capabilities = capabilities
+	return w
+}
+
+/*
+Run processes tasks until ctx is cancelled.
+It returns the context error once the loop has stopped.
+*/
+func (w *Worker) Run(ctx context.Context) error {
+	go w.reportLiveness(ctx)
+
+	paused := false
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		task, err := w.manager.WaitNextTask(ctx, w.executorName, pollWait)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if status.Code(err) == codes.NotFound {
+				sleep(ctx, time.Second)
+				continue
+			}
+			if status.Code(err) == codes.Unavailable {
+				// The executor is paused or the manager is unreachable, either way only waiting helps
+				if !paused {
+					log.Printf("Executor %s is unavailable, waiting: %v", w.executorName, err)
+					paused = true
+				}
+				sleep(ctx, 5*time.Second)
+				continue
+			}
+			var limited *manager.RateLimitedError
+			if errors.As(err, &limited) {
+				sleep(ctx, limited.RetryAfter)
+				continue
+			}
+			log.Printf("Error getting next task: %v", err)
+			sleep(ctx, 5*time.Second)
+			continue
+		}
+		paused = false
+		if task == nil {
+			// The wait timed out on an empty queue
+			continue
+		}
+
+		w.process(ctx, task)
+	}
+}
+
+func (w *Worker) process(ctx context.Context, task *pb.Task) {
+	w.activeTasks.Add(1)
+	defer w.activeTasks.Add(-1)
+
+	taskCtx, cancelTask := context.WithCancel(ctx)
+	defer cancelTask()
+
+	var cancelled atomic.Bool
+	heartbeatCtx, stopHeartbeat := context.WithCancel(ctx)
+	heartbeatDone := make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+		w.keepAlive(heartbeatCtx, task, func() {
+			if cancelled.CompareAndSwap(false, true) {
+				log.Printf("Task %s was cancelled, stopping its processing", task.Id)
+				cancelTask()
+			}
+		})
+	}()
+
+	result, err := w.run(taskCtx, task)
+	stopHeartbeat()
+	<-heartbeatDone
+
+	if cancelled.Load() && err != nil {
+		if err := w.manager.UpdateTaskStatus(task.Id, task.LeaseId, pb.TaskStatus_TASK_STATUS_CANCELLED, err.Error()); err != nil {
+			log.Printf("Error updating task status: %v", err)
+		}
+		return
+	}
+
+	if err != nil && ctx.Err() != nil {
+		// The processing was interrupted by the shutdown, not failed, so another worker takes the task over
+		log.Printf("Returning task %s to the queue on shutdown", task.Id)
+		if err := w.manager.ReleaseTask(task.Id, task.LeaseId); err != nil {
+			log.Printf("Error releasing task: %v", err)
+		}
+		return
+	}
+
+	if err != nil {
+		log.Printf("Error processing task %s: %v", task.Id, err)
+		if err := w.manager.UpdateTaskStatus(task.Id, task.LeaseId, pb.TaskStatus_TASK_STATUS_FAILED, err.Error()); err != nil {
+			log.Printf("Error updating task status: %v", err)
+		}
+		return
+	}
+
+	if err := w.manager.CompleteTask(task.Id, task.LeaseId, result); err != nil {
+		log.Printf("Error updating task status: %v", err)
+	}
+}
+
+// run calls the most specific processing method the processor implements.
+func (w *Worker) run(ctx context.Context, task *pb.Task) ([]byte, error) {
+	switch p := w.processor.(type) {
+	case ResultTaskProcessor:
+		return p.ProcessTaskResult(ctx, taskFromProto(task))
+	case ContextTaskProcessor:
+		return nil, p.ProcessTaskContext(ctx, taskFromProto(task))
+	default:
+		return nil, w.processor.ProcessTask(taskFromProto(task))
+	}
+}
+
+// keepAlive extends the task lease at a third of the remaining lease time
+// until ctx is cancelled or the manager reports that the task is gone.
+// onCancel is called when the manager reports that the task was cancelled;
+// the lease is still extended until the processor returns.
+func (w *Worker) keepAlive(ctx context.Context, task *pb.Task, onCancel func()) {
+	leaseExpiresAt := task.LeaseExpiresAt.AsTime()
+	for {
+		timer := time.NewTimer(heartbeatInterval(leaseExpiresAt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		next, err := w.manager.Heartbeat(task.Id, task.LeaseId)
+		if errors.Is(err, manager.ErrTaskCancelled) {
+			onCancel()
+			err = nil
+		}
+		if err != nil {
+			switch status.Code(err) {
+			case codes.NotFound, codes.FailedPrecondition:
+				log.Printf("Stopping heartbeat for task %s: %v", task.Id, err)
+				return
+			}
+			log.Printf("Error sending heartbeat for task %s: %v", task.Id, err)
+			continue
+		}
+		leaseExpiresAt = next
+	}
+}
+
+// reportLiveness registers the worker and sends worker heartbeats until ctx is
+// cancelled, registering again whenever the manager has marked the worker dead.
+func (w *Worker) reportLiveness(ctx context.Context) {
+	registered := false
+	interval := defaultWorkerHeartbeatInterval
+	for {
+		var err error
+		if registered {
+			err = w.manager.HeartbeatWorker(w.info.ID, int(w.activeTasks.Load()))
+			if status.Code(err) == codes.NotFound {
+				log.Printf("Worker %s is not registered, registering again", w.info.ID)
+				registered = false
+			}
+		}
+		if !registered {
+			var next time.Duration
+			next, err = w.manager.RegisterWorker(w.executorName, w.info)
+			if err == nil {
+				registered = true
+				if next > 0 {
+					interval = next
+				}
+			}
+		}
+		if err != nil {
+			log.Printf("Error reporting liveness of worker %s: %v", w.info.ID, err)
+		}
+
+		sleep(ctx, interval)
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func heartbeatInterval(leaseExpiresAt time.Time) time.Duration {
+	interval := time.Until(leaseExpiresAt) / 3
+	if interval < minHeartbeatInterval {
+		return minHeartbeatInterval
+	}
+	return interval
+}
+
+func sleep(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+func taskFromProto(task *pb.Task) *models.Task {
+	id, _ := primitive.ObjectIDFromHex(task.Id)
+	return &models.Task{
+		ID:           id,
+		ExecutorName: task.ExecutorName,
+		Data:         task.Data,
+		Metadata:     task.Metadata,
+		Error:        task.Error,
+		RetryCount:   int(task.RetryCount),
+		CreatedAt:    task.CreatedAt.AsTime(),
+		UpdatedAt:    task.UpdatedAt.AsTime(),
+	}
+}
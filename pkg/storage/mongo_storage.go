@@ -3,6 +3,9 @@ package storage
 import (
 	"context"
 	"errors"
+	"log"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/botashev/tasks-executor/pkg/models"
@@ -18,8 +21,22 @@ type mongoStorage struct {
 	executorsColl *mongo.Collection
 	tasksColl     *mongo.Collection
 	dlqColl       *mongo.Collection
+	schedulesColl *mongo.Collection
+	workersColl   *mongo.Collection
+	archiveColl   *mongo.Collection
+	revisionsColl *mongo.Collection
+	// transactions is true when the deployment (replica set or sharded cluster)
+	// supports multi-document transactions
+	transactions bool
 }
 
+// Cross-collection writes recorded on a task while they are in flight on
+// deployments without transactions, see ReplayPendingOps.
+const (
+	pendingOpDLQInsert = "dlq_insert" // Task is marked dlq, its DLQ copy may be missing
+	pendingOpDLQDelete = "dlq_delete" // Task was redriven, its DLQ copy may still exist
+)
+
 func NewMongoStorage(config StorageConfig) (Storage, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -33,6 +50,10 @@ func NewMongoStorage(config StorageConfig) (Storage, error) {
 	executorsColl := db.Collection(config.ExecutorsColl)
 	tasksColl := db.Collection(config.TasksColl)
 	dlqColl := db.Collection(config.DLQColl)
+	schedulesColl := db.Collection(config.SchedulesColl)
+	workersColl := db.Collection(config.WorkersColl)
+	archiveColl := db.Collection(config.ArchiveColl)
+	revisionsColl := db.Collection(config.RevisionsColl)
 
 	_, err = executorsColl.Indexes().CreateOne(ctx, mongo.IndexModel{
 		Keys:    bson.D{{Key: "name", Value: 1}},
@@ -41,6 +62,17 @@ func NewMongoStorage(config StorageConfig) (Storage, error) {
 	if err != nil {
 		return nil, err
 	}
+	// Executors created before versioning start at version 1
+	_, err = executorsColl.UpdateMany(ctx, bson.M{"version": bson.M{"$exists": false}}, bson.M{"$set": bson.M{"version": 1}})
+	if err != nil {
+		return nil, err
+	}
+	// max_in_flight used to be tracked by an in_flight counter on the executor;
+	// tasks in progress now hold the slots themselves
+	_, err = executorsColl.UpdateMany(ctx, bson.M{"in_flight": bson.M{"$exists": true}}, bson.M{"$unset": bson.M{"in_flight": ""}})
+	if err != nil {
+		return nil, err
+	}
 
 	_, err = tasksColl.Indexes().CreateMany(ctx, []mongo.IndexModel{
 		{
@@ -49,35 +81,266 @@ func NewMongoStorage(config StorageConfig) (Storage, error) {
 		{
 			Keys: bson.D{{Key: "created_at", Value: 1}},
 		},
+		{
+			Keys: bson.D{{Key: "executor_name", Value: 1}, {Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "lease_expires_at", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "executor_name", Value: 1}, {Key: "status", Value: 1}, {Key: "priority", Value: -1}, {Key: "created_at", Value: 1}},
+		},
+		{
+			// Task listing: newest first, optionally narrowed to an executor and status
+			Keys: bson.D{{Key: "executor_name", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "executor_name", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "updated_at", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "metadata.$**", Value: 1}},
+		},
+		{
+			// Idempotency keys are unique per executor; tasks without a key are not indexed
+			Keys: bson.D{{Key: "executor_name", Value: 1}, {Key: "idempotency_key", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"idempotency_key": bson.M{"$exists": true}}),
+		},
+		{
+			// max_in_flight: tasks of an executor in progress hold distinct slots;
+			// a task frees its slot by leaving the in_progress status
+			Keys: bson.D{{Key: "executor_name", Value: 1}, {Key: "in_flight_slot", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{
+					"status":         models.TaskStatusInProgress,
+					"in_flight_slot": bson.M{"$exists": true},
+				}),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = archiveColl.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "executor_name", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = revisionsColl.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "executor_name", Value: 1}, {Key: "_id", Value: -1}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = dlqColl.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "executor_name", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "executor_name", Value: 1}, {Key: "updated_at", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "dlq_name", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "executor_name", Value: 1}, {Key: "error_group", Value: 1}, {Key: "updated_at", Value: -1}},
+		},
+		{
+			// Tasks claimed for archiving by the DLQ retention loop
+			Keys:    bson.D{{Key: "evict_claim", Value: 1}},
+			Options: options.Index().SetPartialFilterExpression(bson.M{"evict_claim": bson.M{"$exists": true}}),
+		},
+		{
+			// DLQ max age: every DLQ copy carries its own expiry time
+			Keys:    bson.D{{Key: "expire_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = schedulesColl.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "name", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "paused", Value: 1}, {Key: "next_run_at", Value: 1}},
+		},
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	_, err = workersColl.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "executor_name", Value: 1}, {Key: "status", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "last_heartbeat_at", Value: 1}},
+		},
+		{
+			// Workers silent for a day (dead or long gone) are forgotten
+			Keys:    bson.D{{Key: "last_heartbeat_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32((24 * time.Hour).Seconds())),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tasksColl.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "pending_op", Value: 1}, {Key: "updated_at", Value: 1}},
+		Options: options.Index().SetPartialFilterExpression(bson.M{"pending_op": bson.M{"$exists": true}}),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := supportsTransactions(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	if !transactions {
+		log.Println("MongoDB deployment does not support transactions, using pending operations for DLQ transitions")
+	}
+
 	return &mongoStorage{
 		client:        client,
 		db:            db,
 		executorsColl: executorsColl,
 		tasksColl:     tasksColl,
 		dlqColl:       dlqColl,
+		schedulesColl: schedulesColl,
+		workersColl:   workersColl,
+		archiveColl:   archiveColl,
+		revisionsColl: revisionsColl,
+		transactions:  transactions,
 	}, nil
 }
 
-func (s *mongoStorage) CreateExecutor(ctx context.Context, config *models.ExecutorConfig) error {
-	_, err := s.executorsColl.InsertOne(ctx, config)
+// supportsTransactions reports whether the server is a replica set member or a mongos.
+func supportsTransactions(ctx context.Context, client *mongo.Client) (bool, error) {
+	var hello bson.M
+	if err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return false, err
+	}
+	_, replicaSet := hello["setName"]
+	return replicaSet || hello["msg"] == "isdbgrid", nil
+}
+
+// inTransaction runs fn in a multi-document transaction.
+func (s *mongoStorage) inTransaction(ctx context.Context, fn func(ctx mongo.SessionContext) error) error {
+	session, err := s.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(ctx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(ctx)
+	})
 	return err
 }
 
-func (s *mongoStorage) UpdateExecutor(ctx context.Context, config *models.ExecutorConfig) error {
-	filter := bson.M{"name": config.Name}
-	update := bson.M{"$set": config}
-	_, err := s.executorsColl.UpdateOne(ctx, filter, update)
+// updateTaskFrom applies update to the task if it is still in the from status
+// and, if leaseID is not empty, still held under that lease.
+// Returns ErrStatusConflict otherwise.
+func (s *mongoStorage) updateTaskFrom(ctx context.Context, id primitive.ObjectID, from models.TaskStatus, leaseID string, update bson.M) error {
+	result, err := s.tasksColl.UpdateOne(ctx, taskFrom(id, from, leaseID), update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrStatusConflict
+	}
+	return nil
+}
+
+// taskFrom matches the task if it is in the from status and, if leaseID is not
+// empty, held under that lease.
+func taskFrom(id primitive.ObjectID, from models.TaskStatus, leaseID string) bson.M {
+	filter := bson.M{"_id": id, "status": from}
+	if leaseID != "" {
+		filter["lease_id"] = leaseID
+	}
+	return filter
+}
+
+// liveExecutor matches the executor with the given name unless it has been deleted.
+func liveExecutor(name string) bson.M {
+	return bson.M{"name": name, "deleted_at": bson.M{"$exists": false}}
+}
+
+func (s *mongoStorage) CreateExecutor(ctx context.Context, config *models.ExecutorConfig) error {
+	// The name is unique, so a deleted executor would block it until purged
+	_, err := s.executorsColl.DeleteOne(ctx, bson.M{"name": config.Name, "deleted_at": bson.M{"$exists": true}})
+	if err != nil {
+		return err
+	}
+	config.Version = 1
+	_, err = s.executorsColl.InsertOne(ctx, config)
 	return err
 }
 
+func (s *mongoStorage) UpdateExecutor(ctx context.Context, config *models.ExecutorConfig, fields []string) (*models.ExecutorConfig, error) {
+	doc, err := bson.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	set := bson.M{"updated_at": config.UpdatedAt}
+	unset := bson.M{}
+	for _, field := range fields {
+		value := bson.Raw(doc).Lookup(strings.Split(field, ".")...)
+		if value.Type == 0 {
+			// Zero values of omitempty fields are not marshalled
+			unset[field] = ""
+			continue
+		}
+		set[field] = value
+	}
+
+	filter := liveExecutor(config.Name)
+	filter["version"] = config.Version
+	update := bson.M{"$set": set, "$inc": bson.M{"version": 1}}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updated models.ExecutorConfig
+	err = s.executorsColl.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+	if err == nil {
+		return &updated, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	count, err := s.executorsColl.CountDocuments(ctx, liveExecutor(config.Name))
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, ErrVersionConflict
+	}
+	return nil, nil
+}
+
 func (s *mongoStorage) GetExecutor(ctx context.Context, name string) (*models.ExecutorConfig, error) {
 	var config models.ExecutorConfig
-	err := s.executorsColl.FindOne(ctx, bson.M{"name": name}).Decode(&config)
+	err := s.executorsColl.FindOne(ctx, liveExecutor(name)).Decode(&config)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, nil
@@ -88,7 +351,15 @@ func (s *mongoStorage) GetExecutor(ctx context.Context, name string) (*models.Ex
 }
 
 func (s *mongoStorage) ListExecutors(ctx context.Context) ([]*models.ExecutorConfig, error) {
-	cursor, err := s.executorsColl.Find(ctx, bson.M{})
+	return s.findExecutors(ctx, bson.M{"deleted_at": bson.M{"$exists": false}})
+}
+
+func (s *mongoStorage) ListDeletedExecutors(ctx context.Context) ([]*models.ExecutorConfig, error) {
+	return s.findExecutors(ctx, bson.M{"deleted_at": bson.M{"$exists": true}})
+}
+
+func (s *mongoStorage) findExecutors(ctx context.Context, filter bson.M) ([]*models.ExecutorConfig, error) {
+	cursor, err := s.executorsColl.Find(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -101,121 +372,1442 @@ func (s *mongoStorage) ListExecutors(ctx context.Context) ([]*models.ExecutorCon
 	return executors, nil
 }
 
-func (s *mongoStorage) DeleteExecutor(ctx context.Context, name string) error {
-	_, err := s.executorsColl.DeleteOne(ctx, bson.M{"name": name})
-	return err
-}
-
-func (s *mongoStorage) AddTask(ctx context.Context, task *models.Task) error {
-	task.CreatedAt = time.Now()
-	task.UpdatedAt = time.Now()
-	task.Status = models.TaskStatusPending
-	task.RetryCount = 0
-
-	result, err := s.tasksColl.InsertOne(ctx, task)
-	if err != nil {
-		return err
+func (s *mongoStorage) DeleteExecutor(ctx context.Context, name string) (*models.ExecutorConfig, error) {
+	// $ifNull keeps the time of the first deletion when the call is repeated
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"deleted_at": bson.M{"$ifNull": bson.A{"$deleted_at", "$$NOW"}}}}},
 	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
 
-	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
-		task.ID = oid
+	var config models.ExecutorConfig
+	err := s.executorsColl.FindOneAndUpdate(ctx, bson.M{"name": name}, update, opts).Decode(&config)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
 	}
-	return nil
+	return &config, nil
 }
 
-func (s *mongoStorage) GetTask(ctx context.Context, id string) (*models.Task, error) {
-	objectID, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
-		return nil, err
+func (s *mongoStorage) UndeleteExecutor(ctx context.Context, name string, deletedAfter time.Time) (*models.ExecutorConfig, error) {
+	filter := bson.M{"name": name, "deleted_at": bson.M{"$gte": deletedAfter}}
+	update := bson.M{
+		"$unset": bson.M{"deleted_at": ""},
+		"$set":   bson.M{"updated_at": time.Now()},
+		"$inc":   bson.M{"version": 1},
 	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
 
-	var task models.Task
-	err = s.tasksColl.FindOne(ctx, bson.M{"_id": objectID}).Decode(&task)
+	var config models.ExecutorConfig
+	err := s.executorsColl.FindOneAndUpdate(ctx, filter, update, opts).Decode(&config)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, nil
 		}
 		return nil, err
 	}
-	return &task, nil
+	return &config, nil
 }
 
-func (s *mongoStorage) UpdateTaskStatus(ctx context.Context, id string, status models.TaskStatus, errorMsg string) error {
-	objectID, err := primitive.ObjectIDFromHex(id)
+func (s *mongoStorage) PurgeDeletedExecutors(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	result, err := s.executorsColl.DeleteMany(ctx, bson.M{"deleted_at": bson.M{"$lt": deletedBefore}})
 	if err != nil {
-		return err
+		return 0, err
 	}
+	return result.DeletedCount, nil
+}
 
+func (s *mongoStorage) SetExecutorState(ctx context.Context, name string, state models.ExecutorState) (*models.ExecutorConfig, error) {
 	update := bson.M{
 		"$set": bson.M{
-			"status":     status,
-			"error":      errorMsg,
+			"state":      state,
+			"enabled":    state != models.ExecutorStateDisabled,
 			"updated_at": time.Now(),
 		},
+		"$inc": bson.M{"version": 1},
 	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
 
-	if status == models.TaskStatusInProgress {
-		now := time.Now()
-		update["$set"].(bson.M)["started_at"] = now
-	} else if status == models.TaskStatusCompleted || status == models.TaskStatusFailed || status == models.TaskStatusDLQ {
-		now := time.Now()
-		update["$set"].(bson.M)["completed_at"] = now
+	var config models.ExecutorConfig
+	err := s.executorsColl.FindOneAndUpdate(ctx, liveExecutor(name), update, opts).Decode(&config)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
 	}
+	return &config, nil
+}
 
-	_, err = s.tasksColl.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+func (s *mongoStorage) AddExecutorRevision(ctx context.Context, revision *models.ExecutorRevision) error {
+	revision.ID = primitive.NewObjectID()
+	_, err := s.revisionsColl.InsertOne(ctx, revision)
 	return err
 }
 
-func (s *mongoStorage) GetNextTask(ctx context.Context, executorName string) (*models.Task, error) {
-	filter := bson.M{
-		"executor_name": executorName,
-		"status":        models.TaskStatusPending,
+func (s *mongoStorage) ListExecutorRevisions(ctx context.Context, executorName string, before primitive.ObjectID, limit int) ([]*models.ExecutorRevision, error) {
+	filter := bson.M{"executor_name": executorName}
+	if !before.IsZero() {
+		filter["_id"] = bson.M{"$lt": before}
 	}
-	update := bson.M{
-		"$set": bson.M{
-			"status":     models.TaskStatusInProgress,
-			"started_at": time.Now(),
-			"updated_at": time.Now(),
-		},
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: -1}}).SetLimit(int64(limit))
+
+	cursor, err := s.revisionsColl.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
 	}
-	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	defer cursor.Close(ctx)
 
-	var task models.Task
-	err := s.tasksColl.FindOneAndUpdate(ctx, filter, update, opts).Decode(&task)
+	revisions := []*models.ExecutorRevision{}
+	if err := cursor.All(ctx, &revisions); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+func (s *mongoStorage) GetExecutorRevision(ctx context.Context, id primitive.ObjectID) (*models.ExecutorRevision, error) {
+	var revision models.ExecutorRevision
+	err := s.revisionsColl.FindOne(ctx, bson.M{"_id": id}).Decode(&revision)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, nil
 		}
 		return nil, err
 	}
-	return &task, nil
+	return &revision, nil
 }
 
-func (s *mongoStorage) MoveToDLQ(ctx context.Context, task *models.Task) error {
-	if err := s.UpdateTaskStatus(ctx, task.ID.Hex(), models.TaskStatusDLQ, task.Error); err != nil {
-		return err
+func (s *mongoStorage) TakeRateTokens(ctx context.Context, executorName string, limit models.RateLimit, n int) (int, time.Duration, error) {
+	size := limit.BucketSize()
+	elapsed := bson.M{"$divide": bson.A{
+		bson.M{"$subtract": bson.A{"$$NOW", bson.M{"$ifNull": bson.A{"$rate_refilled_at", "$$NOW"}}}},
+		1000,
+	}}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"rate_tokens": bson.M{"$min": bson.A{size, bson.M{"$add": bson.A{
+				bson.M{"$ifNull": bson.A{"$rate_tokens", size}},
+				bson.M{"$multiply": bson.A{elapsed, limit.TasksPerSecond}},
+			}}}},
+			"rate_refilled_at": "$$NOW",
+		}}},
+		{{Key: "$set", Value: bson.M{"rate_taken": bson.M{"$min": bson.A{n, bson.M{"$floor": "$rate_tokens"}}}}}},
+		{{Key: "$set", Value: bson.M{"rate_tokens": bson.M{"$subtract": bson.A{"$rate_tokens", "$rate_taken"}}}}},
+	}
+	opts := options.FindOneAndUpdate().
+		SetReturnDocument(options.After).
+		SetProjection(bson.M{"rate_tokens": 1, "rate_taken": 1})
+
+	var after struct {
+		Tokens float64 `bson:"rate_tokens"`
+		Taken  int     `bson:"rate_taken"`
 	}
+	err := s.executorsColl.FindOneAndUpdate(ctx, bson.M{"name": executorName}, update, opts).Decode(&after)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return 0, 0, nil
+		}
+		return 0, 0, err
+	}
+	if after.Taken > 0 {
+		return after.Taken, 0, nil
+	}
+	wait := time.Duration((1 - after.Tokens) / limit.TasksPerSecond * float64(time.Second))
+	return 0, wait, nil
+}
 
-	_, err := s.dlqColl.InsertOne(ctx, task)
+func (s *mongoStorage) ReturnRateTokens(ctx context.Context, executorName string, limit models.RateLimit, n int) error {
+	size := limit.BucketSize()
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"rate_tokens": bson.M{"$min": bson.A{size, bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$rate_tokens", size}}, n}}}}}}},
+	}
+	_, err := s.executorsColl.UpdateOne(ctx, bson.M{"name": executorName}, update)
 	return err
 }
 
-func (s *mongoStorage) GetDLQTasks(ctx context.Context, executorName string) ([]*models.Task, error) {
-	filter := bson.M{"executor_name": executorName}
-	cursor, err := s.dlqColl.Find(ctx, filter)
+func (s *mongoStorage) AddTask(ctx context.Context, task *models.Task) error {
+	task.CreatedAt = time.Now()
+	task.UpdatedAt = time.Now()
+	task.Status = models.TaskStatusPending
+	task.RetryCount = 0
+
+	result, err := s.tasksColl.InsertOne(ctx, task)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) && task.IdempotencyKey != "" {
+			return ErrDuplicateTask
+		}
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		task.ID = oid
+	}
+	return nil
+}
+
+func (s *mongoStorage) GetTask(ctx context.Context, id string) (*models.Task, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(ctx)
 
-	var tasks []*models.Task
-	if err := cursor.All(ctx, &tasks); err != nil {
+	var task models.Task
+	err = s.tasksColl.FindOne(ctx, bson.M{"_id": objectID}).Decode(&task)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
 		return nil, err
 	}
-	return tasks, nil
+	return &task, nil
 }
 
-func (s *mongoStorage) ClearDLQ(ctx context.Context, executorName string) error {
-	_, err := s.dlqColl.DeleteMany(ctx, bson.M{"executor_name": executorName})
-	return err
+func (s *mongoStorage) CountUnfinishedTasks(ctx context.Context, executorName string) (int64, error) {
+	return s.tasksColl.CountDocuments(ctx, bson.M{
+		"executor_name": executorName,
+		"status":        bson.M{"$in": bson.A{models.TaskStatusPending, models.TaskStatusInProgress}},
+	})
+}
+
+// executorTasks matches the pending, in-progress and DLQ tasks of an executor.
+func executorTasks(executorName string) bson.M {
+	return bson.M{
+		"executor_name": executorName,
+		"status":        bson.M{"$in": bson.A{models.TaskStatusPending, models.TaskStatusInProgress, models.TaskStatusDLQ}},
+	}
+}
+
+// requestExecutorCancel requests the cancellation of the in-progress tasks of
+// an executor, like CancelTask does for a single task.
+func (s *mongoStorage) requestExecutorCancel(ctx context.Context, executorName, reason string) (int64, error) {
+	result, err := s.tasksColl.UpdateMany(ctx,
+		bson.M{"executor_name": executorName, "status": models.TaskStatusInProgress, "cancel_requested": bson.M{"$ne": true}},
+		bson.M{"$set": bson.M{
+			"cancel_requested": true,
+			"error":            reason,
+			"updated_at":       time.Now(),
+		}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+func (s *mongoStorage) CancelExecutorTasks(ctx context.Context, executorName, reason string) (int64, error) {
+	now := time.Now()
+	result, err := s.tasksColl.UpdateMany(ctx,
+		bson.M{"executor_name": executorName, "status": bson.M{"$in": bson.A{models.TaskStatusPending, models.TaskStatusDLQ}}},
+		bson.M{"$set": bson.M{
+			"status":       models.TaskStatusCancelled,
+			"error":        reason,
+			"updated_at":   now,
+			"completed_at": now,
+		}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := s.dlqColl.DeleteMany(ctx, bson.M{"executor_name": executorName}); err != nil {
+		return 0, err
+	}
+	requested, err := s.requestExecutorCancel(ctx, executorName, reason)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount + requested, nil
+}
+
+func (s *mongoStorage) MoveExecutorTasks(ctx context.Context, from, to, toDLQ, reason string) (int64, error) {
+	now := time.Now()
+	result, err := s.tasksColl.UpdateMany(ctx,
+		bson.M{"executor_name": from, "status": models.TaskStatusPending},
+		bson.M{
+			"$set":   bson.M{"executor_name": to, "updated_at": now},
+			"$unset": bson.M{"idempotency_key": ""},
+		},
+	)
+	if err != nil {
+		return 0, err
+	}
+	moved := result.ModifiedCount
+
+	toDLQUpdate := bson.M{
+		"$set":   bson.M{"executor_name": to, "dlq_name": toDLQ, "updated_at": now},
+		"$unset": bson.M{"idempotency_key": ""},
+	}
+	result, err = s.tasksColl.UpdateMany(ctx, bson.M{"executor_name": from, "status": models.TaskStatusDLQ}, toDLQUpdate)
+	if err != nil {
+		return 0, err
+	}
+	moved += result.ModifiedCount
+	if _, err := s.dlqColl.UpdateMany(ctx, bson.M{"executor_name": from}, toDLQUpdate); err != nil {
+		return 0, err
+	}
+
+	requested, err := s.requestExecutorCancel(ctx, from, reason)
+	if err != nil {
+		return 0, err
+	}
+	return moved + requested, nil
+}
+
+// archiveBatchSize is the number of tasks copied to the archive at once.
+const archiveBatchSize = 500
+
+func (s *mongoStorage) ArchiveExecutorTasks(ctx context.Context, executorName string) (int64, error) {
+	var archived int64
+	for {
+		tasks, err := findTasks(ctx, s.tasksColl, executorTasks(executorName), archiveBatchSize)
+		if err != nil {
+			return archived, err
+		}
+		if len(tasks) == 0 {
+			break
+		}
+
+		// Upserts keep a retry after a failed delete from duplicating tasks in the archive
+		writes := make([]mongo.WriteModel, len(tasks))
+		ids := make(bson.A, len(tasks))
+		for i, task := range tasks {
+			writes[i] = mongo.NewReplaceOneModel().SetFilter(bson.M{"_id": task.ID}).SetReplacement(task).SetUpsert(true)
+			ids[i] = task.ID
+		}
+		if _, err := s.archiveColl.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+			return archived, err
+		}
+		result, err := s.tasksColl.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+		if err != nil {
+			return archived, err
+		}
+		archived += result.DeletedCount
+	}
+
+	_, err := s.dlqColl.DeleteMany(ctx, bson.M{"executor_name": executorName})
+	return archived, err
+}
+
+func (s *mongoStorage) GetTaskByIdempotencyKey(ctx context.Context, executorName, key string) (*models.Task, error) {
+	filter := bson.M{
+		"executor_name":   executorName,
+		"idempotency_key": key,
+	}
+
+	var task models.Task
+	err := s.tasksColl.FindOne(ctx, filter).Decode(&task)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (s *mongoStorage) ReleaseIdempotencyKey(ctx context.Context, id primitive.ObjectID) error {
+	update := bson.M{
+		"$unset": bson.M{"idempotency_key": ""},
+		"$set":   bson.M{"updated_at": time.Now()},
+	}
+	_, err := s.tasksColl.UpdateOne(ctx, bson.M{"_id": id}, update)
+	return err
+}
+
+func (s *mongoStorage) ListTasks(ctx context.Context, filter TaskFilter, after *TaskCursor, limit int) ([]*models.Task, error) {
+	return findTasks(ctx, s.tasksColl, taskQuery(filter, after), limit)
+}
+
+// taskQuery translates a task filter and page position into a MongoDB query.
+func taskQuery(filter TaskFilter, after *TaskCursor) bson.M {
+	query := bson.M{}
+	if filter.ExecutorName != "" {
+		query["executor_name"] = filter.ExecutorName
+	}
+	if len(filter.Statuses) > 0 {
+		query["status"] = bson.M{"$in": filter.Statuses}
+	}
+	if created := timeRange(filter.CreatedAfter, filter.CreatedBefore); created != nil {
+		query["created_at"] = created
+	}
+	if updated := timeRange(filter.UpdatedAfter, filter.UpdatedBefore); updated != nil {
+		query["updated_at"] = updated
+	}
+	for key, value := range filter.Metadata {
+		query["metadata."+key] = value
+	}
+	if len(filter.IDs) > 0 {
+		query["_id"] = bson.M{"$in": filter.IDs}
+	}
+	if filter.ErrorContains != "" {
+		query["error"] = bson.M{"$regex": regexp.QuoteMeta(filter.ErrorContains), "$options": "i"}
+	}
+	var and bson.A
+	if filter.DLQName != "" {
+		// Tasks dead-lettered before queues were named belong to the queue named after their executor
+		and = append(and, bson.M{"$or": bson.A{
+			bson.M{"dlq_name": filter.DLQName},
+			bson.M{"dlq_name": bson.M{"$exists": false}, "executor_name": filter.DLQName},
+		}})
+	}
+	if filter.ErrorGroup != "" {
+		and = append(and, bson.M{"$or": bson.A{
+			bson.M{"error_group": filter.ErrorGroup},
+			bson.M{"error_group": bson.M{"$exists": false}, "error": filter.ErrorGroup},
+		}})
+	}
+	if after != nil {
+		and = append(and, bson.M{"$or": bson.A{
+			bson.M{"created_at": bson.M{"$lt": after.CreatedAt}},
+			bson.M{"created_at": after.CreatedAt, "_id": bson.M{"$lt": after.ID}},
+		}})
+	}
+	if len(and) > 0 {
+		query["$and"] = and
+	}
+	return query
+}
+
+// findTasks returns up to limit tasks of coll matching query, newest first.
+func findTasks(ctx context.Context, coll *mongo.Collection, query bson.M, limit int) ([]*models.Task, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(int64(limit))
+	cursor, err := coll.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tasks []*models.Task
+	if err := cursor.All(ctx, &tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// timeRange builds a [from, to) range condition, or nil if both bounds are zero.
+func timeRange(from, to time.Time) bson.M {
+	if from.IsZero() && to.IsZero() {
+		return nil
+	}
+	cond := bson.M{}
+	if !from.IsZero() {
+		cond["$gte"] = from
+	}
+	if !to.IsZero() {
+		cond["$lt"] = to
+	}
+	return cond
+}
+
+func (s *mongoStorage) UpdateTaskStatus(ctx context.Context, id, leaseID string, from, status models.TaskStatus, errorMsg string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":     status,
+			"error":      errorMsg,
+			"updated_at": time.Now(),
+		},
+	}
+
+	if status == models.TaskStatusInProgress {
+		now := time.Now()
+		update["$set"].(bson.M)["started_at"] = now
+	} else {
+		update["$unset"] = bson.M{"lease_expires_at": "", "lease_id": "", "in_flight_slot": ""}
+	}
+	if status == models.TaskStatusCompleted || status == models.TaskStatusFailed || status == models.TaskStatusDLQ || status == models.TaskStatusCancelled {
+		now := time.Now()
+		update["$set"].(bson.M)["completed_at"] = now
+	}
+
+	return s.updateTaskFrom(ctx, objectID, from, leaseID, update)
+}
+
+func (s *mongoStorage) CompleteTask(ctx context.Context, id, leaseID string, from models.TaskStatus, result []byte) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"status":       models.TaskStatusCompleted,
+			"error":        "",
+			"result":       result,
+			"updated_at":   now,
+			"completed_at": now,
+		},
+		"$unset": bson.M{"lease_expires_at": "", "lease_id": "", "in_flight_slot": ""},
+	}
+	return s.updateTaskFrom(ctx, objectID, from, leaseID, update)
+}
+
+func (s *mongoStorage) CompleteTasks(ctx context.Context, from models.TaskStatus, completions []TaskCompletion) ([]error, error) {
+	batchID := primitive.NewObjectID().Hex()
+	now := time.Now()
+	ids := make([]primitive.ObjectID, len(completions))
+	writes := make([]mongo.WriteModel, len(completions))
+	for i, completion := range completions {
+		objectID, err := primitive.ObjectIDFromHex(completion.ID)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = objectID
+		writes[i] = mongo.NewUpdateOneModel().
+			SetFilter(taskFrom(objectID, from, completion.LeaseID)).
+			SetUpdate(bson.M{
+				"$set": bson.M{
+					"status":       models.TaskStatusCompleted,
+					"error":        "",
+					"result":       completion.Result,
+					"updated_at":   now,
+					"completed_at": now,
+					"batch_id":     batchID,
+				},
+				"$unset": bson.M{"lease_expires_at": "", "lease_id": "", "in_flight_slot": ""},
+			})
+	}
+
+	results := make([]error, len(completions))
+	if len(completions) == 0 {
+		return results, nil
+	}
+	written, err := s.tasksColl.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return nil, err
+	}
+	if written.MatchedCount == int64(len(completions)) {
+		return results, nil
+	}
+
+	// Some tasks were not in the from status; the batch ID tells which ones were completed here
+	cursor, err := s.tasksColl.Find(ctx,
+		bson.M{"_id": bson.M{"$in": ids}, "batch_id": batchID},
+		options.Find().SetProjection(bson.M{"_id": 1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var completed []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &completed); err != nil {
+		return nil, err
+	}
+	done := make(map[primitive.ObjectID]bool, len(completed))
+	for _, task := range completed {
+		done[task.ID] = true
+	}
+	for i, id := range ids {
+		if !done[id] {
+			results[i] = ErrStatusConflict
+		}
+	}
+	return results, nil
+}
+
+func (s *mongoStorage) CancelTask(ctx context.Context, id string, reason string) (*models.Task, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var task models.Task
+	err = s.tasksColl.FindOneAndUpdate(ctx,
+		bson.M{"_id": objectID, "status": models.TaskStatusPending},
+		bson.M{"$set": bson.M{
+			"status":       models.TaskStatusCancelled,
+			"error":        reason,
+			"updated_at":   now,
+			"completed_at": now,
+		}},
+		opts,
+	).Decode(&task)
+	if err == nil {
+		return &task, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+
+	err = s.tasksColl.FindOneAndUpdate(ctx,
+		bson.M{"_id": objectID, "status": models.TaskStatusInProgress},
+		bson.M{"$set": bson.M{
+			"cancel_requested": true,
+			"error":            reason,
+			"updated_at":       now,
+		}},
+		opts,
+	).Decode(&task)
+	if err == nil {
+		return &task, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+
+	return s.GetTask(ctx, id)
+}
+
+func (s *mongoStorage) RetryTask(ctx context.Context, task *models.Task, from models.TaskStatus) error {
+	update := bson.M{
+		"$set": bson.M{
+			"status":          models.TaskStatusPending,
+			"error":           task.Error,
+			"retry_count":     task.RetryCount,
+			"next_attempt_at": task.NextAttemptAt,
+			"updated_at":      time.Now(),
+		},
+		"$unset": bson.M{"lease_expires_at": "", "lease_id": "", "in_flight_slot": ""},
+	}
+
+	return s.updateTaskFrom(ctx, task.ID, from, task.LeaseID, update)
+}
+
+func (s *mongoStorage) ReleaseTask(ctx context.Context, id, leaseID string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":     models.TaskStatusPending,
+			"updated_at": time.Now(),
+		},
+		"$unset": bson.M{"lease_expires_at": "", "lease_id": "", "in_flight_slot": "", "next_attempt_at": "", "progress": ""},
+	}
+	return s.updateTaskFrom(ctx, objectID, models.TaskStatusInProgress, leaseID, update)
+}
+
+// leaseSlotAttempts is how many times GetNextTask tries to take a free
+// in-flight slot before giving up to concurrent callers.
+const leaseSlotAttempts = 3
+
+func (s *mongoStorage) GetNextTask(ctx context.Context, executorName string, leaseDuration time.Duration, maxInFlight int) (*models.Task, error) {
+	for attempt := 1; ; attempt++ {
+		now := time.Now()
+		filter := bson.M{
+			"executor_name": executorName,
+			"status":        models.TaskStatusPending,
+			"$or": bson.A{
+				bson.M{"next_attempt_at": bson.M{"$exists": false}},
+				bson.M{"next_attempt_at": bson.M{"$lte": now}},
+			},
+		}
+		set := bson.M{
+			"status":           models.TaskStatusInProgress,
+			"started_at":       now,
+			"updated_at":       now,
+			"lease_expires_at": now.Add(leaseDuration),
+			"lease_id":         primitive.NewObjectID().Hex(),
+		}
+		update := bson.M{"$set": set}
+		if maxInFlight > 0 {
+			slots, err := s.freeInFlightSlots(ctx, executorName, maxInFlight, 1)
+			if err != nil {
+				return nil, err
+			}
+			set["in_flight_slot"] = slots[0]
+		} else {
+			update["$unset"] = bson.M{"in_flight_slot": ""}
+		}
+		opts := options.FindOneAndUpdate().
+			SetSort(bson.D{{Key: "priority", Value: -1}, {Key: "created_at", Value: 1}}).
+			SetReturnDocument(options.After)
+
+		var task models.Task
+		err := s.tasksColl.FindOneAndUpdate(ctx, filter, update, opts).Decode(&task)
+		if isDuplicateKeyOn(err, "in_flight_slot") {
+			// A concurrent caller has taken the slot in the meantime
+			if attempt < leaseSlotAttempts {
+				continue
+			}
+			return nil, ErrInFlightLimit
+		}
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return &task, nil
+	}
+}
+
+// freeInFlightSlots returns up to n slot numbers below limit that no task of
+// the executor in progress holds, or ErrInFlightLimit if there are none. Tasks
+// in progress without a slot (leased while the executor had no limit) or with
+// a slot at or above limit (leased before it was lowered) count against the
+// limit too.
+func (s *mongoStorage) freeInFlightSlots(ctx context.Context, executorName string, limit, n int) ([]int, error) {
+	inProgress := bson.M{"executor_name": executorName, "status": models.TaskStatusInProgress}
+	count, err := s.tasksColl.CountDocuments(ctx, inProgress)
+	if err != nil {
+		return nil, err
+	}
+	if free := limit - int(count); free < n {
+		n = free
+	}
+	if n <= 0 {
+		return nil, ErrInFlightLimit
+	}
+
+	inProgress["in_flight_slot"] = bson.M{"$lt": limit}
+	used, err := s.tasksColl.Distinct(ctx, "in_flight_slot", inProgress)
+	if err != nil {
+		return nil, err
+	}
+	taken := make(map[int64]bool, len(used))
+	for _, slot := range used {
+		switch slot := slot.(type) {
+		case int32:
+			taken[int64(slot)] = true
+		case int64:
+			taken[slot] = true
+		}
+	}
+	slots := make([]int, 0, n)
+	for slot := 0; slot < limit && len(slots) < n; slot++ {
+		if !taken[int64(slot)] {
+			slots = append(slots, slot)
+		}
+	}
+	return slots, nil
+}
+
+func (s *mongoStorage) GetNextTasks(ctx context.Context, executorName string, leaseDuration time.Duration, limit, maxInFlight int) ([]*models.Task, error) {
+	var slots []int
+	if maxInFlight > 0 {
+		var err error
+		slots, err = s.freeInFlightSlots(ctx, executorName, maxInFlight, limit)
+		if err != nil {
+			return nil, err
+		}
+		limit = len(slots)
+	}
+
+	now := time.Now()
+	filter := bson.M{
+		"executor_name": executorName,
+		"status":        models.TaskStatusPending,
+		"$or": bson.A{
+			bson.M{"next_attempt_at": bson.M{"$exists": false}},
+			bson.M{"next_attempt_at": bson.M{"$lte": now}},
+		},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "priority", Value: -1}, {Key: "created_at", Value: 1}}).
+		SetLimit(int64(limit)).
+		SetProjection(bson.M{"_id": 1})
+	cursor, err := s.tasksColl.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	err = cursor.All(ctx, &candidates)
+	cursor.Close(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return []*models.Task{}, nil
+	}
+	ids := make([]primitive.ObjectID, len(candidates))
+	for i, candidate := range candidates {
+		ids[i] = candidate.ID
+	}
+
+	// Each document is updated atomically and only while still pending, so a
+	// task picked by a concurrent caller in the meantime is skipped; the batch
+	// ID then tells which of the candidates were leased by this call.
+	batchID := primitive.NewObjectID().Hex()
+	set := bson.M{
+		"status":           models.TaskStatusInProgress,
+		"started_at":       now,
+		"updated_at":       now,
+		"lease_expires_at": now.Add(leaseDuration),
+		"lease_id":         batchID,
+		"batch_id":         batchID,
+	}
+	if slots == nil {
+		filter["_id"] = bson.M{"$in": ids}
+		_, err = s.tasksColl.UpdateMany(ctx, filter, bson.M{"$set": set, "$unset": bson.M{"in_flight_slot": ""}})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Every candidate takes its own slot; one a concurrent caller has taken
+		// in the meantime is rejected by the unique index and the task skipped
+		writes := make([]mongo.WriteModel, len(ids))
+		for i, id := range ids {
+			taskSet := bson.M{"in_flight_slot": slots[i]}
+			for k, v := range set {
+				taskSet[k] = v
+			}
+			writes[i] = mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"$and": bson.A{filter, bson.M{"_id": id}}}).
+				SetUpdate(bson.M{"$set": taskSet})
+		}
+		_, err = s.tasksColl.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+		if err != nil && !onlyDuplicateKeysOn(err, "in_flight_slot") {
+			return nil, err
+		}
+	}
+
+	cursor, err = s.tasksColl.Find(ctx,
+		bson.M{"_id": bson.M{"$in": ids}, "batch_id": batchID, "status": models.TaskStatusInProgress},
+		options.Find().SetSort(bson.D{{Key: "priority", Value: -1}, {Key: "created_at", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	tasks := []*models.Task{}
+	if err := cursor.All(ctx, &tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (s *mongoStorage) WatchPendingTasks(ctx context.Context, fn func(executorName string)) error {
+	// Change streams need an oplog, which is there whenever transactions are
+	if !s.transactions {
+		return ErrWatchUnsupported
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"$or": bson.A{
+			bson.M{"operationType": bson.M{"$in": bson.A{"insert", "replace"}}, "fullDocument.status": models.TaskStatusPending},
+			// Other updates of pending tasks, e.g. priority aging, do not make them available
+			bson.M{"operationType": "update", "updateDescription.updatedFields.status": models.TaskStatusPending},
+		}}}},
+		{{Key: "$project", Value: bson.M{"fullDocument.executor_name": 1}}},
+	}
+	stream, err := s.tasksColl.Watch(ctx, pipeline, options.ChangeStream().SetFullDocument(options.UpdateLookup))
+	if err != nil {
+		return err
+	}
+	defer stream.Close(ctx)
+
+	for stream.Next(ctx) {
+		var event struct {
+			FullDocument struct {
+				ExecutorName string `bson:"executor_name"`
+			} `bson:"fullDocument"`
+		}
+		if err := stream.Decode(&event); err != nil {
+			return err
+		}
+		fn(event.FullDocument.ExecutorName)
+	}
+	return stream.Err()
+}
+
+func (s *mongoStorage) GetExpiredTasks(ctx context.Context) ([]*models.Task, error) {
+	filter := bson.M{
+		"status":           models.TaskStatusInProgress,
+		"lease_expires_at": bson.M{"$lte": time.Now()},
+	}
+	cursor, err := s.tasksColl.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tasks []*models.Task
+	if err := cursor.All(ctx, &tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (s *mongoStorage) ExtendLease(ctx context.Context, id, leaseID string, leaseExpiresAt time.Time, progress *models.TaskProgress) (*models.Task, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{
+		"_id":      objectID,
+		"status":   models.TaskStatusInProgress,
+		"lease_id": leaseID,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"lease_expires_at": leaseExpiresAt,
+			"updated_at":       time.Now(),
+		},
+	}
+	if progress != nil {
+		update["$set"].(bson.M)["progress"] = progress
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var task models.Task
+	err = s.tasksColl.FindOneAndUpdate(ctx, filter, update, opts).Decode(&task)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (s *mongoStorage) AgeTasks(ctx context.Context, executorName string, aging models.PriorityAging) (int64, error) {
+	now := time.Now()
+	waitedSince := now.Add(-aging.Threshold)
+	filter := bson.M{
+		"executor_name": executorName,
+		"status":        models.TaskStatusPending,
+		"priority":      bson.M{"$lt": aging.MaxPriority},
+		"$and": bson.A{
+			bson.M{"$or": bson.A{
+				bson.M{"next_attempt_at": bson.M{"$exists": false}},
+				bson.M{"next_attempt_at": bson.M{"$lte": now}},
+			}},
+			bson.M{"$or": bson.A{
+				bson.M{"aged_at": bson.M{"$lte": waitedSince}},
+				bson.M{"aged_at": bson.M{"$exists": false}, "created_at": bson.M{"$lte": waitedSince}},
+			}},
+		},
+	}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"priority": bson.M{"$min": bson.A{bson.M{"$add": bson.A{"$priority", aging.Step}}, aging.MaxPriority}},
+			"aged_at":  now,
+		}}},
+	}
+
+	result, err := s.tasksColl.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+func (s *mongoStorage) MoveToDLQ(ctx context.Context, task *models.Task, from models.TaskStatus) error {
+	// The DLQ copy reflects the final state; updated_at is the time the task was moved.
+	now := time.Now()
+	task.Status = models.TaskStatusDLQ
+	task.UpdatedAt = now
+	task.CompletedAt = &now
+	task.LeaseExpiresAt = nil
+	leaseID := task.LeaseID
+	task.LeaseID = ""
+
+	markDLQ := func(ctx context.Context, pendingOp string) error {
+		set := bson.M{
+			"status":       models.TaskStatusDLQ,
+			"error":        task.Error,
+			"dlq_name":     task.DLQName,
+			"error_group":  task.ErrorGroup,
+			"updated_at":   now,
+			"completed_at": now,
+		}
+		if task.ExpireAt != nil {
+			// Kept on the task so that a replayed DLQ insert expires as well
+			set["expire_at"] = task.ExpireAt
+		}
+		if pendingOp != "" {
+			set["pending_op"] = pendingOp
+		}
+		return s.updateTaskFrom(ctx, task.ID, from, leaseID, bson.M{"$set": set, "$unset": bson.M{"lease_expires_at": "", "lease_id": "", "in_flight_slot": ""}})
+	}
+
+	if s.transactions {
+		return s.inTransaction(ctx, func(ctx mongo.SessionContext) error {
+			if err := markDLQ(ctx, ""); err != nil {
+				return err
+			}
+			return s.upsertDLQTask(ctx, task)
+		})
+	}
+
+	// Without transactions the DLQ insert is recorded on the task by the same
+	// single-document update and replayed by ReplayPendingOps after a crash.
+	if err := markDLQ(ctx, pendingOpDLQInsert); err != nil {
+		return err
+	}
+	if err := s.upsertDLQTask(ctx, task); err != nil {
+		return err
+	}
+	return s.clearPendingOp(ctx, task.ID, pendingOpDLQInsert)
+}
+
+// upsertDLQTask writes the DLQ copy of a task; repeating it is harmless.
+func (s *mongoStorage) upsertDLQTask(ctx context.Context, task *models.Task) error {
+	dlqTask := *task
+	dlqTask.PendingOp = ""
+	_, err := s.dlqColl.ReplaceOne(ctx, bson.M{"_id": task.ID}, &dlqTask, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (s *mongoStorage) clearPendingOp(ctx context.Context, id primitive.ObjectID, op string) error {
+	_, err := s.tasksColl.UpdateOne(ctx,
+		bson.M{"_id": id, "pending_op": op},
+		bson.M{"$unset": bson.M{"pending_op": ""}},
+	)
+	return err
+}
+
+func (s *mongoStorage) ReplayPendingOps(ctx context.Context, olderThan time.Time) (int, error) {
+	cursor, err := s.tasksColl.Find(ctx, bson.M{
+		"pending_op": bson.M{"$exists": true},
+		"updated_at": bson.M{"$lt": olderThan},
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var tasks []*models.Task
+	if err := cursor.All(ctx, &tasks); err != nil {
+		return 0, err
+	}
+
+	replayed := 0
+	for _, task := range tasks {
+		switch task.PendingOp {
+		case pendingOpDLQInsert:
+			err = s.upsertDLQTask(ctx, task)
+		case pendingOpDLQDelete:
+			_, err = s.dlqColl.DeleteOne(ctx, bson.M{"_id": task.ID})
+		default:
+			log.Printf("Unknown pending operation %q on task %s", task.PendingOp, task.ID.Hex())
+			continue
+		}
+		if err != nil {
+			return replayed, err
+		}
+		if err := s.clearPendingOp(ctx, task.ID, task.PendingOp); err != nil {
+			return replayed, err
+		}
+		replayed++
+	}
+	return replayed, nil
+}
+
+func (s *mongoStorage) ListDLQTasks(ctx context.Context, filter TaskFilter, after *TaskCursor, limit int) ([]*models.Task, error) {
+	return findTasks(ctx, s.dlqColl, taskQuery(filter, after), limit)
+}
+
+func (s *mongoStorage) PurgeDLQ(ctx context.Context, filter TaskFilter) (int64, error) {
+	result, err := s.dlqColl.DeleteMany(ctx, taskQuery(filter, nil))
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+func (s *mongoStorage) GetDLQStats(ctx context.Context, executorName string) (int64, time.Time, error) {
+	size, err := s.dlqColl.CountDocuments(ctx, bson.M{"executor_name": executorName})
+	if err != nil || size == 0 {
+		return 0, time.Time{}, err
+	}
+
+	oldest, err := s.OldestDLQTasks(ctx, executorName, time.Time{}, 1)
+	if err != nil || len(oldest) == 0 {
+		return size, time.Time{}, err
+	}
+	return size, oldest[0].UpdatedAt, nil
+}
+
+func (s *mongoStorage) OldestDLQTasks(ctx context.Context, executorName string, movedBefore time.Time, limit int) ([]*models.Task, error) {
+	filter := bson.M{"executor_name": executorName}
+	if !movedBefore.IsZero() {
+		filter["updated_at"] = bson.M{"$lt": movedBefore}
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "updated_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+	cursor, err := s.dlqColl.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tasks []*models.Task
+	if err := cursor.All(ctx, &tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (s *mongoStorage) ClaimDLQTasks(ctx context.Context, ids []primitive.ObjectID, claim string, abandonedBefore time.Time) ([]*models.Task, error) {
+	filter := bson.M{
+		"_id": bson.M{"$in": ids},
+		"$or": bson.A{
+			bson.M{"evict_claimed_at": bson.M{"$exists": false}},
+			bson.M{"evict_claimed_at": bson.M{"$lt": abandonedBefore}},
+		},
+	}
+	update := bson.M{"$set": bson.M{"evict_claim": claim, "evict_claimed_at": time.Now()}}
+	if _, err := s.dlqColl.UpdateMany(ctx, filter, update); err != nil {
+		return nil, err
+	}
+
+	cursor, err := s.dlqColl.Find(ctx, bson.M{"evict_claim": claim})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tasks []*models.Task
+	if err := cursor.All(ctx, &tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (s *mongoStorage) DeleteClaimedDLQTasks(ctx context.Context, claim string) (int64, error) {
+	result, err := s.dlqColl.DeleteMany(ctx, bson.M{"evict_claim": claim})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+func (s *mongoStorage) DLQErrorGroups(ctx context.Context, filter TaskFilter, samples int) ([]*models.DLQErrorGroup, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: taskQuery(filter, nil)}},
+		{{Key: "$sort", Value: bson.D{{Key: "updated_at", Value: -1}, {Key: "_id", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"executor_name": "$executor_name",
+				"error_group":   bson.M{"$ifNull": bson.A{"$error_group", "$error"}},
+			},
+			"count":           bson.M{"$sum": 1},
+			"first_seen":      bson.M{"$min": "$updated_at"},
+			"last_seen":       bson.M{"$max": "$updated_at"},
+			"sample_task_ids": bson.M{"$push": "$_id"},
+			"sample_error":    bson.M{"$first": "$error"},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":             0,
+			"executor_name":   "$_id.executor_name",
+			"error_group":     "$_id.error_group",
+			"count":           1,
+			"first_seen":      1,
+			"last_seen":       1,
+			"sample_task_ids": bson.M{"$slice": bson.A{"$sample_task_ids", samples}},
+			"sample_error":    1,
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "executor_name", Value: 1}, {Key: "error_group", Value: 1}}}},
+	}
+
+	cursor, err := s.dlqColl.Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	groups := []*models.DLQErrorGroup{}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+func (s *mongoStorage) ListDLQs(ctx context.Context) ([]*models.DLQInfo, error) {
+	return s.aggregateDLQs(ctx, "")
+}
+
+func (s *mongoStorage) GetDLQ(ctx context.Context, name string) (*models.DLQInfo, error) {
+	queues, err := s.aggregateDLQs(ctx, name)
+	if err != nil || len(queues) == 0 {
+		return nil, err
+	}
+	return queues[0], nil
+}
+
+// aggregateDLQs groups DLQ tasks by queue name, optionally only for the queue name.
+func (s *mongoStorage) aggregateDLQs(ctx context.Context, name string) ([]*models.DLQInfo, error) {
+	var pipeline mongo.Pipeline
+	if name != "" {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: taskQuery(TaskFilter{DLQName: name}, nil)}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":             bson.M{"$ifNull": bson.A{"$dlq_name", "$executor_name"}},
+			"size":            bson.M{"$sum": 1},
+			"executors":       bson.M{"$addToSet": "$executor_name"},
+			"oldest_entry_at": bson.M{"$min": "$updated_at"},
+		}}},
+		bson.D{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	)
+
+	cursor, err := s.dlqColl.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var queues []*models.DLQInfo
+	if err := cursor.All(ctx, &queues); err != nil {
+		return nil, err
+	}
+	return queues, nil
+}
+
+func (s *mongoStorage) RedriveTask(ctx context.Context, task *models.Task) error {
+	// Upsert keeps the redrive working even if the original task document is gone.
+	// A task document in any status other than dlq makes the upsert fail on the _id index.
+	replaceTask := func(ctx context.Context) error {
+		_, err := s.tasksColl.ReplaceOne(ctx,
+			bson.M{"_id": task.ID, "status": models.TaskStatusDLQ},
+			task,
+			options.Replace().SetUpsert(true),
+		)
+		if isDuplicateKeyOn(err, "idempotency_key") {
+			return ErrDuplicateTask
+		}
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrStatusConflict
+		}
+		return err
+	}
+	deleteDLQTask := func(ctx context.Context) error {
+		_, err := s.dlqColl.DeleteOne(ctx, bson.M{"_id": task.ID})
+		return err
+	}
+
+	if s.transactions {
+		return s.inTransaction(ctx, func(ctx mongo.SessionContext) error {
+			if err := replaceTask(ctx); err != nil {
+				return err
+			}
+			return deleteDLQTask(ctx)
+		})
+	}
+
+	task.PendingOp = pendingOpDLQDelete
+	if err := replaceTask(ctx); err != nil {
+		return err
+	}
+	if err := deleteDLQTask(ctx); err != nil {
+		return err
+	}
+	task.PendingOp = ""
+	return s.clearPendingOp(ctx, task.ID, pendingOpDLQDelete)
+}
+
+// isDuplicateKeyOn reports whether err is a duplicate key error of an index on field.
+func isDuplicateKeyOn(err error, field string) bool {
+	// findAndModify reports a duplicate key as a command error
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) {
+		return cmdErr.Code == 11000 && strings.Contains(cmdErr.Message, field)
+	}
+	var writeErr mongo.WriteException
+	if !errors.As(err, &writeErr) {
+		return false
+	}
+	for _, e := range writeErr.WriteErrors {
+		if e.Code == 11000 && strings.Contains(e.Message, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// onlyDuplicateKeysOn reports whether every write of a failed unordered bulk
+// write was rejected by the unique index on field.
+func onlyDuplicateKeysOn(err error, field string) bool {
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil {
+		return false
+	}
+	for _, e := range bulkErr.WriteErrors {
+		if e.Code != 11000 || !strings.Contains(e.Message, field) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *mongoStorage) CreateSchedule(ctx context.Context, schedule *models.Schedule) error {
+	result, err := s.schedulesColl.InsertOne(ctx, schedule)
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		schedule.ID = oid
+	}
+	return nil
+}
+
+func (s *mongoStorage) GetSchedule(ctx context.Context, name string) (*models.Schedule, error) {
+	var schedule models.Schedule
+	err := s.schedulesColl.FindOne(ctx, bson.M{"name": name}).Decode(&schedule)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (s *mongoStorage) ListSchedules(ctx context.Context, executorName string) ([]*models.Schedule, error) {
+	filter := bson.M{}
+	if executorName != "" {
+		filter["executor_name"] = executorName
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := s.schedulesColl.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var schedules []*models.Schedule
+	if err := cursor.All(ctx, &schedules); err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+func (s *mongoStorage) DeleteSchedule(ctx context.Context, name string) error {
+	result, err := s.schedulesColl.DeleteOne(ctx, bson.M{"name": name})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrScheduleNotFound
+	}
+	return nil
+}
+
+func (s *mongoStorage) SetSchedulePaused(ctx context.Context, name string, paused bool, nextRunAt time.Time) (*models.Schedule, error) {
+	update := bson.M{
+		"$set": bson.M{
+			"paused":      paused,
+			"next_run_at": nextRunAt,
+			"updated_at":  time.Now(),
+		},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var schedule models.Schedule
+	err := s.schedulesColl.FindOneAndUpdate(ctx, bson.M{"name": name}, update, opts).Decode(&schedule)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (s *mongoStorage) GetDueSchedules(ctx context.Context, now time.Time) ([]*models.Schedule, error) {
+	filter := bson.M{
+		"paused":      false,
+		"next_run_at": bson.M{"$lte": now},
+	}
+	cursor, err := s.schedulesColl.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var schedules []*models.Schedule
+	if err := cursor.All(ctx, &schedules); err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+func (s *mongoStorage) ClaimScheduleRun(ctx context.Context, id primitive.ObjectID, scheduledAt, nextRunAt time.Time) (bool, error) {
+	filter := bson.M{
+		"_id":         id,
+		"paused":      false,
+		"next_run_at": scheduledAt,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"next_run_at": nextRunAt,
+			"last_run_at": scheduledAt,
+			"updated_at":  time.Now(),
+		},
+	}
+	result, err := s.schedulesColl.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+func (s *mongoStorage) SetScheduleLastTask(ctx context.Context, id primitive.ObjectID, taskID string) error {
+	update := bson.M{
+		"$set": bson.M{
+			"last_task_id": taskID,
+			"updated_at":   time.Now(),
+		},
+	}
+	_, err := s.schedulesColl.UpdateOne(ctx, bson.M{"_id": id}, update)
+	return err
+}
+
+func (s *mongoStorage) RegisterWorker(ctx context.Context, worker *models.Worker) error {
+	_, err := s.workersColl.ReplaceOne(ctx, bson.M{"_id": worker.ID}, worker, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (s *mongoStorage) HeartbeatWorker(ctx context.Context, id string, activeTasks int, at time.Time) (bool, error) {
+	result, err := s.workersColl.UpdateOne(ctx,
+		bson.M{"_id": id, "status": models.WorkerStatusAlive},
+		bson.M{"$set": bson.M{"active_tasks": activeTasks, "last_heartbeat_at": at}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount == 1, nil
+}
+
+func (s *mongoStorage) ListWorkers(ctx context.Context, executorName string, includeDead bool) ([]*models.Worker, error) {
+	filter := bson.M{}
+	if executorName != "" {
+		filter["executor_name"] = executorName
+	}
+	if !includeDead {
+		filter["status"] = models.WorkerStatusAlive
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "executor_name", Value: 1}, {Key: "hostname", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := s.workersColl.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	workers := []*models.Worker{}
+	if err := cursor.All(ctx, &workers); err != nil {
+		return nil, err
+	}
+	return workers, nil
+}
+
+func (s *mongoStorage) MarkDeadWorkers(ctx context.Context, heartbeatBefore time.Time) (int64, error) {
+	result, err := s.workersColl.UpdateMany(ctx,
+		bson.M{"status": models.WorkerStatusAlive, "last_heartbeat_at": bson.M{"$lt": heartbeatBefore}},
+		bson.M{"$set": bson.M{"status": models.WorkerStatusDead, "active_tasks": 0}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
 }
@@ -2,59 +2,207 @@ package storage
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/botashev/tasks-executor/pkg/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// ErrDuplicateTask is returned by AddTask when the executor already has a task
+// with the same idempotency key.
+var ErrDuplicateTask = errors.New("task with the same idempotency key already exists")
+
+// ErrStatusConflict is returned by task state transitions when the task is no
+// longer in the expected prior status, e.g. because a concurrent worker or the
+// lease reaper has already moved it on.
+var ErrStatusConflict = errors.New("task is not in the expected status")
+
+// ErrVersionConflict is returned by UpdateExecutor when the executor has been
+// changed since the version the update is based on.
+var ErrVersionConflict = errors.New("executor has been modified concurrently")
+
+// ErrInFlightLimit is returned by GetNextTask and GetNextTasks when the
+// executor already has max_in_flight tasks in progress.
+var ErrInFlightLimit = errors.New("executor has reached its max_in_flight limit")
+
+// ErrScheduleNotFound is returned by DeleteSchedule when there is no schedule
+// with the given name.
+var ErrScheduleNotFound = errors.New("schedule not found")
+
+// ErrWatchUnsupported is returned by WatchPendingTasks when the deployment
+// cannot report changes, e.g. a standalone MongoDB server.
+var ErrWatchUnsupported = errors.New("watching tasks is not supported by the deployment")
+
+/*
+TaskFilter selects the tasks returned by ListTasks. Zero-valued fields are not
+applied; Metadata matches tasks that have all of the given key/value pairs.
+*/
+type TaskFilter struct {
+	ExecutorName  string
+	Statuses      []models.TaskStatus
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	UpdatedAfter  time.Time
+	UpdatedBefore time.Time
+	Metadata      map[string]string
+	ErrorContains string // Case-insensitive substring of the task error
+	IDs           []primitive.ObjectID
+	DLQName       string // Dead Letter Queue name, only applies to DLQ queries
+	ErrorGroup    string // Normalized error, only applies to DLQ queries
+}
+
+/*
+TaskCompletion is the outcome of a successfully processed task reported by CompleteTasks.
+*/
+type TaskCompletion struct {
+	ID      string
+	LeaseID string // Lease the task must still be held under, not checked if empty
+	Result  []byte
+}
+
+/*
+TaskCursor is the position of the last task of a page returned by ListTasks.
+The next page starts right after it.
+*/
+type TaskCursor struct {
+	CreatedAt time.Time
+	ID        primitive.ObjectID
+}
+
 /*
 Storage defines the interface for persistent storage operations in the task execution system.
 This interface provides methods for managing both executors and tasks, including their lifecycle
 and state transitions. Implementations of this interface should ensure thread safety and
-proper handling of concurrent operations.
+proper handling of concurrent operations: task state transitions are
+compare-and-set on the prior status, and transitions touching several
+collections (MoveToDLQ, RedriveTask) must not leave them inconsistent.
 
-The interface is divided into two main sections:
+The interface is divided into four main sections:
 1. Executor operations - for managing executor configurations
 2. Task operations - for managing task lifecycle and state
+3. Schedule operations - for managing recurring task submissions
+4. Worker operations - for tracking the worker processes of executors
 */
 type Storage interface {
 	// Executor operations
 	/*
-		CreateExecutor adds a new executor configuration to the storage.
-		Returns an error if an executor with the same name already exists.
+		CreateExecutor adds a new executor configuration to the storage, starting
+		at version 1. Returns an error if an executor with the same name already exists.
+		A deleted executor with the same name is purged and can no longer be restored.
 	*/
 	CreateExecutor(ctx context.Context, config *models.ExecutorConfig) error
 
 	/*
-		UpdateExecutor modifies an existing executor configuration.
-		Returns an error if the executor doesn't exist.
+		UpdateExecutor sets the given fields of an existing executor configuration
+		to their values in config and increments its version. Fields are BSON
+		paths such as "retry_policy.max_attempts". The update only applies if the
+		stored version is still config.Version, ErrVersionConflict is returned
+		otherwise. Returns the updated executor, nil if it doesn't exist.
 	*/
-	UpdateExecutor(ctx context.Context, config *models.ExecutorConfig) error
+	UpdateExecutor(ctx context.Context, config *models.ExecutorConfig, fields []string) (*models.ExecutorConfig, error)
 
 	/*
 		GetExecutor retrieves an executor configuration by its name.
 		Returns nil and an error if the executor doesn't exist.
+		Deleted executors are not returned.
 	*/
 	GetExecutor(ctx context.Context, name string) (*models.ExecutorConfig, error)
 
 	/*
 		ListExecutors returns all registered executor configurations.
 		Returns an empty slice if no executors are found.
+		Deleted executors are not returned.
 	*/
 	ListExecutors(ctx context.Context) ([]*models.ExecutorConfig, error)
 
 	/*
-		DeleteExecutor removes an executor configuration by its name.
-		Returns an error if the executor doesn't exist.
+		DeleteExecutor marks an executor configuration as deleted. The
+		configuration is kept until PurgeDeletedExecutors and can be restored
+		with UndeleteExecutor. Returns the executor, nil if it doesn't exist;
+		an executor that is already deleted is returned unchanged.
+	*/
+	DeleteExecutor(ctx context.Context, name string) (*models.ExecutorConfig, error)
+
+	/*
+		UndeleteExecutor restores an executor deleted after deletedAfter.
+		Returns nil if there is no such executor.
+	*/
+	UndeleteExecutor(ctx context.Context, name string, deletedAfter time.Time) (*models.ExecutorConfig, error)
+
+	/*
+		ListDeletedExecutors returns the deleted executors that have not been purged yet.
+	*/
+	ListDeletedExecutors(ctx context.Context) ([]*models.ExecutorConfig, error)
+
+	/*
+		PurgeDeletedExecutors removes the configurations of executors deleted
+		before deletedBefore. Returns the number of executors removed.
+	*/
+	PurgeDeletedExecutors(ctx context.Context, deletedBefore time.Time) (int64, error)
+
+	/*
+		SetExecutorState changes the state of an executor and keeps its Enabled
+		flag in line. Returns nil if the executor does not exist.
+	*/
+	SetExecutorState(ctx context.Context, name string, state models.ExecutorState) (*models.ExecutorConfig, error)
+
+	/*
+		AddExecutorRevision appends a revision to the change history of an
+		executor and sets its ID. Revisions are never modified or removed.
+	*/
+	AddExecutorRevision(ctx context.Context, revision *models.ExecutorRevision) error
+
+	/*
+		ListExecutorRevisions returns up to limit revisions of an executor,
+		newest first, starting after the revision with the ID before if it is
+		not zero.
+	*/
+	ListExecutorRevisions(ctx context.Context, executorName string, before primitive.ObjectID, limit int) ([]*models.ExecutorRevision, error)
+
+	/*
+		GetExecutorRevision retrieves a revision by its ID.
+		Returns nil if the revision doesn't exist.
+	*/
+	GetExecutorRevision(ctx context.Context, id primitive.ObjectID) (*models.ExecutorRevision, error)
+
+	/*
+		TakeRateTokens refills the rate limit bucket of an executor for the time
+		passed since the last call and takes up to n tokens from it, atomically
+		across all callers. The time is taken from the database, so manager
+		replicas with skewed clocks share the bucket fairly. Returns the number
+		of tokens taken and, if none were, how long until the next token.
+	*/
+	TakeRateTokens(ctx context.Context, executorName string, limit models.RateLimit, n int) (int, time.Duration, error)
+
+	/*
+		ReturnRateTokens puts n unused tokens taken with TakeRateTokens back
+		into the bucket.
 	*/
-	DeleteExecutor(ctx context.Context, name string) error
+	ReturnRateTokens(ctx context.Context, executorName string, limit models.RateLimit, n int) error
 
 	// Task operations
 	/*
 		AddTask creates a new task in the storage.
-		The task will be in PENDING state initially.
+		The task will be in PENDING state initially. If NextAttemptAt is set,
+		the task is not dispatched before that time.
+		Returns ErrDuplicateTask if the task has an idempotency key that is
+		already taken by another task of the same executor.
 	*/
 	AddTask(ctx context.Context, task *models.Task) error
 
+	/*
+		GetTaskByIdempotencyKey retrieves the task of an executor holding the given idempotency key.
+		Returns nil if there is no such task.
+	*/
+	GetTaskByIdempotencyKey(ctx context.Context, executorName, key string) (*models.Task, error)
+
+	/*
+		ReleaseIdempotencyKey removes the idempotency key from a task once its
+		dedup window is over, so the key can be used for a new submission.
+	*/
+	ReleaseIdempotencyKey(ctx context.Context, id primitive.ObjectID) error
+
 	/*
 		GetTask retrieves a task by its ID.
 		Returns nil and an error if the task doesn't exist.
@@ -62,35 +210,308 @@ type Storage interface {
 	GetTask(ctx context.Context, id string) (*models.Task, error)
 
 	/*
-		UpdateTaskStatus changes the status of a task and optionally sets an error message.
+		CancelExecutorTasks cancels all pending and DLQ tasks of an executor with
+		reason as their error and removes its tasks from the DLQ. The cancellation
+		of its in-progress tasks is requested, as with CancelTask, so that their
+		workers stop them. Returns the number of tasks affected.
+	*/
+	CancelExecutorTasks(ctx context.Context, executorName, reason string) (int64, error)
+
+	/*
+		MoveExecutorTasks reassigns all pending and DLQ tasks of an executor to
+		another one; DLQ tasks move to the target's queue toDLQ. Their idempotency
+		keys are dropped, as they may clash with the keys of the target. Tasks in
+		progress stay with their workers, and their cancellation is requested with
+		reason as the error. Returns the number of tasks moved or cancelled.
+	*/
+	MoveExecutorTasks(ctx context.Context, from, to, toDLQ, reason string) (int64, error)
+
+	/*
+		ArchiveExecutorTasks moves all pending, in-progress and DLQ tasks of an
+		executor to the archive collection. Returns the number of tasks archived.
+	*/
+	ArchiveExecutorTasks(ctx context.Context, executorName string) (int64, error)
+
+	/*
+		CountUnfinishedTasks returns the number of pending and in-progress tasks
+		of an executor.
+	*/
+	CountUnfinishedTasks(ctx context.Context, executorName string) (int64, error)
+
+	/*
+		ListTasks returns up to limit tasks matching filter, newest first
+		(by creation time, then by ID). If after is not nil, only tasks
+		following that position are returned.
+	*/
+	ListTasks(ctx context.Context, filter TaskFilter, after *TaskCursor, limit int) ([]*models.Task, error)
+
+	/*
+		UpdateTaskStatus changes the status of a task from the from status and optionally sets an error message.
 		This method should also update the task's timestamps based on the new status.
+		If leaseID is not empty, the task must also still be held under that lease.
+		Returns ErrStatusConflict if the task is not in the from status or has been leased again.
+	*/
+	UpdateTaskStatus(ctx context.Context, id, leaseID string, from, status models.TaskStatus, error string) error
+
+	/*
+		CompleteTask marks a task as COMPLETED and stores the result returned by its worker.
+		If leaseID is not empty, the task must also still be held under that lease.
+		Returns ErrStatusConflict if the task is not in the from status or has been leased again.
+	*/
+	CompleteTask(ctx context.Context, id, leaseID string, from models.TaskStatus, result []byte) error
+
+	/*
+		CompleteTasks completes several tasks like CompleteTask in one round trip.
+		The returned slice holds the outcome of every completion in order: nil, or
+		ErrStatusConflict if that task was not in the from status or under its lease.
+	*/
+	CompleteTasks(ctx context.Context, from models.TaskStatus, completions []TaskCompletion) ([]error, error)
+
+	/*
+		CancelTask cancels a task. A PENDING task becomes CANCELLED right away,
+		an IN_PROGRESS task is flagged with CancelRequested so that its worker
+		stops it. Tasks in other states are left unchanged.
+		Returns the resulting task, or nil if the task doesn't exist.
 	*/
-	UpdateTaskStatus(ctx context.Context, id string, status models.TaskStatus, error string) error
+	CancelTask(ctx context.Context, id string, reason string) (*models.Task, error)
+
+	/*
+		RetryTask returns a failed task to PENDING state for another attempt.
+		The task's retry count, error and next attempt time are persisted as given,
+		so the task is not dispatched again before NextAttemptAt.
+		If task.LeaseID is not empty, the task must also still be held under that lease.
+		Returns ErrStatusConflict if the task is not in the from status or has been leased again.
+	*/
+	RetryTask(ctx context.Context, task *models.Task, from models.TaskStatus) error
+
+	/*
+		ReleaseTask returns an IN_PROGRESS task held under leaseID to PENDING
+		without counting a failed attempt, so that it can be dispatched right away.
+		Returns ErrStatusConflict if the task is not in progress or has been leased again.
+	*/
+	ReleaseTask(ctx context.Context, id, leaseID string) error
 
 	/*
 		GetNextTask retrieves the next available task for an executor.
-		The task should be in PENDING state and not assigned to any other executor.
+		The task should be in PENDING state, not assigned to any other executor
+		and due for dispatch (its next attempt time, if any, has passed).
+		Tasks are dispatched by priority (highest first) and then by creation time.
+		The task is leased to the caller until now + leaseDuration; once the lease
+		expires the task may be reclaimed (see GetExpiredTasks). Every lease gets
+		a new LeaseID, which the worker presents to extend the lease and to report
+		the outcome, so a worker whose task was reclaimed and leased again cannot
+		act on the new attempt.
+		With maxInFlight > 0 the task takes one of maxInFlight slots of the
+		executor, atomically across all callers, and frees it by leaving the
+		IN_PROGRESS status; ErrInFlightLimit is returned if no slot is free.
 		Returns nil if no tasks are available.
 	*/
-	GetNextTask(ctx context.Context, executorName string) (*models.Task, error)
+	GetNextTask(ctx context.Context, executorName string, leaseDuration time.Duration, maxInFlight int) (*models.Task, error)
+
+	/*
+		GetNextTasks leases up to limit tasks for an executor at once, choosing them
+		like GetNextTask. Every task is leased to one caller only; under contention
+		fewer than limit tasks may be returned even if more are pending.
+		With maxInFlight > 0 no more tasks are leased than there are free slots.
+		Returns an empty slice if no tasks are available.
+	*/
+	GetNextTasks(ctx context.Context, executorName string, leaseDuration time.Duration, limit, maxInFlight int) ([]*models.Task, error)
+
+	/*
+		WatchPendingTasks calls fn with the executor name whenever a task is added
+		in or returned to the PENDING state, by any client of the storage.
+		It blocks until ctx is cancelled or watching fails.
+		Returns ErrWatchUnsupported if the deployment cannot report changes.
+	*/
+	WatchPendingTasks(ctx context.Context, fn func(executorName string)) error
+
+	/*
+		GetExpiredTasks retrieves IN_PROGRESS tasks whose lease has expired.
+		Such tasks are typically orphaned by a crashed worker and have to be
+		returned to the queue.
+		Returns an empty slice if no such tasks are found.
+	*/
+	GetExpiredTasks(ctx context.Context) ([]*models.Task, error)
 
 	/*
-		MoveToDLQ moves a failed task to the Dead Letter Queue.
+		ExtendLease moves the lease leaseID of an IN_PROGRESS task forward to
+		leaseExpiresAt and stores the progress report if one is given.
+		Returns nil if the task doesn't exist, is no longer in progress or has
+		been leased again.
+	*/
+	ExtendLease(ctx context.Context, id, leaseID string, leaseExpiresAt time.Time, progress *models.TaskProgress) (*models.Task, error)
+
+	/*
+		AgeTasks raises the priority of due PENDING tasks of an executor that have
+		waited for at least aging.Threshold since creation or since the previous boost.
+		Returns the number of boosted tasks.
+	*/
+	AgeTasks(ctx context.Context, executorName string, aging models.PriorityAging) (int64, error)
+
+	/*
+		MoveToDLQ moves a failed task to the Dead Letter Queue: the task is marked
+		DLQ and copied to the queue atomically.
 		This is typically called when a task has exceeded its retry attempts.
+		If task.LeaseID is not empty, the task must also still be held under that lease.
+		Returns ErrStatusConflict if the task is not in the from status or has been leased again.
 	*/
-	MoveToDLQ(ctx context.Context, task *models.Task) error
+	MoveToDLQ(ctx context.Context, task *models.Task, from models.TaskStatus) error
 
 	/*
-		GetDLQTasks retrieves all tasks in the Dead Letter Queue for an executor.
+		ListDLQTasks returns up to limit tasks of the Dead Letter Queue matching filter,
+		in the same order and with the same paging as ListTasks. UpdatedAt of a DLQ
+		task is the time it was moved to the DLQ.
 		Returns an empty slice if no tasks are in the DLQ.
 	*/
-	GetDLQTasks(ctx context.Context, executorName string) ([]*models.Task, error)
+	ListDLQTasks(ctx context.Context, filter TaskFilter, after *TaskCursor, limit int) ([]*models.Task, error)
 
 	/*
-		ClearDLQ removes all tasks from the Dead Letter Queue for an executor.
+		PurgeDLQ removes the tasks matching filter from the Dead Letter Queue
+		and returns the number of removed tasks.
 		This operation cannot be undone.
 	*/
-	ClearDLQ(ctx context.Context, executorName string) error
+	PurgeDLQ(ctx context.Context, filter TaskFilter) (int64, error)
+
+	/*
+		GetDLQStats returns the number of DLQ tasks of an executor and the time
+		the oldest of them was moved to the DLQ (zero if there are none).
+	*/
+	GetDLQStats(ctx context.Context, executorName string) (int64, time.Time, error)
+
+	/*
+		OldestDLQTasks returns up to limit DLQ tasks of an executor in the order they
+		were moved to the DLQ, oldest first. If movedBefore is not zero, only tasks
+		moved before that time are returned.
+	*/
+	OldestDLQTasks(ctx context.Context, executorName string, movedBefore time.Time, limit int) ([]*models.Task, error)
+
+	/*
+		ClaimDLQTasks marks the DLQ tasks with the given IDs as being evicted under
+		claim, so that other manager replicas skip them, and returns the tasks
+		claimed. A claim made before abandonedBefore is taken over, as its owner
+		is assumed to have crashed. Claimed tasks are removed with DeleteClaimedDLQTasks.
+	*/
+	ClaimDLQTasks(ctx context.Context, ids []primitive.ObjectID, claim string, abandonedBefore time.Time) ([]*models.Task, error)
+
+	/*
+		DeleteClaimedDLQTasks removes the DLQ tasks claimed under claim.
+		Returns the number of tasks removed.
+	*/
+	DeleteClaimedDLQTasks(ctx context.Context, claim string) (int64, error)
+
+	/*
+		DLQErrorGroups groups the DLQ tasks matching filter by executor and normalized
+		error, largest groups first. Each group carries up to samples task IDs.
+		Tasks dead-lettered before errors were normalized are grouped by their raw error.
+	*/
+	DLQErrorGroups(ctx context.Context, filter TaskFilter, samples int) ([]*models.DLQErrorGroup, error)
+
+	/*
+		ListDLQs returns the non-empty Dead Letter Queues ordered by name.
+		Tasks dead-lettered before queues were named belong to the queue named
+		after their executor.
+	*/
+	ListDLQs(ctx context.Context) ([]*models.DLQInfo, error)
+
+	/*
+		GetDLQ returns a Dead Letter Queue by its name.
+		Returns nil if the queue is empty or doesn't exist.
+	*/
+	GetDLQ(ctx context.Context, name string) (*models.DLQInfo, error)
+
+	/*
+		RedriveTask returns a task from the Dead Letter Queue to the live queue.
+		The task is stored in the tasks collection exactly as given (the caller
+		resets its state and appends the history entry) and removed from the DLQ
+		atomically.
+		Returns ErrStatusConflict if the task has left the DLQ status in the meantime
+		and ErrDuplicateTask if the executor already has a task with its idempotency key.
+	*/
+	RedriveTask(ctx context.Context, task *models.Task) error
+
+	/*
+		ReplayPendingOps completes cross-collection transitions interrupted by a
+		crash on deployments without multi-document transactions. Only operations
+		started before olderThan are replayed, so in-flight ones are left alone.
+		Returns the number of replayed operations.
+	*/
+	ReplayPendingOps(ctx context.Context, olderThan time.Time) (int, error)
+
+	// Schedule operations
+	/*
+		CreateSchedule adds a new schedule to the storage.
+		Returns an error if a schedule with the same name already exists.
+	*/
+	CreateSchedule(ctx context.Context, schedule *models.Schedule) error
+
+	/*
+		GetSchedule retrieves a schedule by its name.
+		Returns nil if the schedule doesn't exist.
+	*/
+	GetSchedule(ctx context.Context, name string) (*models.Schedule, error)
+
+	/*
+		ListSchedules returns the schedules of an executor, or all schedules
+		if executorName is empty.
+	*/
+	ListSchedules(ctx context.Context, executorName string) ([]*models.Schedule, error)
+
+	/*
+		DeleteSchedule removes a schedule by its name.
+		Returns ErrScheduleNotFound if the schedule doesn't exist.
+	*/
+	DeleteSchedule(ctx context.Context, name string) error
+
+	/*
+		SetSchedulePaused pauses or resumes a schedule. nextRunAt replaces the
+		stored next tick so that a resumed schedule does not fire for the ticks
+		missed while it was paused.
+		Returns nil if the schedule doesn't exist.
+	*/
+	SetSchedulePaused(ctx context.Context, name string, paused bool, nextRunAt time.Time) (*models.Schedule, error)
+
+	/*
+		GetDueSchedules returns active schedules whose next tick is not after now.
+	*/
+	GetDueSchedules(ctx context.Context, now time.Time) ([]*models.Schedule, error)
+
+	/*
+		ClaimScheduleRun atomically moves a schedule from the tick scheduledAt to
+		nextRunAt. Only one caller can claim a given tick: it returns false if the
+		schedule has already been moved on, paused or deleted.
+	*/
+	ClaimScheduleRun(ctx context.Context, id primitive.ObjectID, scheduledAt, nextRunAt time.Time) (bool, error)
+
+	/*
+		SetScheduleLastTask records the task created by the last fired tick.
+	*/
+	SetScheduleLastTask(ctx context.Context, id primitive.ObjectID, taskID string) error
+
+	// Worker operations
+	/*
+		RegisterWorker records a worker as alive, replacing an earlier registration
+		with the same ID.
+	*/
+	RegisterWorker(ctx context.Context, worker *models.Worker) error
+
+	/*
+		HeartbeatWorker records a heartbeat of an alive worker.
+		Returns false if the worker is not registered or has been marked dead.
+	*/
+	HeartbeatWorker(ctx context.Context, id string, activeTasks int, at time.Time) (bool, error)
+
+	/*
+		ListWorkers returns the workers of an executor, or of all executors if
+		executorName is empty, ordered by executor name and hostname.
+		Dead workers are only returned if includeDead is set.
+	*/
+	ListWorkers(ctx context.Context, executorName string, includeDead bool) ([]*models.Worker, error)
+
+	/*
+		MarkDeadWorkers marks alive workers whose last heartbeat is before
+		heartbeatBefore as dead. Returns the number of workers marked.
+	*/
+	MarkDeadWorkers(ctx context.Context, heartbeatBefore time.Time) (int64, error)
 }
 
 /*
@@ -104,4 +525,8 @@ type StorageConfig struct {
 	ExecutorsColl string // Collection name for executor configurations
 	TasksColl     string // Collection name for tasks
 	DLQColl       string // Collection name for dead letter queue
+	SchedulesColl string // Collection name for schedules
+	WorkersColl   string // Collection name for registered workers
+	ArchiveColl   string // Collection name for tasks archived with their deleted executor
+	RevisionsColl string // Collection name for the change history of executors
 }
@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -23,6 +24,241 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type WorkerStatus int32
+
+const (
+	WorkerStatus_WORKER_STATUS_UNSPECIFIED WorkerStatus = 0
+	WorkerStatus_WORKER_STATUS_ALIVE       WorkerStatus = 1
+	WorkerStatus_WORKER_STATUS_DEAD        WorkerStatus = 2
+)
+
+// Enum value maps for WorkerStatus.
+var (
+	WorkerStatus_name = map[int32]string{
+		0: "WORKER_STATUS_UNSPECIFIED",
+		1: "WORKER_STATUS_ALIVE",
+		2: "WORKER_STATUS_DEAD",
+	}
+	WorkerStatus_value = map[string]int32{
+		"WORKER_STATUS_UNSPECIFIED": 0,
+		"WORKER_STATUS_ALIVE":       1,
+		"WORKER_STATUS_DEAD":        2,
+	}
+)
+
+func (x WorkerStatus) Enum() *WorkerStatus {
+	p := new(WorkerStatus)
+	*p = x
+	return p
+}
+
+func (x WorkerStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkerStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_task_executor_proto_enumTypes[0].Descriptor()
+}
+
+func (WorkerStatus) Type() protoreflect.EnumType {
+	return &file_proto_task_executor_proto_enumTypes[0]
+}
+
+func (x WorkerStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkerStatus.Descriptor instead.
+func (WorkerStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{0}
+}
+
+// Что делать с ожидающими, выполняемыми задачами и задачами DLQ обработчика
+type ExecutorDeletionPolicy int32
+
+const (
+	// То же, что EXECUTOR_DELETION_POLICY_REFUSE
+	ExecutorDeletionPolicy_EXECUTOR_DELETION_POLICY_UNSPECIFIED ExecutorDeletionPolicy = 0
+	// Не удалять, если такие задачи есть (FAILED_PRECONDITION)
+	ExecutorDeletionPolicy_EXECUTOR_DELETION_POLICY_REFUSE ExecutorDeletionPolicy = 1
+	// Отменить ожидающие задачи, удалить задачи из DLQ. Выполняемым задачам
+	// запрашивается отмена, как в CancelTask: обработчик узнаёт о ней из
+	// heartbeat, отчёт о задаче принимается и после удаления обработчика
+	ExecutorDeletionPolicy_EXECUTOR_DELETION_POLICY_CANCEL ExecutorDeletionPolicy = 2
+	// Передать ожидающие задачи и задачи DLQ обработчику target_executor; задачи
+	// DLQ переходят в его очередь DLQ. Ключи идемпотентности передаваемых задач
+	// сбрасываются. Выполняемым задачам запрашивается отмена, как при CANCEL
+	ExecutorDeletionPolicy_EXECUTOR_DELETION_POLICY_MOVE ExecutorDeletionPolicy = 3
+	// Перенести задачи в коллекцию архива
+	ExecutorDeletionPolicy_EXECUTOR_DELETION_POLICY_ARCHIVE ExecutorDeletionPolicy = 4
+)
+
+// Enum value maps for ExecutorDeletionPolicy.
+var (
+	ExecutorDeletionPolicy_name = map[int32]string{
+		0: "EXECUTOR_DELETION_POLICY_UNSPECIFIED",
+		1: "EXECUTOR_DELETION_POLICY_REFUSE",
+		2: "EXECUTOR_DELETION_POLICY_CANCEL",
+		3: "EXECUTOR_DELETION_POLICY_MOVE",
+		4: "EXECUTOR_DELETION_POLICY_ARCHIVE",
+	}
+	ExecutorDeletionPolicy_value = map[string]int32{
+		"EXECUTOR_DELETION_POLICY_UNSPECIFIED": 0,
+		"EXECUTOR_DELETION_POLICY_REFUSE":      1,
+		"EXECUTOR_DELETION_POLICY_CANCEL":      2,
+		"EXECUTOR_DELETION_POLICY_MOVE":        3,
+		"EXECUTOR_DELETION_POLICY_ARCHIVE":     4,
+	}
+)
+
+func (x ExecutorDeletionPolicy) Enum() *ExecutorDeletionPolicy {
+	p := new(ExecutorDeletionPolicy)
+	*p = x
+	return p
+}
+
+func (x ExecutorDeletionPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExecutorDeletionPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_task_executor_proto_enumTypes[1].Descriptor()
+}
+
+func (ExecutorDeletionPolicy) Type() protoreflect.EnumType {
+	return &file_proto_task_executor_proto_enumTypes[1]
+}
+
+func (x ExecutorDeletionPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExecutorDeletionPolicy.Descriptor instead.
+func (ExecutorDeletionPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{1}
+}
+
+type ExecutorRevisionAction int32
+
+const (
+	ExecutorRevisionAction_EXECUTOR_REVISION_ACTION_UNSPECIFIED ExecutorRevisionAction = 0
+	ExecutorRevisionAction_EXECUTOR_REVISION_ACTION_CREATED     ExecutorRevisionAction = 1
+	ExecutorRevisionAction_EXECUTOR_REVISION_ACTION_UPDATED     ExecutorRevisionAction = 2
+	// Pause/Resume/Drain/DisableExecutor
+	ExecutorRevisionAction_EXECUTOR_REVISION_ACTION_STATE_CHANGED ExecutorRevisionAction = 3
+	ExecutorRevisionAction_EXECUTOR_REVISION_ACTION_DELETED       ExecutorRevisionAction = 4
+	ExecutorRevisionAction_EXECUTOR_REVISION_ACTION_UNDELETED     ExecutorRevisionAction = 5
+	ExecutorRevisionAction_EXECUTOR_REVISION_ACTION_ROLLED_BACK   ExecutorRevisionAction = 6
+)
+
+// Enum value maps for ExecutorRevisionAction.
+var (
+	ExecutorRevisionAction_name = map[int32]string{
+		0: "EXECUTOR_REVISION_ACTION_UNSPECIFIED",
+		1: "EXECUTOR_REVISION_ACTION_CREATED",
+		2: "EXECUTOR_REVISION_ACTION_UPDATED",
+		3: "EXECUTOR_REVISION_ACTION_STATE_CHANGED",
+		4: "EXECUTOR_REVISION_ACTION_DELETED",
+		5: "EXECUTOR_REVISION_ACTION_UNDELETED",
+		6: "EXECUTOR_REVISION_ACTION_ROLLED_BACK",
+	}
+	ExecutorRevisionAction_value = map[string]int32{
+		"EXECUTOR_REVISION_ACTION_UNSPECIFIED":   0,
+		"EXECUTOR_REVISION_ACTION_CREATED":       1,
+		"EXECUTOR_REVISION_ACTION_UPDATED":       2,
+		"EXECUTOR_REVISION_ACTION_STATE_CHANGED": 3,
+		"EXECUTOR_REVISION_ACTION_DELETED":       4,
+		"EXECUTOR_REVISION_ACTION_UNDELETED":     5,
+		"EXECUTOR_REVISION_ACTION_ROLLED_BACK":   6,
+	}
+)
+
+func (x ExecutorRevisionAction) Enum() *ExecutorRevisionAction {
+	p := new(ExecutorRevisionAction)
+	*p = x
+	return p
+}
+
+func (x ExecutorRevisionAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExecutorRevisionAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_task_executor_proto_enumTypes[2].Descriptor()
+}
+
+func (ExecutorRevisionAction) Type() protoreflect.EnumType {
+	return &file_proto_task_executor_proto_enumTypes[2]
+}
+
+func (x ExecutorRevisionAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExecutorRevisionAction.Descriptor instead.
+func (ExecutorRevisionAction) EnumDescriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{2}
+}
+
+type ExecutorState int32
+
+const (
+	ExecutorState_EXECUTOR_STATE_UNSPECIFIED ExecutorState = 0
+	// Принимает и выдаёт задачи
+	ExecutorState_EXECUTOR_STATE_ACTIVE ExecutorState = 1
+	// Принимает задачи, но не выдаёт их
+	ExecutorState_EXECUTOR_STATE_PAUSED ExecutorState = 2
+	// Не принимает новые задачи, выдаёт оставшиеся
+	ExecutorState_EXECUTOR_STATE_DRAINING ExecutorState = 3
+	// Не принимает и не выдаёт задачи
+	ExecutorState_EXECUTOR_STATE_DISABLED ExecutorState = 4
+)
+
+// Enum value maps for ExecutorState.
+var (
+	ExecutorState_name = map[int32]string{
+		0: "EXECUTOR_STATE_UNSPECIFIED",
+		1: "EXECUTOR_STATE_ACTIVE",
+		2: "EXECUTOR_STATE_PAUSED",
+		3: "EXECUTOR_STATE_DRAINING",
+		4: "EXECUTOR_STATE_DISABLED",
+	}
+	ExecutorState_value = map[string]int32{
+		"EXECUTOR_STATE_UNSPECIFIED": 0,
+		"EXECUTOR_STATE_ACTIVE":      1,
+		"EXECUTOR_STATE_PAUSED":      2,
+		"EXECUTOR_STATE_DRAINING":    3,
+		"EXECUTOR_STATE_DISABLED":    4,
+	}
+)
+
+func (x ExecutorState) Enum() *ExecutorState {
+	p := new(ExecutorState)
+	*p = x
+	return p
+}
+
+func (x ExecutorState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExecutorState) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_task_executor_proto_enumTypes[3].Descriptor()
+}
+
+func (ExecutorState) Type() protoreflect.EnumType {
+	return &file_proto_task_executor_proto_enumTypes[3]
+}
+
+func (x ExecutorState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExecutorState.Descriptor instead.
+func (ExecutorState) EnumDescriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{3}
+}
+
 type WriteConcernLevel int32
 
 const (
@@ -62,11 +298,11 @@ func (x WriteConcernLevel) String() string {
 }
 
 func (WriteConcernLevel) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_task_executor_proto_enumTypes[0].Descriptor()
+	return file_proto_task_executor_proto_enumTypes[4].Descriptor()
 }
 
 func (WriteConcernLevel) Type() protoreflect.EnumType {
-	return &file_proto_task_executor_proto_enumTypes[0]
+	return &file_proto_task_executor_proto_enumTypes[4]
 }
 
 func (x WriteConcernLevel) Number() protoreflect.EnumNumber {
@@ -75,7 +311,7 @@ func (x WriteConcernLevel) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use WriteConcernLevel.Descriptor instead.
 func (WriteConcernLevel) EnumDescriptor() ([]byte, []int) {
-	return file_proto_task_executor_proto_rawDescGZIP(), []int{0}
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{4}
 }
 
 type RetryPolicyType int32
@@ -114,11 +350,11 @@ func (x RetryPolicyType) String() string {
 }
 
 func (RetryPolicyType) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_task_executor_proto_enumTypes[1].Descriptor()
+	return file_proto_task_executor_proto_enumTypes[5].Descriptor()
 }
 
 func (RetryPolicyType) Type() protoreflect.EnumType {
-	return &file_proto_task_executor_proto_enumTypes[1]
+	return &file_proto_task_executor_proto_enumTypes[5]
 }
 
 func (x RetryPolicyType) Number() protoreflect.EnumNumber {
@@ -127,7 +363,7 @@ func (x RetryPolicyType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use RetryPolicyType.Descriptor instead.
 func (RetryPolicyType) EnumDescriptor() ([]byte, []int) {
-	return file_proto_task_executor_proto_rawDescGZIP(), []int{1}
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{5}
 }
 
 type TaskStatus int32
@@ -139,6 +375,7 @@ const (
 	TaskStatus_TASK_STATUS_COMPLETED   TaskStatus = 3
 	TaskStatus_TASK_STATUS_FAILED      TaskStatus = 4
 	TaskStatus_TASK_STATUS_DLQ         TaskStatus = 5
+	TaskStatus_TASK_STATUS_CANCELLED   TaskStatus = 6
 )
 
 // Enum value maps for TaskStatus.
@@ -150,6 +387,7 @@ var (
 		3: "TASK_STATUS_COMPLETED",
 		4: "TASK_STATUS_FAILED",
 		5: "TASK_STATUS_DLQ",
+		6: "TASK_STATUS_CANCELLED",
 	}
 	TaskStatus_value = map[string]int32{
 		"TASK_STATUS_UNSPECIFIED": 0,
@@ -158,6 +396,7 @@ var (
 		"TASK_STATUS_COMPLETED":   3,
 		"TASK_STATUS_FAILED":      4,
 		"TASK_STATUS_DLQ":         5,
+		"TASK_STATUS_CANCELLED":   6,
 	}
 )
 
@@ -172,11 +411,11 @@ func (x TaskStatus) String() string {
 }
 
 func (TaskStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_task_executor_proto_enumTypes[2].Descriptor()
+	return file_proto_task_executor_proto_enumTypes[6].Descriptor()
 }
 
 func (TaskStatus) Type() protoreflect.EnumType {
-	return &file_proto_task_executor_proto_enumTypes[2]
+	return &file_proto_task_executor_proto_enumTypes[6]
 }
 
 func (x TaskStatus) Number() protoreflect.EnumNumber {
@@ -185,17 +424,26 @@ func (x TaskStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use TaskStatus.Descriptor instead.
 func (TaskStatus) EnumDescriptor() ([]byte, []int) {
-	return file_proto_task_executor_proto_rawDescGZIP(), []int{2}
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{6}
 }
 
 // Task Management Messages
 type AddTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ExecutorName  string                 `protobuf:"bytes,1,opt,name=executor_name,json=executorName,proto3" json:"executor_name,omitempty"`
-	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	Metadata      map[string]string      `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ExecutorName string                 `protobuf:"bytes,1,opt,name=executor_name,json=executorName,proto3" json:"executor_name,omitempty"`
+	Data         []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Metadata     map[string]string      `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Задача не будет выдана обработчику раньше run_at (или now + delay).
+	// Указывается не более одного из полей.
+	RunAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=run_at,json=runAt,proto3" json:"run_at,omitempty"`
+	Delay *durationpb.Duration   `protobuf:"bytes,5,opt,name=delay,proto3" json:"delay,omitempty"`
+	// Задачи с большим приоритетом выдаются раньше, при равном — в порядке создания
+	Priority int32 `protobuf:"varint,6,opt,name=priority,proto3" json:"priority,omitempty"`
+	// Повторная отправка с тем же ключом в пределах окна дедупликации обработчика
+	// возвращает исходную задачу вместо создания новой
+	IdempotencyKey string `protobuf:"bytes,7,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *AddTaskRequest) Reset() {
@@ -249,9 +497,39 @@ func (x *AddTaskRequest) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *AddTaskRequest) GetRunAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RunAt
+	}
+	return nil
+}
+
+func (x *AddTaskRequest) GetDelay() *durationpb.Duration {
+	if x != nil {
+		return x.Delay
+	}
+	return nil
+}
+
+func (x *AddTaskRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *AddTaskRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
 type AddTaskResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Task  *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// true, если задача с этим idempotency_key уже существовала
+	Deduplicated  bool `protobuf:"varint,2,opt,name=deduplicated,proto3" json:"deduplicated,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -293,6 +571,13 @@ func (x *AddTaskResponse) GetTask() *Task {
 	return nil
 }
 
+func (x *AddTaskResponse) GetDeduplicated() bool {
+	if x != nil {
+		return x.Deduplicated
+	}
+	return false
+}
+
 type GetTaskStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -341,6 +626,7 @@ type GetTaskStatusResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Status        TaskStatus             `protobuf:"varint,1,opt,name=status,proto3,enum=taskexecutor.TaskStatus" json:"status,omitempty"`
 	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Result        []byte                 `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -389,29 +675,37 @@ func (x *GetTaskStatusResponse) GetError() string {
 	return ""
 }
 
-// Executor Management Messages
-type RegisterExecutorRequest struct {
+func (x *GetTaskStatusResponse) GetResult() []byte {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+// Ожидающая задача отменяется сразу, выполняющаяся помечается для отмены:
+// обработчик узнаёт об этом при следующем HeartbeatTask или UpdateTaskStatus
+type CancelTaskRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ExecutorName  string                 `protobuf:"bytes,1,opt,name=executor_name,json=executorName,proto3" json:"executor_name,omitempty"`
-	LeaderId      string                 `protobuf:"bytes,2,opt,name=leader_id,json=leaderId,proto3" json:"leader_id,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RegisterExecutorRequest) Reset() {
-	*x = RegisterExecutorRequest{}
+func (x *CancelTaskRequest) Reset() {
+	*x = CancelTaskRequest{}
 	mi := &file_proto_task_executor_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RegisterExecutorRequest) String() string {
+func (x *CancelTaskRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RegisterExecutorRequest) ProtoMessage() {}
+func (*CancelTaskRequest) ProtoMessage() {}
 
-func (x *RegisterExecutorRequest) ProtoReflect() protoreflect.Message {
+func (x *CancelTaskRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_executor_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -423,46 +717,46 @@ func (x *RegisterExecutorRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RegisterExecutorRequest.ProtoReflect.Descriptor instead.
-func (*RegisterExecutorRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CancelTaskRequest.ProtoReflect.Descriptor instead.
+func (*CancelTaskRequest) Descriptor() ([]byte, []int) {
 	return file_proto_task_executor_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *RegisterExecutorRequest) GetExecutorName() string {
+func (x *CancelTaskRequest) GetId() string {
 	if x != nil {
-		return x.ExecutorName
+		return x.Id
 	}
 	return ""
 }
 
-func (x *RegisterExecutorRequest) GetLeaderId() string {
+func (x *CancelTaskRequest) GetReason() string {
 	if x != nil {
-		return x.LeaderId
+		return x.Reason
 	}
 	return ""
 }
 
-type RegisterExecutorResponse struct {
+type CancelTaskResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RegisterExecutorResponse) Reset() {
-	*x = RegisterExecutorResponse{}
+func (x *CancelTaskResponse) Reset() {
+	*x = CancelTaskResponse{}
 	mi := &file_proto_task_executor_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RegisterExecutorResponse) String() string {
+func (x *CancelTaskResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RegisterExecutorResponse) ProtoMessage() {}
+func (*CancelTaskResponse) ProtoMessage() {}
 
-func (x *RegisterExecutorResponse) ProtoReflect() protoreflect.Message {
+func (x *CancelTaskResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_executor_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -474,39 +768,50 @@ func (x *RegisterExecutorResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RegisterExecutorResponse.ProtoReflect.Descriptor instead.
-func (*RegisterExecutorResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CancelTaskResponse.ProtoReflect.Descriptor instead.
+func (*CancelTaskResponse) Descriptor() ([]byte, []int) {
 	return file_proto_task_executor_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *RegisterExecutorResponse) GetSuccess() bool {
+func (x *CancelTaskResponse) GetTask() *Task {
 	if x != nil {
-		return x.Success
+		return x.Task
 	}
-	return false
+	return nil
 }
 
-type GetNextTaskRequest struct {
+// Список задач, от новых к старым. Пустые поля фильтра не применяются,
+// metadata отбирает задачи, у которых совпадают все указанные пары ключ-значение
+type ListTasksRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ExecutorName  string                 `protobuf:"bytes,1,opt,name=executor_name,json=executorName,proto3" json:"executor_name,omitempty"`
+	Statuses      []TaskStatus           `protobuf:"varint,2,rep,packed,name=statuses,proto3,enum=taskexecutor.TaskStatus" json:"statuses,omitempty"`
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	UpdatedAfter  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_after,json=updatedAfter,proto3" json:"updated_after,omitempty"`
+	UpdatedBefore *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_before,json=updatedBefore,proto3" json:"updated_before,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// По умолчанию 50, не более 500
+	PageSize      int32  `protobuf:"varint,8,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string `protobuf:"bytes,9,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetNextTaskRequest) Reset() {
-	*x = GetNextTaskRequest{}
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
 	mi := &file_proto_task_executor_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetNextTaskRequest) String() string {
+func (x *ListTasksRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetNextTaskRequest) ProtoMessage() {}
+func (*ListTasksRequest) ProtoMessage() {}
 
-func (x *GetNextTaskRequest) ProtoReflect() protoreflect.Message {
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_executor_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -518,39 +823,97 @@ func (x *GetNextTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetNextTaskRequest.ProtoReflect.Descriptor instead.
-func (*GetNextTaskRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
 	return file_proto_task_executor_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetNextTaskRequest) GetExecutorName() string {
+func (x *ListTasksRequest) GetExecutorName() string {
 	if x != nil {
 		return x.ExecutorName
 	}
 	return ""
 }
 
-type GetNextTaskResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+func (x *ListTasksRequest) GetStatuses() []TaskStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetUpdatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAfter
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetUpdatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedBefore
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListTasksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Tasks []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// Пустое значение — страниц больше нет
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetNextTaskResponse) Reset() {
-	*x = GetNextTaskResponse{}
+func (x *ListTasksResponse) Reset() {
+	*x = ListTasksResponse{}
 	mi := &file_proto_task_executor_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetNextTaskResponse) String() string {
+func (x *ListTasksResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetNextTaskResponse) ProtoMessage() {}
+func (*ListTasksResponse) ProtoMessage() {}
 
-func (x *GetNextTaskResponse) ProtoReflect() protoreflect.Message {
+func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_executor_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -562,41 +925,58 @@ func (x *GetNextTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetNextTaskResponse.ProtoReflect.Descriptor instead.
-func (*GetNextTaskResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListTasksResponse) Descriptor() ([]byte, []int) {
 	return file_proto_task_executor_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GetNextTaskResponse) GetTask() *Task {
+func (x *ListTasksResponse) GetTasks() []*Task {
 	if x != nil {
-		return x.Task
+		return x.Tasks
 	}
 	return nil
 }
 
-type UpdateTaskStatusRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Status        TaskStatus             `protobuf:"varint,2,opt,name=status,proto3,enum=taskexecutor.TaskStatus" json:"status,omitempty"`
-	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
-	unknownFields protoimpl.UnknownFields
+func (x *ListTasksResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Executor Management Messages
+// Регистрация процесса-обработчика. Если задан worker_id (или устаревший leader_id),
+// процесс записывается в реестр и должен присылать HeartbeatWorker не реже
+// heartbeat_interval, иначе по истечении WORKER_TIMEOUT считается мёртвым
+type RegisterExecutorRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ExecutorName string                 `protobuf:"bytes,1,opt,name=executor_name,json=executorName,proto3" json:"executor_name,omitempty"`
+	// Устарело, используйте worker_id
+	LeaderId     string   `protobuf:"bytes,2,opt,name=leader_id,json=leaderId,proto3" json:"leader_id,omitempty"`
+	WorkerId     string   `protobuf:"bytes,3,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	Hostname     string   `protobuf:"bytes,4,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Version      string   `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	Capabilities []string `protobuf:"bytes,6,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	// Сколько задач процесс обрабатывает одновременно
+	Concurrency   int32 `protobuf:"varint,7,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateTaskStatusRequest) Reset() {
-	*x = UpdateTaskStatusRequest{}
+func (x *RegisterExecutorRequest) Reset() {
+	*x = RegisterExecutorRequest{}
 	mi := &file_proto_task_executor_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateTaskStatusRequest) String() string {
+func (x *RegisterExecutorRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateTaskStatusRequest) ProtoMessage() {}
+func (*RegisterExecutorRequest) ProtoMessage() {}
 
-func (x *UpdateTaskStatusRequest) ProtoReflect() protoreflect.Message {
+func (x *RegisterExecutorRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_executor_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -608,53 +988,83 @@ func (x *UpdateTaskStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateTaskStatusRequest.ProtoReflect.Descriptor instead.
-func (*UpdateTaskStatusRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RegisterExecutorRequest.ProtoReflect.Descriptor instead.
+func (*RegisterExecutorRequest) Descriptor() ([]byte, []int) {
 	return file_proto_task_executor_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *UpdateTaskStatusRequest) GetId() string {
+func (x *RegisterExecutorRequest) GetExecutorName() string {
 	if x != nil {
-		return x.Id
+		return x.ExecutorName
 	}
 	return ""
 }
 
-func (x *UpdateTaskStatusRequest) GetStatus() TaskStatus {
+func (x *RegisterExecutorRequest) GetLeaderId() string {
 	if x != nil {
-		return x.Status
+		return x.LeaderId
 	}
-	return TaskStatus_TASK_STATUS_UNSPECIFIED
+	return ""
 }
 
-func (x *UpdateTaskStatusRequest) GetError() string {
+func (x *RegisterExecutorRequest) GetWorkerId() string {
 	if x != nil {
-		return x.Error
+		return x.WorkerId
 	}
 	return ""
 }
 
-type UpdateTaskStatusResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *RegisterExecutorRequest) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
 }
 
-func (x *UpdateTaskStatusResponse) Reset() {
-	*x = UpdateTaskStatusResponse{}
+func (x *RegisterExecutorRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *RegisterExecutorRequest) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+func (x *RegisterExecutorRequest) GetConcurrency() int32 {
+	if x != nil {
+		return x.Concurrency
+	}
+	return 0
+}
+
+type RegisterExecutorResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Success           bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	WorkerId          string                 `protobuf:"bytes,2,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	HeartbeatInterval *durationpb.Duration   `protobuf:"bytes,3,opt,name=heartbeat_interval,json=heartbeatInterval,proto3" json:"heartbeat_interval,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RegisterExecutorResponse) Reset() {
+	*x = RegisterExecutorResponse{}
 	mi := &file_proto_task_executor_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateTaskStatusResponse) String() string {
+func (x *RegisterExecutorResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateTaskStatusResponse) ProtoMessage() {}
+func (*RegisterExecutorResponse) ProtoMessage() {}
 
-func (x *UpdateTaskStatusResponse) ProtoReflect() protoreflect.Message {
+func (x *RegisterExecutorResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_executor_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -666,40 +1076,55 @@ func (x *UpdateTaskStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateTaskStatusResponse.ProtoReflect.Descriptor instead.
-func (*UpdateTaskStatusResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RegisterExecutorResponse.ProtoReflect.Descriptor instead.
+func (*RegisterExecutorResponse) Descriptor() ([]byte, []int) {
 	return file_proto_task_executor_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *UpdateTaskStatusResponse) GetTask() *Task {
+func (x *RegisterExecutorResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Task
+		return x.Success
+	}
+	return false
+}
+
+func (x *RegisterExecutorResponse) GetWorkerId() string {
+	if x != nil {
+		return x.WorkerId
+	}
+	return ""
+}
+
+func (x *RegisterExecutorResponse) GetHeartbeatInterval() *durationpb.Duration {
+	if x != nil {
+		return x.HeartbeatInterval
 	}
 	return nil
 }
 
-// Executor Configuration Messages
-type CreateExecutorRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Config        *ExecutorConfig        `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+type HeartbeatWorkerRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	WorkerId string                 `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	// Число задач, обрабатываемых в данный момент
+	ActiveTasks   int32 `protobuf:"varint,2,opt,name=active_tasks,json=activeTasks,proto3" json:"active_tasks,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateExecutorRequest) Reset() {
-	*x = CreateExecutorRequest{}
+func (x *HeartbeatWorkerRequest) Reset() {
+	*x = HeartbeatWorkerRequest{}
 	mi := &file_proto_task_executor_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateExecutorRequest) String() string {
+func (x *HeartbeatWorkerRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateExecutorRequest) ProtoMessage() {}
+func (*HeartbeatWorkerRequest) ProtoMessage() {}
 
-func (x *CreateExecutorRequest) ProtoReflect() protoreflect.Message {
+func (x *HeartbeatWorkerRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_executor_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -711,39 +1136,47 @@ func (x *CreateExecutorRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateExecutorRequest.ProtoReflect.Descriptor instead.
-func (*CreateExecutorRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use HeartbeatWorkerRequest.ProtoReflect.Descriptor instead.
+func (*HeartbeatWorkerRequest) Descriptor() ([]byte, []int) {
 	return file_proto_task_executor_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *CreateExecutorRequest) GetConfig() *ExecutorConfig {
+func (x *HeartbeatWorkerRequest) GetWorkerId() string {
 	if x != nil {
-		return x.Config
+		return x.WorkerId
 	}
-	return nil
+	return ""
 }
 
-type CreateExecutorResponse struct {
+func (x *HeartbeatWorkerRequest) GetActiveTasks() int32 {
+	if x != nil {
+		return x.ActiveTasks
+	}
+	return 0
+}
+
+// NOT_FOUND означает, что процесс не зарегистрирован или уже признан мёртвым:
+// его следует зарегистрировать заново
+type HeartbeatWorkerResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Executor      *Executor              `protobuf:"bytes,1,opt,name=executor,proto3" json:"executor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateExecutorResponse) Reset() {
-	*x = CreateExecutorResponse{}
+func (x *HeartbeatWorkerResponse) Reset() {
+	*x = HeartbeatWorkerResponse{}
 	mi := &file_proto_task_executor_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateExecutorResponse) String() string {
+func (x *HeartbeatWorkerResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateExecutorResponse) ProtoMessage() {}
+func (*HeartbeatWorkerResponse) ProtoMessage() {}
 
-func (x *CreateExecutorResponse) ProtoReflect() protoreflect.Message {
+func (x *HeartbeatWorkerResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_executor_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -755,40 +1188,34 @@ func (x *CreateExecutorResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateExecutorResponse.ProtoReflect.Descriptor instead.
-func (*CreateExecutorResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use HeartbeatWorkerResponse.ProtoReflect.Descriptor instead.
+func (*HeartbeatWorkerResponse) Descriptor() ([]byte, []int) {
 	return file_proto_task_executor_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *CreateExecutorResponse) GetExecutor() *Executor {
-	if x != nil {
-		return x.Executor
-	}
-	return nil
-}
-
-type UpdateExecutorRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Config        *ExecutorConfig        `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+type ListWorkersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Пустое значение — процессы всех обработчиков
+	ExecutorName  string `protobuf:"bytes,1,opt,name=executor_name,json=executorName,proto3" json:"executor_name,omitempty"`
+	IncludeDead   bool   `protobuf:"varint,2,opt,name=include_dead,json=includeDead,proto3" json:"include_dead,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateExecutorRequest) Reset() {
-	*x = UpdateExecutorRequest{}
+func (x *ListWorkersRequest) Reset() {
+	*x = ListWorkersRequest{}
 	mi := &file_proto_task_executor_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateExecutorRequest) String() string {
+func (x *ListWorkersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateExecutorRequest) ProtoMessage() {}
+func (*ListWorkersRequest) ProtoMessage() {}
 
-func (x *UpdateExecutorRequest) ProtoReflect() protoreflect.Message {
+func (x *ListWorkersRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_executor_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -800,46 +1227,46 @@ func (x *UpdateExecutorRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateExecutorRequest.ProtoReflect.Descriptor instead.
-func (*UpdateExecutorRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListWorkersRequest.ProtoReflect.Descriptor instead.
+func (*ListWorkersRequest) Descriptor() ([]byte, []int) {
 	return file_proto_task_executor_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *UpdateExecutorRequest) GetId() string {
+func (x *ListWorkersRequest) GetExecutorName() string {
 	if x != nil {
-		return x.Id
+		return x.ExecutorName
 	}
 	return ""
 }
 
-func (x *UpdateExecutorRequest) GetConfig() *ExecutorConfig {
+func (x *ListWorkersRequest) GetIncludeDead() bool {
 	if x != nil {
-		return x.Config
+		return x.IncludeDead
 	}
-	return nil
+	return false
 }
 
-type UpdateExecutorResponse struct {
+type ListWorkersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Executor      *Executor              `protobuf:"bytes,1,opt,name=executor,proto3" json:"executor,omitempty"`
+	Workers       []*Worker              `protobuf:"bytes,1,rep,name=workers,proto3" json:"workers,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateExecutorResponse) Reset() {
-	*x = UpdateExecutorResponse{}
+func (x *ListWorkersResponse) Reset() {
+	*x = ListWorkersResponse{}
 	mi := &file_proto_task_executor_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateExecutorResponse) String() string {
+func (x *ListWorkersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateExecutorResponse) ProtoMessage() {}
+func (*ListWorkersResponse) ProtoMessage() {}
 
-func (x *UpdateExecutorResponse) ProtoReflect() protoreflect.Message {
+func (x *ListWorkersResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_executor_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -851,39 +1278,48 @@ func (x *UpdateExecutorResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateExecutorResponse.ProtoReflect.Descriptor instead.
-func (*UpdateExecutorResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListWorkersResponse.ProtoReflect.Descriptor instead.
+func (*ListWorkersResponse) Descriptor() ([]byte, []int) {
 	return file_proto_task_executor_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *UpdateExecutorResponse) GetExecutor() *Executor {
+func (x *ListWorkersResponse) GetWorkers() []*Worker {
 	if x != nil {
-		return x.Executor
+		return x.Workers
 	}
 	return nil
 }
 
-type GetExecutorRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetExecutorRequest) Reset() {
-	*x = GetExecutorRequest{}
+type Worker struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExecutorName    string                 `protobuf:"bytes,2,opt,name=executor_name,json=executorName,proto3" json:"executor_name,omitempty"`
+	Hostname        string                 `protobuf:"bytes,3,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Version         string                 `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	Capabilities    []string               `protobuf:"bytes,5,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	Concurrency     int32                  `protobuf:"varint,6,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	ActiveTasks     int32                  `protobuf:"varint,7,opt,name=active_tasks,json=activeTasks,proto3" json:"active_tasks,omitempty"`
+	Status          WorkerStatus           `protobuf:"varint,8,opt,name=status,proto3,enum=taskexecutor.WorkerStatus" json:"status,omitempty"`
+	RegisteredAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=registered_at,json=registeredAt,proto3" json:"registered_at,omitempty"`
+	LastHeartbeatAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_heartbeat_at,json=lastHeartbeatAt,proto3" json:"last_heartbeat_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Worker) Reset() {
+	*x = Worker{}
 	mi := &file_proto_task_executor_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetExecutorRequest) String() string {
+func (x *Worker) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetExecutorRequest) ProtoMessage() {}
+func (*Worker) ProtoMessage() {}
 
-func (x *GetExecutorRequest) ProtoReflect() protoreflect.Message {
+func (x *Worker) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_executor_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -895,85 +1331,108 @@ func (x *GetExecutorRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetExecutorRequest.ProtoReflect.Descriptor instead.
-func (*GetExecutorRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Worker.ProtoReflect.Descriptor instead.
+func (*Worker) Descriptor() ([]byte, []int) {
 	return file_proto_task_executor_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *GetExecutorRequest) GetId() string {
+func (x *Worker) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-type GetExecutorResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Executor      *Executor              `protobuf:"bytes,1,opt,name=executor,proto3" json:"executor,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Worker) GetExecutorName() string {
+	if x != nil {
+		return x.ExecutorName
+	}
+	return ""
 }
 
-func (x *GetExecutorResponse) Reset() {
-	*x = GetExecutorResponse{}
-	mi := &file_proto_task_executor_proto_msgTypes[15]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Worker) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
 }
 
-func (x *GetExecutorResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Worker) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
 }
 
-func (*GetExecutorResponse) ProtoMessage() {}
+func (x *Worker) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
 
-func (x *GetExecutorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_executor_proto_msgTypes[15]
+func (x *Worker) GetConcurrency() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Concurrency
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use GetExecutorResponse.ProtoReflect.Descriptor instead.
-func (*GetExecutorResponse) Descriptor() ([]byte, []int) {
-	return file_proto_task_executor_proto_rawDescGZIP(), []int{15}
+func (x *Worker) GetActiveTasks() int32 {
+	if x != nil {
+		return x.ActiveTasks
+	}
+	return 0
 }
 
-func (x *GetExecutorResponse) GetExecutor() *Executor {
+func (x *Worker) GetStatus() WorkerStatus {
 	if x != nil {
-		return x.Executor
+		return x.Status
+	}
+	return WorkerStatus_WORKER_STATUS_UNSPECIFIED
+}
+
+func (x *Worker) GetRegisteredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RegisteredAt
 	}
 	return nil
 }
 
-type ListExecutorsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+func (x *Worker) GetLastHeartbeatAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastHeartbeatAt
+	}
+	return nil
+}
+
+type GetNextTaskRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ExecutorName string                 `protobuf:"bytes,1,opt,name=executor_name,json=executorName,proto3" json:"executor_name,omitempty"`
+	// Сколько ждать появления задачи, не более 60 секунд. Если задано, пустая очередь
+	// возвращает ответ без задачи, а не NOT_FOUND. Без ожидания — прежнее поведение
+	WaitTimeout *durationpb.Duration `protobuf:"bytes,2,opt,name=wait_timeout,json=waitTimeout,proto3" json:"wait_timeout,omitempty"`
+	// Сколько задач выдать за один вызов, не более 100. 0 и 1 — одну задачу
+	MaxTasks      int32 `protobuf:"varint,3,opt,name=max_tasks,json=maxTasks,proto3" json:"max_tasks,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListExecutorsRequest) Reset() {
-	*x = ListExecutorsRequest{}
-	mi := &file_proto_task_executor_proto_msgTypes[16]
+func (x *GetNextTaskRequest) Reset() {
+	*x = GetNextTaskRequest{}
+	mi := &file_proto_task_executor_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListExecutorsRequest) String() string {
+func (x *GetNextTaskRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListExecutorsRequest) ProtoMessage() {}
+func (*GetNextTaskRequest) ProtoMessage() {}
 
-func (x *ListExecutorsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_executor_proto_msgTypes[16]
+func (x *GetNextTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -984,47 +1443,127 @@ func (x *ListExecutorsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListExecutorsRequest.ProtoReflect.Descriptor instead.
-func (*ListExecutorsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_task_executor_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use GetNextTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetNextTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *ListExecutorsRequest) GetPageSize() int32 {
+func (x *GetNextTaskRequest) GetExecutorName() string {
 	if x != nil {
-		return x.PageSize
+		return x.ExecutorName
 	}
-	return 0
+	return ""
 }
 
-func (x *ListExecutorsRequest) GetPageToken() string {
+func (x *GetNextTaskRequest) GetWaitTimeout() *durationpb.Duration {
 	if x != nil {
-		return x.PageToken
+		return x.WaitTimeout
 	}
-	return ""
+	return nil
 }
 
-type ListExecutorsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Executors     []*Executor            `protobuf:"bytes,1,rep,name=executors,proto3" json:"executors,omitempty"`
-	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+func (x *GetNextTaskRequest) GetMaxTasks() int32 {
+	if x != nil {
+		return x.MaxTasks
+	}
+	return 0
+}
+
+type GetNextTaskResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Не задано, если за wait_timeout задача не появилась. При max_tasks > 1 — первая из tasks
+	Task *Task `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// Все выданные задачи, каждая — только одному обработчику
+	Tasks []*Task `protobuf:"bytes,2,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// Задано, если задачи не выданы из-за rate_limit обработчика: раньше этого
+	// времени повторять запрос бессмысленно. Без wait_timeout вместо пустого
+	// ответа возвращается RESOURCE_EXHAUSTED с тем же значением в RetryInfo
+	RetryAfter    *durationpb.Duration `protobuf:"bytes,3,opt,name=retry_after,json=retryAfter,proto3" json:"retry_after,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListExecutorsResponse) Reset() {
-	*x = ListExecutorsResponse{}
+func (x *GetNextTaskResponse) Reset() {
+	*x = GetNextTaskResponse{}
+	mi := &file_proto_task_executor_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNextTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNextTaskResponse) ProtoMessage() {}
+
+func (x *GetNextTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNextTaskResponse.ProtoReflect.Descriptor instead.
+func (*GetNextTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetNextTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *GetNextTaskResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *GetNextTaskResponse) GetRetryAfter() *durationpb.Duration {
+	if x != nil {
+		return x.RetryAfter
+	}
+	return nil
+}
+
+type UpdateTaskStatusRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// TASK_STATUS_COMPLETED, TASK_STATUS_FAILED или TASK_STATUS_CANCELLED, иначе
+	// INVALID_ARGUMENT. Вернуть задачу в очередь — ReleaseTask
+	Status TaskStatus `protobuf:"varint,2,opt,name=status,proto3,enum=taskexecutor.TaskStatus" json:"status,omitempty"`
+	Error  string     `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// Результат обработки, сохраняется для TASK_STATUS_COMPLETED (не более 1 МиБ)
+	Result []byte `protobuf:"bytes,4,opt,name=result,proto3" json:"result,omitempty"`
+	// Аренда, под которой получена задача (task.lease_id). Обязательна: отчёт
+	// по задаче, которая с тех пор возвращена в очередь и выдана снова, отклоняется (ABORTED)
+	LeaseId       string `protobuf:"bytes,5,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskStatusRequest) Reset() {
+	*x = UpdateTaskStatusRequest{}
 	mi := &file_proto_task_executor_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListExecutorsResponse) String() string {
+func (x *UpdateTaskStatusRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListExecutorsResponse) ProtoMessage() {}
+func (*UpdateTaskStatusRequest) ProtoMessage() {}
 
-func (x *ListExecutorsResponse) ProtoReflect() protoreflect.Message {
+func (x *UpdateTaskStatusRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_executor_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1036,47 +1575,67 @@ func (x *ListExecutorsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListExecutorsResponse.ProtoReflect.Descriptor instead.
-func (*ListExecutorsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateTaskStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTaskStatusRequest) Descriptor() ([]byte, []int) {
 	return file_proto_task_executor_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *ListExecutorsResponse) GetExecutors() []*Executor {
+func (x *UpdateTaskStatusRequest) GetId() string {
 	if x != nil {
-		return x.Executors
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateTaskStatusRequest) GetStatus() TaskStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TaskStatus_TASK_STATUS_UNSPECIFIED
+}
+
+func (x *UpdateTaskStatusRequest) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *UpdateTaskStatusRequest) GetResult() []byte {
+	if x != nil {
+		return x.Result
 	}
 	return nil
 }
 
-func (x *ListExecutorsResponse) GetNextPageToken() string {
+func (x *UpdateTaskStatusRequest) GetLeaseId() string {
 	if x != nil {
-		return x.NextPageToken
+		return x.LeaseId
 	}
 	return ""
 }
 
-// Удаление executor
-type DeleteExecutorRequest struct {
+type UpdateTaskStatusResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteExecutorRequest) Reset() {
-	*x = DeleteExecutorRequest{}
+func (x *UpdateTaskStatusResponse) Reset() {
+	*x = UpdateTaskStatusResponse{}
 	mi := &file_proto_task_executor_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteExecutorRequest) String() string {
+func (x *UpdateTaskStatusResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteExecutorRequest) ProtoMessage() {}
+func (*UpdateTaskStatusResponse) ProtoMessage() {}
 
-func (x *DeleteExecutorRequest) ProtoReflect() protoreflect.Message {
+func (x *UpdateTaskStatusResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_executor_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1088,38 +1647,41 @@ func (x *DeleteExecutorRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteExecutorRequest.ProtoReflect.Descriptor instead.
-func (*DeleteExecutorRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateTaskStatusResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTaskStatusResponse) Descriptor() ([]byte, []int) {
 	return file_proto_task_executor_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *DeleteExecutorRequest) GetId() string {
+func (x *UpdateTaskStatusResponse) GetTask() *Task {
 	if x != nil {
-		return x.Id
+		return x.Task
 	}
-	return ""
+	return nil
 }
 
-type DeleteExecutorResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+// Пакетное обновление статусов: не более 100 задач, завершённые записываются
+// в MongoDB одним запросом. Ошибка одной задачи не отменяет остальные
+type UpdateTaskStatusesRequest struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Updates       []*UpdateTaskStatusRequest `protobuf:"bytes,1,rep,name=updates,proto3" json:"updates,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteExecutorResponse) Reset() {
-	*x = DeleteExecutorResponse{}
+func (x *UpdateTaskStatusesRequest) Reset() {
+	*x = UpdateTaskStatusesRequest{}
 	mi := &file_proto_task_executor_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteExecutorResponse) String() string {
+func (x *UpdateTaskStatusesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteExecutorResponse) ProtoMessage() {}
+func (*UpdateTaskStatusesRequest) ProtoMessage() {}
 
-func (x *DeleteExecutorResponse) ProtoReflect() protoreflect.Message {
+func (x *UpdateTaskStatusesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_executor_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1131,38 +1693,40 @@ func (x *DeleteExecutorResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteExecutorResponse.ProtoReflect.Descriptor instead.
-func (*DeleteExecutorResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateTaskStatusesRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTaskStatusesRequest) Descriptor() ([]byte, []int) {
 	return file_proto_task_executor_proto_rawDescGZIP(), []int{19}
 }
 
-// Common Messages
-type Executor struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Enabled       bool                   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
-	Config        *ExecutorConfig        `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+func (x *UpdateTaskStatusesRequest) GetUpdates() []*UpdateTaskStatusRequest {
+	if x != nil {
+		return x.Updates
+	}
+	return nil
+}
+
+type UpdateTaskStatusesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// В порядке updates
+	Results       []*UpdateTaskStatusResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Executor) Reset() {
-	*x = Executor{}
+func (x *UpdateTaskStatusesResponse) Reset() {
+	*x = UpdateTaskStatusesResponse{}
 	mi := &file_proto_task_executor_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Executor) String() string {
+func (x *UpdateTaskStatusesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Executor) ProtoMessage() {}
+func (*UpdateTaskStatusesResponse) ProtoMessage() {}
 
-func (x *Executor) ProtoReflect() protoreflect.Message {
+func (x *UpdateTaskStatusesResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_executor_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1174,79 +1738,114 @@ func (x *Executor) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Executor.ProtoReflect.Descriptor instead.
-func (*Executor) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateTaskStatusesResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTaskStatusesResponse) Descriptor() ([]byte, []int) {
 	return file_proto_task_executor_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *Executor) GetId() string {
+func (x *UpdateTaskStatusesResponse) GetResults() []*UpdateTaskStatusResult {
 	if x != nil {
-		return x.Id
+		return x.Results
 	}
-	return ""
+	return nil
 }
 
-func (x *Executor) GetName() string {
+type UpdateTaskStatusResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Задача после обновления, если оно удалось
+	Task *Task `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	// Код gRPC, как у UpdateTaskStatus: 0 — успех, ABORTED — задача уже не выполняется
+	Code          int32  `protobuf:"varint,3,opt,name=code,proto3" json:"code,omitempty"`
+	Error         string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskStatusResult) Reset() {
+	*x = UpdateTaskStatusResult{}
+	mi := &file_proto_task_executor_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskStatusResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskStatusResult) ProtoMessage() {}
+
+func (x *UpdateTaskStatusResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[21]
 	if x != nil {
-		return x.Name
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Executor) GetEnabled() bool {
+// Deprecated: Use UpdateTaskStatusResult.ProtoReflect.Descriptor instead.
+func (*UpdateTaskStatusResult) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UpdateTaskStatusResult) GetId() string {
 	if x != nil {
-		return x.Enabled
+		return x.Id
 	}
-	return false
+	return ""
 }
 
-func (x *Executor) GetConfig() *ExecutorConfig {
+func (x *UpdateTaskStatusResult) GetTask() *Task {
 	if x != nil {
-		return x.Config
+		return x.Task
 	}
 	return nil
 }
 
-func (x *Executor) GetCreatedAt() *timestamppb.Timestamp {
+func (x *UpdateTaskStatusResult) GetCode() int32 {
 	if x != nil {
-		return x.CreatedAt
+		return x.Code
 	}
-	return nil
+	return 0
 }
 
-func (x *Executor) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *UpdateTaskStatusResult) GetError() string {
 	if x != nil {
-		return x.UpdatedAt
+		return x.Error
 	}
-	return nil
+	return ""
 }
 
-type ExecutorConfig struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
-	WriteConcern  *WriteConcern          `protobuf:"bytes,3,opt,name=write_concern,json=writeConcern,proto3" json:"write_concern,omitempty"`
-	RetryPolicy   *RetryPolicy           `protobuf:"bytes,4,opt,name=retry_policy,json=retryPolicy,proto3" json:"retry_policy,omitempty"`
-	DlqConfig     *DLQConfig             `protobuf:"bytes,5,opt,name=dlq_config,json=dlqConfig,proto3" json:"dlq_config,omitempty"`
+// Продление аренды задачи, которая всё ещё выполняется
+type HeartbeatTaskRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Progress *TaskProgress          `protobuf:"bytes,2,opt,name=progress,proto3" json:"progress,omitempty"`
+	// Аренда, под которой получена задача (task.lease_id). Обязательна
+	LeaseId       string `protobuf:"bytes,3,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ExecutorConfig) Reset() {
-	*x = ExecutorConfig{}
-	mi := &file_proto_task_executor_proto_msgTypes[21]
+func (x *HeartbeatTaskRequest) Reset() {
+	*x = HeartbeatTaskRequest{}
+	mi := &file_proto_task_executor_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ExecutorConfig) String() string {
+func (x *HeartbeatTaskRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExecutorConfig) ProtoMessage() {}
+func (*HeartbeatTaskRequest) ProtoMessage() {}
 
-func (x *ExecutorConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_executor_proto_msgTypes[21]
+func (x *HeartbeatTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1257,68 +1856,3548 @@ func (x *ExecutorConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExecutorConfig.ProtoReflect.Descriptor instead.
-func (*ExecutorConfig) Descriptor() ([]byte, []int) {
-	return file_proto_task_executor_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use HeartbeatTaskRequest.ProtoReflect.Descriptor instead.
+func (*HeartbeatTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *ExecutorConfig) GetName() string {
+func (x *HeartbeatTaskRequest) GetId() string {
 	if x != nil {
-		return x.Name
+		return x.Id
 	}
 	return ""
 }
 
-func (x *ExecutorConfig) GetEnabled() bool {
+func (x *HeartbeatTaskRequest) GetProgress() *TaskProgress {
 	if x != nil {
-		return x.Enabled
+		return x.Progress
+	}
+	return nil
+}
+
+func (x *HeartbeatTaskRequest) GetLeaseId() string {
+	if x != nil {
+		return x.LeaseId
+	}
+	return ""
+}
+
+type HeartbeatTaskResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	LeaseExpiresAt *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=lease_expires_at,json=leaseExpiresAt,proto3" json:"lease_expires_at,omitempty"`
+	// Задачу попросили отменить, обработчику следует прекратить работу
+	CancelRequested bool `protobuf:"varint,2,opt,name=cancel_requested,json=cancelRequested,proto3" json:"cancel_requested,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *HeartbeatTaskResponse) Reset() {
+	*x = HeartbeatTaskResponse{}
+	mi := &file_proto_task_executor_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatTaskResponse) ProtoMessage() {}
+
+func (x *HeartbeatTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatTaskResponse.ProtoReflect.Descriptor instead.
+func (*HeartbeatTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *HeartbeatTaskResponse) GetLeaseExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LeaseExpiresAt
+	}
+	return nil
+}
+
+func (x *HeartbeatTaskResponse) GetCancelRequested() bool {
+	if x != nil {
+		return x.CancelRequested
+	}
+	return false
+}
+
+// Возврат недообработанной задачи в очередь, например при остановке обработчика.
+// Попытка не засчитывается как неудачная, задача сразу доступна другим обработчикам;
+// задача, которую попросили отменить, отменяется
+type ReleaseTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Аренда, под которой получена задача (task.lease_id). Обязательна
+	LeaseId       string `protobuf:"bytes,2,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseTaskRequest) Reset() {
+	*x = ReleaseTaskRequest{}
+	mi := &file_proto_task_executor_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseTaskRequest) ProtoMessage() {}
+
+func (x *ReleaseTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseTaskRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ReleaseTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReleaseTaskRequest) GetLeaseId() string {
+	if x != nil {
+		return x.LeaseId
+	}
+	return ""
+}
+
+type ReleaseTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseTaskResponse) Reset() {
+	*x = ReleaseTaskResponse{}
+	mi := &file_proto_task_executor_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseTaskResponse) ProtoMessage() {}
+
+func (x *ReleaseTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseTaskResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseTaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ReleaseTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+// Executor Configuration Messages
+type CreateExecutorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Config        *ExecutorConfig        `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateExecutorRequest) Reset() {
+	*x = CreateExecutorRequest{}
+	mi := &file_proto_task_executor_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateExecutorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateExecutorRequest) ProtoMessage() {}
+
+func (x *CreateExecutorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateExecutorRequest.ProtoReflect.Descriptor instead.
+func (*CreateExecutorRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CreateExecutorRequest) GetConfig() *ExecutorConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+type CreateExecutorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Executor      *Executor              `protobuf:"bytes,1,opt,name=executor,proto3" json:"executor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateExecutorResponse) Reset() {
+	*x = CreateExecutorResponse{}
+	mi := &file_proto_task_executor_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateExecutorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateExecutorResponse) ProtoMessage() {}
+
+func (x *CreateExecutorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateExecutorResponse.ProtoReflect.Descriptor instead.
+func (*CreateExecutorResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CreateExecutorResponse) GetExecutor() *Executor {
+	if x != nil {
+		return x.Executor
+	}
+	return nil
+}
+
+type UpdateExecutorRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Config *ExecutorConfig        `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	// Версия (config.version), на основе которой сделаны изменения. Обязательна;
+	// если обработчик с тех пор изменён, возвращается ABORTED
+	Version int64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	// Обновляемые поля config, например "retry_policy.max_attempts" или "rate_limit".
+	// Пустая маска — обновить все поля, кроме name
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,4,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateExecutorRequest) Reset() {
+	*x = UpdateExecutorRequest{}
+	mi := &file_proto_task_executor_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateExecutorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateExecutorRequest) ProtoMessage() {}
+
+func (x *UpdateExecutorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateExecutorRequest.ProtoReflect.Descriptor instead.
+func (*UpdateExecutorRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UpdateExecutorRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateExecutorRequest) GetConfig() *ExecutorConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *UpdateExecutorRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UpdateExecutorRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateExecutorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Executor      *Executor              `protobuf:"bytes,1,opt,name=executor,proto3" json:"executor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateExecutorResponse) Reset() {
+	*x = UpdateExecutorResponse{}
+	mi := &file_proto_task_executor_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateExecutorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateExecutorResponse) ProtoMessage() {}
+
+func (x *UpdateExecutorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateExecutorResponse.ProtoReflect.Descriptor instead.
+func (*UpdateExecutorResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *UpdateExecutorResponse) GetExecutor() *Executor {
+	if x != nil {
+		return x.Executor
+	}
+	return nil
+}
+
+type GetExecutorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExecutorRequest) Reset() {
+	*x = GetExecutorRequest{}
+	mi := &file_proto_task_executor_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExecutorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExecutorRequest) ProtoMessage() {}
+
+func (x *GetExecutorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExecutorRequest.ProtoReflect.Descriptor instead.
+func (*GetExecutorRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetExecutorRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetExecutorResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Executor *Executor              `protobuf:"bytes,1,opt,name=executor,proto3" json:"executor,omitempty"`
+	// Задачи обработчика в DLQ
+	DlqStats      *DLQStats `protobuf:"bytes,2,opt,name=dlq_stats,json=dlqStats,proto3" json:"dlq_stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExecutorResponse) Reset() {
+	*x = GetExecutorResponse{}
+	mi := &file_proto_task_executor_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExecutorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExecutorResponse) ProtoMessage() {}
+
+func (x *GetExecutorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExecutorResponse.ProtoReflect.Descriptor instead.
+func (*GetExecutorResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetExecutorResponse) GetExecutor() *Executor {
+	if x != nil {
+		return x.Executor
+	}
+	return nil
+}
+
+func (x *GetExecutorResponse) GetDlqStats() *DLQStats {
+	if x != nil {
+		return x.DlqStats
+	}
+	return nil
+}
+
+type DLQStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Size  int64                  `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	// Сколько лежит в DLQ самая старая задача. Пустое значение — DLQ пуста
+	OldestEntryAge *durationpb.Duration `protobuf:"bytes,2,opt,name=oldest_entry_age,json=oldestEntryAge,proto3" json:"oldest_entry_age,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DLQStats) Reset() {
+	*x = DLQStats{}
+	mi := &file_proto_task_executor_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DLQStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DLQStats) ProtoMessage() {}
+
+func (x *DLQStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DLQStats.ProtoReflect.Descriptor instead.
+func (*DLQStats) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DLQStats) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *DLQStats) GetOldestEntryAge() *durationpb.Duration {
+	if x != nil {
+		return x.OldestEntryAge
+	}
+	return nil
+}
+
+type ListExecutorsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	PageSize  int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Вернуть удалённые обработчики, которые ещё можно восстановить, вместо действующих
+	Deleted       bool `protobuf:"varint,3,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExecutorsRequest) Reset() {
+	*x = ListExecutorsRequest{}
+	mi := &file_proto_task_executor_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExecutorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExecutorsRequest) ProtoMessage() {}
+
+func (x *ListExecutorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExecutorsRequest.ProtoReflect.Descriptor instead.
+func (*ListExecutorsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListExecutorsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListExecutorsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListExecutorsRequest) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+type ListExecutorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Executors     []*Executor            `protobuf:"bytes,1,rep,name=executors,proto3" json:"executors,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExecutorsResponse) Reset() {
+	*x = ListExecutorsResponse{}
+	mi := &file_proto_task_executor_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExecutorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExecutorsResponse) ProtoMessage() {}
+
+func (x *ListExecutorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExecutorsResponse.ProtoReflect.Descriptor instead.
+func (*ListExecutorsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListExecutorsResponse) GetExecutors() []*Executor {
+	if x != nil {
+		return x.Executors
+	}
+	return nil
+}
+
+func (x *ListExecutorsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Удаление executor
+// Обработчик удаляется мягко: его можно восстановить через UndeleteExecutor
+// в течение 7 дней, после чего конфигурация удаляется окончательно.
+// Задачи обрабатываются сразу согласно policy и при восстановлении не возвращаются
+type DeleteExecutorRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Policy ExecutorDeletionPolicy `protobuf:"varint,2,opt,name=policy,proto3,enum=taskexecutor.ExecutorDeletionPolicy" json:"policy,omitempty"`
+	// Обработчик, которому передаются задачи при EXECUTOR_DELETION_POLICY_MOVE
+	TargetExecutor string `protobuf:"bytes,3,opt,name=target_executor,json=targetExecutor,proto3" json:"target_executor,omitempty"`
+	// Ошибка отменённых задач при EXECUTOR_DELETION_POLICY_CANCEL и
+	// EXECUTOR_DELETION_POLICY_MOVE. Пустое значение — "executor deleted"
+	Reason        string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteExecutorRequest) Reset() {
+	*x = DeleteExecutorRequest{}
+	mi := &file_proto_task_executor_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteExecutorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteExecutorRequest) ProtoMessage() {}
+
+func (x *DeleteExecutorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteExecutorRequest.ProtoReflect.Descriptor instead.
+func (*DeleteExecutorRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DeleteExecutorRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteExecutorRequest) GetPolicy() ExecutorDeletionPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return ExecutorDeletionPolicy_EXECUTOR_DELETION_POLICY_UNSPECIFIED
+}
+
+func (x *DeleteExecutorRequest) GetTargetExecutor() string {
+	if x != nil {
+		return x.TargetExecutor
+	}
+	return ""
+}
+
+func (x *DeleteExecutorRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type DeleteExecutorResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Сколько задач отменено, передано или заархивировано
+	AffectedTasks int64                  `protobuf:"varint,1,opt,name=affected_tasks,json=affectedTasks,proto3" json:"affected_tasks,omitempty"`
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// До какого момента обработчик можно восстановить
+	RestoreUntil  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=restore_until,json=restoreUntil,proto3" json:"restore_until,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteExecutorResponse) Reset() {
+	*x = DeleteExecutorResponse{}
+	mi := &file_proto_task_executor_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteExecutorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteExecutorResponse) ProtoMessage() {}
+
+func (x *DeleteExecutorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteExecutorResponse.ProtoReflect.Descriptor instead.
+func (*DeleteExecutorResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DeleteExecutorResponse) GetAffectedTasks() int64 {
+	if x != nil {
+		return x.AffectedTasks
+	}
+	return 0
+}
+
+func (x *DeleteExecutorResponse) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *DeleteExecutorResponse) GetRestoreUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RestoreUntil
+	}
+	return nil
+}
+
+type UndeleteExecutorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UndeleteExecutorRequest) Reset() {
+	*x = UndeleteExecutorRequest{}
+	mi := &file_proto_task_executor_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UndeleteExecutorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndeleteExecutorRequest) ProtoMessage() {}
+
+func (x *UndeleteExecutorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndeleteExecutorRequest.ProtoReflect.Descriptor instead.
+func (*UndeleteExecutorRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *UndeleteExecutorRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type UndeleteExecutorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Executor      *Executor              `protobuf:"bytes,1,opt,name=executor,proto3" json:"executor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UndeleteExecutorResponse) Reset() {
+	*x = UndeleteExecutorResponse{}
+	mi := &file_proto_task_executor_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UndeleteExecutorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndeleteExecutorResponse) ProtoMessage() {}
+
+func (x *UndeleteExecutorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndeleteExecutorResponse.ProtoReflect.Descriptor instead.
+func (*UndeleteExecutorResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *UndeleteExecutorResponse) GetExecutor() *Executor {
+	if x != nil {
+		return x.Executor
+	}
+	return nil
+}
+
+// История изменений обработчика, от новых ревизий к старым. Кто вносит
+// изменение, берётся из метаданных x-actor запроса (по умолчанию — адрес клиента)
+type ListExecutorRevisionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// По умолчанию 50, не больше 500
+	PageSize      int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExecutorRevisionsRequest) Reset() {
+	*x = ListExecutorRevisionsRequest{}
+	mi := &file_proto_task_executor_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExecutorRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExecutorRevisionsRequest) ProtoMessage() {}
+
+func (x *ListExecutorRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExecutorRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*ListExecutorRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListExecutorRevisionsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListExecutorRevisionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListExecutorRevisionsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListExecutorRevisionsResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Revisions []*ExecutorRevision    `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	// Пусто, если это последняя страница
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExecutorRevisionsResponse) Reset() {
+	*x = ListExecutorRevisionsResponse{}
+	mi := &file_proto_task_executor_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExecutorRevisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExecutorRevisionsResponse) ProtoMessage() {}
+
+func (x *ListExecutorRevisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExecutorRevisionsResponse.ProtoReflect.Descriptor instead.
+func (*ListExecutorRevisionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListExecutorRevisionsResponse) GetRevisions() []*ExecutorRevision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+func (x *ListExecutorRevisionsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Восстанавливает конфигурацию обработчика из ревизии. Удалённый обработчик
+// нужно сначала восстановить через UndeleteExecutor
+type RollbackExecutorRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Name       string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	RevisionId string                 `protobuf:"bytes,2,opt,name=revision_id,json=revisionId,proto3" json:"revision_id,omitempty"`
+	// Текущая версия обработчика (config.version). Обязательна; если обработчик
+	// с тех пор изменён, возвращается ABORTED
+	Version       int64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackExecutorRequest) Reset() {
+	*x = RollbackExecutorRequest{}
+	mi := &file_proto_task_executor_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackExecutorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackExecutorRequest) ProtoMessage() {}
+
+func (x *RollbackExecutorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackExecutorRequest.ProtoReflect.Descriptor instead.
+func (*RollbackExecutorRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *RollbackExecutorRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RollbackExecutorRequest) GetRevisionId() string {
+	if x != nil {
+		return x.RevisionId
+	}
+	return ""
+}
+
+func (x *RollbackExecutorRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type RollbackExecutorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Executor      *Executor              `protobuf:"bytes,1,opt,name=executor,proto3" json:"executor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackExecutorResponse) Reset() {
+	*x = RollbackExecutorResponse{}
+	mi := &file_proto_task_executor_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackExecutorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackExecutorResponse) ProtoMessage() {}
+
+func (x *RollbackExecutorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackExecutorResponse.ProtoReflect.Descriptor instead.
+func (*RollbackExecutorResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *RollbackExecutorResponse) GetExecutor() *Executor {
+	if x != nil {
+		return x.Executor
+	}
+	return nil
+}
+
+type ExecutorRevision struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExecutorName string                 `protobuf:"bytes,2,opt,name=executor_name,json=executorName,proto3" json:"executor_name,omitempty"`
+	Action       ExecutorRevisionAction `protobuf:"varint,3,opt,name=action,proto3,enum=taskexecutor.ExecutorRevisionAction" json:"action,omitempty"`
+	// Кто внёс изменение
+	Actor string `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	// Конфигурация после изменения; у удаления — на момент удаления
+	Config *ExecutorConfig `protobuf:"bytes,5,opt,name=config,proto3" json:"config,omitempty"`
+	// Изменённые поля конфигурации
+	Changes []*ExecutorFieldChange `protobuf:"bytes,6,rep,name=changes,proto3" json:"changes,omitempty"`
+	// Ревизия, к которой откатили обработчик (только для ROLLED_BACK)
+	RolledBackTo  string                 `protobuf:"bytes,7,opt,name=rolled_back_to,json=rolledBackTo,proto3" json:"rolled_back_to,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecutorRevision) Reset() {
+	*x = ExecutorRevision{}
+	mi := &file_proto_task_executor_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutorRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutorRevision) ProtoMessage() {}
+
+func (x *ExecutorRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecutorRevision.ProtoReflect.Descriptor instead.
+func (*ExecutorRevision) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ExecutorRevision) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ExecutorRevision) GetExecutorName() string {
+	if x != nil {
+		return x.ExecutorName
+	}
+	return ""
+}
+
+func (x *ExecutorRevision) GetAction() ExecutorRevisionAction {
+	if x != nil {
+		return x.Action
+	}
+	return ExecutorRevisionAction_EXECUTOR_REVISION_ACTION_UNSPECIFIED
+}
+
+func (x *ExecutorRevision) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *ExecutorRevision) GetConfig() *ExecutorConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *ExecutorRevision) GetChanges() []*ExecutorFieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *ExecutorRevision) GetRolledBackTo() string {
+	if x != nil {
+		return x.RolledBackTo
+	}
+	return ""
+}
+
+func (x *ExecutorRevision) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ExecutorFieldChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Путь поля, например "retry_policy.max_attempts"
+	Field         string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	OldValue      string `protobuf:"bytes,2,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"`
+	NewValue      string `protobuf:"bytes,3,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecutorFieldChange) Reset() {
+	*x = ExecutorFieldChange{}
+	mi := &file_proto_task_executor_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutorFieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutorFieldChange) ProtoMessage() {}
+
+func (x *ExecutorFieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecutorFieldChange.ProtoReflect.Descriptor instead.
+func (*ExecutorFieldChange) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ExecutorFieldChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ExecutorFieldChange) GetOldValue() string {
+	if x != nil {
+		return x.OldValue
+	}
+	return ""
+}
+
+func (x *ExecutorFieldChange) GetNewValue() string {
+	if x != nil {
+		return x.NewValue
+	}
+	return ""
+}
+
+// Приостанавливает выдачу задач: AddTask принимает задачи, GetNextTask
+// возвращает UNAVAILABLE
+type PauseExecutorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseExecutorRequest) Reset() {
+	*x = PauseExecutorRequest{}
+	mi := &file_proto_task_executor_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseExecutorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseExecutorRequest) ProtoMessage() {}
+
+func (x *PauseExecutorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseExecutorRequest.ProtoReflect.Descriptor instead.
+func (*PauseExecutorRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *PauseExecutorRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type PauseExecutorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Executor      *Executor              `protobuf:"bytes,1,opt,name=executor,proto3" json:"executor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseExecutorResponse) Reset() {
+	*x = PauseExecutorResponse{}
+	mi := &file_proto_task_executor_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseExecutorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseExecutorResponse) ProtoMessage() {}
+
+func (x *PauseExecutorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseExecutorResponse.ProtoReflect.Descriptor instead.
+func (*PauseExecutorResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *PauseExecutorResponse) GetExecutor() *Executor {
+	if x != nil {
+		return x.Executor
+	}
+	return nil
+}
+
+// Возвращает обработчик из любого состояния в EXECUTOR_STATE_ACTIVE
+type ResumeExecutorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeExecutorRequest) Reset() {
+	*x = ResumeExecutorRequest{}
+	mi := &file_proto_task_executor_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeExecutorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeExecutorRequest) ProtoMessage() {}
+
+func (x *ResumeExecutorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeExecutorRequest.ProtoReflect.Descriptor instead.
+func (*ResumeExecutorRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ResumeExecutorRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ResumeExecutorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Executor      *Executor              `protobuf:"bytes,1,opt,name=executor,proto3" json:"executor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeExecutorResponse) Reset() {
+	*x = ResumeExecutorResponse{}
+	mi := &file_proto_task_executor_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeExecutorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeExecutorResponse) ProtoMessage() {}
+
+func (x *ResumeExecutorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeExecutorResponse.ProtoReflect.Descriptor instead.
+func (*ResumeExecutorResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ResumeExecutorResponse) GetExecutor() *Executor {
+	if x != nil {
+		return x.Executor
+	}
+	return nil
+}
+
+// Останавливает приём задач: AddTask возвращает FAILED_PRECONDITION,
+// уже поставленные задачи выдаются как обычно
+type DrainExecutorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DrainExecutorRequest) Reset() {
+	*x = DrainExecutorRequest{}
+	mi := &file_proto_task_executor_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DrainExecutorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrainExecutorRequest) ProtoMessage() {}
+
+func (x *DrainExecutorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrainExecutorRequest.ProtoReflect.Descriptor instead.
+func (*DrainExecutorRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *DrainExecutorRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DrainExecutorResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Executor *Executor              `protobuf:"bytes,1,opt,name=executor,proto3" json:"executor,omitempty"`
+	// Сколько задач осталось выполнить: ожидающие и выполняемые
+	RemainingTasks int64 `protobuf:"varint,2,opt,name=remaining_tasks,json=remainingTasks,proto3" json:"remaining_tasks,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DrainExecutorResponse) Reset() {
+	*x = DrainExecutorResponse{}
+	mi := &file_proto_task_executor_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DrainExecutorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrainExecutorResponse) ProtoMessage() {}
+
+func (x *DrainExecutorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrainExecutorResponse.ProtoReflect.Descriptor instead.
+func (*DrainExecutorResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *DrainExecutorResponse) GetExecutor() *Executor {
+	if x != nil {
+		return x.Executor
+	}
+	return nil
+}
+
+func (x *DrainExecutorResponse) GetRemainingTasks() int64 {
+	if x != nil {
+		return x.RemainingTasks
+	}
+	return 0
+}
+
+// Выключает обработчик: AddTask, GetNextTask и RegisterExecutor возвращают
+// FAILED_PRECONDITION. Выполняемые задачи можно завершить
+type DisableExecutorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableExecutorRequest) Reset() {
+	*x = DisableExecutorRequest{}
+	mi := &file_proto_task_executor_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableExecutorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableExecutorRequest) ProtoMessage() {}
+
+func (x *DisableExecutorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableExecutorRequest.ProtoReflect.Descriptor instead.
+func (*DisableExecutorRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *DisableExecutorRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DisableExecutorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Executor      *Executor              `protobuf:"bytes,1,opt,name=executor,proto3" json:"executor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableExecutorResponse) Reset() {
+	*x = DisableExecutorResponse{}
+	mi := &file_proto_task_executor_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableExecutorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableExecutorResponse) ProtoMessage() {}
+
+func (x *DisableExecutorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableExecutorResponse.ProtoReflect.Descriptor instead.
+func (*DisableExecutorResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *DisableExecutorResponse) GetExecutor() *Executor {
+	if x != nil {
+		return x.Executor
+	}
+	return nil
+}
+
+// DLQ Messages
+// Пустые поля фильтра не применяются
+type DLQFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Время попадания задачи в DLQ
+	FailedAfter  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=failed_after,json=failedAfter,proto3" json:"failed_after,omitempty"`
+	FailedBefore *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=failed_before,json=failedBefore,proto3" json:"failed_before,omitempty"`
+	// Подстрока текста ошибки без учёта регистра
+	ErrorContains string            `protobuf:"bytes,3,opt,name=error_contains,json=errorContains,proto3" json:"error_contains,omitempty"`
+	Metadata      map[string]string `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Группа ошибок из DLQSummary: позволяет удалить или вернуть в очередь всю группу
+	ErrorGroup    string `protobuf:"bytes,5,opt,name=error_group,json=errorGroup,proto3" json:"error_group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DLQFilter) Reset() {
+	*x = DLQFilter{}
+	mi := &file_proto_task_executor_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DLQFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DLQFilter) ProtoMessage() {}
+
+func (x *DLQFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DLQFilter.ProtoReflect.Descriptor instead.
+func (*DLQFilter) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *DLQFilter) GetFailedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FailedAfter
+	}
+	return nil
+}
+
+func (x *DLQFilter) GetFailedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FailedBefore
+	}
+	return nil
+}
+
+func (x *DLQFilter) GetErrorContains() string {
+	if x != nil {
+		return x.ErrorContains
+	}
+	return ""
+}
+
+func (x *DLQFilter) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *DLQFilter) GetErrorGroup() string {
+	if x != nil {
+		return x.ErrorGroup
+	}
+	return ""
+}
+
+// Задачи DLQ от новых к старым. Указывается обработчик, очередь или оба:
+// очередь может быть общей для нескольких обработчиков
+type ListDLQTasksRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ExecutorName string                 `protobuf:"bytes,1,opt,name=executor_name,json=executorName,proto3" json:"executor_name,omitempty"`
+	Filter       *DLQFilter             `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// По умолчанию 50, не более 500
+	PageSize      int32  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	QueueName     string `protobuf:"bytes,5,opt,name=queue_name,json=queueName,proto3" json:"queue_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDLQTasksRequest) Reset() {
+	*x = ListDLQTasksRequest{}
+	mi := &file_proto_task_executor_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDLQTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDLQTasksRequest) ProtoMessage() {}
+
+func (x *ListDLQTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDLQTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListDLQTasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ListDLQTasksRequest) GetExecutorName() string {
+	if x != nil {
+		return x.ExecutorName
+	}
+	return ""
+}
+
+func (x *ListDLQTasksRequest) GetFilter() *DLQFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListDLQTasksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListDLQTasksRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListDLQTasksRequest) GetQueueName() string {
+	if x != nil {
+		return x.QueueName
+	}
+	return ""
+}
+
+type ListDLQTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDLQTasksResponse) Reset() {
+	*x = ListDLQTasksResponse{}
+	mi := &file_proto_task_executor_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDLQTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDLQTasksResponse) ProtoMessage() {}
+
+func (x *ListDLQTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDLQTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListDLQTasksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ListDLQTasksResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *ListDLQTasksResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Удаление задач DLQ, подходящих под фильтр (без фильтра — всех).
+// Обработчик и очередь указываются как в ListDLQTasksRequest
+type PurgeDLQRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExecutorName  string                 `protobuf:"bytes,1,opt,name=executor_name,json=executorName,proto3" json:"executor_name,omitempty"`
+	Filter        *DLQFilter             `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	QueueName     string                 `protobuf:"bytes,3,opt,name=queue_name,json=queueName,proto3" json:"queue_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeDLQRequest) Reset() {
+	*x = PurgeDLQRequest{}
+	mi := &file_proto_task_executor_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeDLQRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDLQRequest) ProtoMessage() {}
+
+func (x *PurgeDLQRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDLQRequest.ProtoReflect.Descriptor instead.
+func (*PurgeDLQRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *PurgeDLQRequest) GetExecutorName() string {
+	if x != nil {
+		return x.ExecutorName
+	}
+	return ""
+}
+
+func (x *PurgeDLQRequest) GetFilter() *DLQFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *PurgeDLQRequest) GetQueueName() string {
+	if x != nil {
+		return x.QueueName
+	}
+	return ""
+}
+
+type PurgeDLQResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PurgedCount   int64                  `protobuf:"varint,1,opt,name=purged_count,json=purgedCount,proto3" json:"purged_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeDLQResponse) Reset() {
+	*x = PurgeDLQResponse{}
+	mi := &file_proto_task_executor_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeDLQResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDLQResponse) ProtoMessage() {}
+
+func (x *PurgeDLQResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDLQResponse.ProtoReflect.Descriptor instead.
+func (*PurgeDLQResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *PurgeDLQResponse) GetPurgedCount() int64 {
+	if x != nil {
+		return x.PurgedCount
+	}
+	return 0
+}
+
+// Возврат задач из DLQ в очередь: задачи снова становятся pending, retry_count
+// сбрасывается, в историю задачи добавляется запись о возврате.
+// Возвращаются задачи из task_ids, а если список пуст — все, подходящие под filter.
+// Обработчик и очередь указываются как в ListDLQTasksRequest
+type RedriveDLQRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ExecutorName string                 `protobuf:"bytes,1,opt,name=executor_name,json=executorName,proto3" json:"executor_name,omitempty"`
+	TaskIds      []string               `protobuf:"bytes,2,rep,name=task_ids,json=taskIds,proto3" json:"task_ids,omitempty"`
+	Filter       *DLQFilter             `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// JSON Merge Patch (RFC 7386), применяемый к данным каждой задачи
+	DataPatch string `protobuf:"bytes,4,opt,name=data_patch,json=dataPatch,proto3" json:"data_patch,omitempty"`
+	// Обработчик, в очередь которого вернуть задачи. Пустое значение — исходный
+	TargetExecutor string `protobuf:"bytes,5,opt,name=target_executor,json=targetExecutor,proto3" json:"target_executor,omitempty"`
+	// Не более rate_per_second задач в секунду. 0 — без ограничения
+	RatePerSecond float64 `protobuf:"fixed64,6,opt,name=rate_per_second,json=ratePerSecond,proto3" json:"rate_per_second,omitempty"`
+	// Комментарий, сохраняется в истории задачи
+	Reason    string `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+	QueueName string `protobuf:"bytes,8,opt,name=queue_name,json=queueName,proto3" json:"queue_name,omitempty"`
+	// next_page_token предыдущего вызова, чтобы продолжить возврат
+	PageToken     string `protobuf:"bytes,9,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedriveDLQRequest) Reset() {
+	*x = RedriveDLQRequest{}
+	mi := &file_proto_task_executor_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedriveDLQRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedriveDLQRequest) ProtoMessage() {}
+
+func (x *RedriveDLQRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedriveDLQRequest.ProtoReflect.Descriptor instead.
+func (*RedriveDLQRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *RedriveDLQRequest) GetExecutorName() string {
+	if x != nil {
+		return x.ExecutorName
+	}
+	return ""
+}
+
+func (x *RedriveDLQRequest) GetTaskIds() []string {
+	if x != nil {
+		return x.TaskIds
+	}
+	return nil
+}
+
+func (x *RedriveDLQRequest) GetFilter() *DLQFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *RedriveDLQRequest) GetDataPatch() string {
+	if x != nil {
+		return x.DataPatch
+	}
+	return ""
+}
+
+func (x *RedriveDLQRequest) GetTargetExecutor() string {
+	if x != nil {
+		return x.TargetExecutor
+	}
+	return ""
+}
+
+func (x *RedriveDLQRequest) GetRatePerSecond() float64 {
+	if x != nil {
+		return x.RatePerSecond
+	}
+	return 0
+}
+
+func (x *RedriveDLQRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RedriveDLQRequest) GetQueueName() string {
+	if x != nil {
+		return x.QueueName
+	}
+	return ""
+}
+
+func (x *RedriveDLQRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type RedriveDLQResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RedrivenCount int64                  `protobuf:"varint,1,opt,name=redriven_count,json=redrivenCount,proto3" json:"redriven_count,omitempty"`
+	// Задачи, оставшиеся в DLQ: в обработчике уже есть задача с тем же
+	// idempotency_key (возможно только при возврате в исходный обработчик)
+	DuplicateTaskIds []string `protobuf:"bytes,2,rep,name=duplicate_task_ids,json=duplicateTaskIds,proto3" json:"duplicate_task_ids,omitempty"`
+	// Непустой, если вызов остановился по ограничению времени, а подходящие
+	// задачи ещё могут остаться: повторите запрос с page_token = next_page_token
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedriveDLQResponse) Reset() {
+	*x = RedriveDLQResponse{}
+	mi := &file_proto_task_executor_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedriveDLQResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedriveDLQResponse) ProtoMessage() {}
+
+func (x *RedriveDLQResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedriveDLQResponse.ProtoReflect.Descriptor instead.
+func (*RedriveDLQResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *RedriveDLQResponse) GetRedrivenCount() int64 {
+	if x != nil {
+		return x.RedrivenCount
+	}
+	return 0
+}
+
+func (x *RedriveDLQResponse) GetDuplicateTaskIds() []string {
+	if x != nil {
+		return x.DuplicateTaskIds
+	}
+	return nil
+}
+
+func (x *RedriveDLQResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Именованная очередь DLQ
+type DLQ struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Size  int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// Обработчики, задачи которых лежат в очереди
+	Executors []string `protobuf:"bytes,3,rep,name=executors,proto3" json:"executors,omitempty"`
+	// Когда в очередь попала самая старая задача
+	OldestEntryAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=oldest_entry_at,json=oldestEntryAt,proto3" json:"oldest_entry_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DLQ) Reset() {
+	*x = DLQ{}
+	mi := &file_proto_task_executor_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DLQ) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DLQ) ProtoMessage() {}
+
+func (x *DLQ) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DLQ.ProtoReflect.Descriptor instead.
+func (*DLQ) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *DLQ) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DLQ) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *DLQ) GetExecutors() []string {
+	if x != nil {
+		return x.Executors
+	}
+	return nil
+}
+
+func (x *DLQ) GetOldestEntryAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OldestEntryAt
+	}
+	return nil
+}
+
+// Непустые очереди DLQ
+type ListDLQsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDLQsRequest) Reset() {
+	*x = ListDLQsRequest{}
+	mi := &file_proto_task_executor_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDLQsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDLQsRequest) ProtoMessage() {}
+
+func (x *ListDLQsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDLQsRequest.ProtoReflect.Descriptor instead.
+func (*ListDLQsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{61}
+}
+
+type ListDLQsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Queues        []*DLQ                 `protobuf:"bytes,1,rep,name=queues,proto3" json:"queues,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDLQsResponse) Reset() {
+	*x = ListDLQsResponse{}
+	mi := &file_proto_task_executor_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDLQsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDLQsResponse) ProtoMessage() {}
+
+func (x *ListDLQsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDLQsResponse.ProtoReflect.Descriptor instead.
+func (*ListDLQsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ListDLQsResponse) GetQueues() []*DLQ {
+	if x != nil {
+		return x.Queues
+	}
+	return nil
+}
+
+type GetDLQRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDLQRequest) Reset() {
+	*x = GetDLQRequest{}
+	mi := &file_proto_task_executor_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDLQRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDLQRequest) ProtoMessage() {}
+
+func (x *GetDLQRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDLQRequest.ProtoReflect.Descriptor instead.
+func (*GetDLQRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *GetDLQRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetDLQResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Queue         *DLQ                   `protobuf:"bytes,1,opt,name=queue,proto3" json:"queue,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDLQResponse) Reset() {
+	*x = GetDLQResponse{}
+	mi := &file_proto_task_executor_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDLQResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDLQResponse) ProtoMessage() {}
+
+func (x *GetDLQResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDLQResponse.ProtoReflect.Descriptor instead.
+func (*GetDLQResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *GetDLQResponse) GetQueue() *DLQ {
+	if x != nil {
+		return x.Queue
+	}
+	return nil
+}
+
+// Сводка DLQ: задачи сгруппированы по обработчику и нормализованному тексту ошибки
+// (идентификаторы и числа заменены на <id> и <n>). Группы отсортированы по убыванию размера
+type DLQSummaryRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ExecutorName string                 `protobuf:"bytes,1,opt,name=executor_name,json=executorName,proto3" json:"executor_name,omitempty"`
+	QueueName    string                 `protobuf:"bytes,2,opt,name=queue_name,json=queueName,proto3" json:"queue_name,omitempty"`
+	Filter       *DLQFilter             `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Число примеров задач в группе, по умолчанию 5, не более 100
+	SampleSize    int32 `protobuf:"varint,4,opt,name=sample_size,json=sampleSize,proto3" json:"sample_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DLQSummaryRequest) Reset() {
+	*x = DLQSummaryRequest{}
+	mi := &file_proto_task_executor_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DLQSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DLQSummaryRequest) ProtoMessage() {}
+
+func (x *DLQSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DLQSummaryRequest.ProtoReflect.Descriptor instead.
+func (*DLQSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *DLQSummaryRequest) GetExecutorName() string {
+	if x != nil {
+		return x.ExecutorName
+	}
+	return ""
+}
+
+func (x *DLQSummaryRequest) GetQueueName() string {
+	if x != nil {
+		return x.QueueName
+	}
+	return ""
+}
+
+func (x *DLQSummaryRequest) GetFilter() *DLQFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *DLQSummaryRequest) GetSampleSize() int32 {
+	if x != nil {
+		return x.SampleSize
+	}
+	return 0
+}
+
+type DLQSummaryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Groups        []*DLQErrorGroup       `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DLQSummaryResponse) Reset() {
+	*x = DLQSummaryResponse{}
+	mi := &file_proto_task_executor_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DLQSummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DLQSummaryResponse) ProtoMessage() {}
+
+func (x *DLQSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DLQSummaryResponse.ProtoReflect.Descriptor instead.
+func (*DLQSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *DLQSummaryResponse) GetGroups() []*DLQErrorGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type DLQErrorGroup struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ExecutorName string                 `protobuf:"bytes,1,opt,name=executor_name,json=executorName,proto3" json:"executor_name,omitempty"`
+	// Нормализованная ошибка, подставляется в DLQFilter.error_group
+	ErrorGroup string                 `protobuf:"bytes,2,opt,name=error_group,json=errorGroup,proto3" json:"error_group,omitempty"`
+	Count      int64                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	FirstSeen  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
+	LastSeen   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	// Последние попавшие в DLQ задачи группы
+	SampleTaskIds []string `protobuf:"bytes,6,rep,name=sample_task_ids,json=sampleTaskIds,proto3" json:"sample_task_ids,omitempty"`
+	// Исходный текст ошибки одной из них
+	SampleError   string `protobuf:"bytes,7,opt,name=sample_error,json=sampleError,proto3" json:"sample_error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DLQErrorGroup) Reset() {
+	*x = DLQErrorGroup{}
+	mi := &file_proto_task_executor_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DLQErrorGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DLQErrorGroup) ProtoMessage() {}
+
+func (x *DLQErrorGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DLQErrorGroup.ProtoReflect.Descriptor instead.
+func (*DLQErrorGroup) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *DLQErrorGroup) GetExecutorName() string {
+	if x != nil {
+		return x.ExecutorName
+	}
+	return ""
+}
+
+func (x *DLQErrorGroup) GetErrorGroup() string {
+	if x != nil {
+		return x.ErrorGroup
+	}
+	return ""
+}
+
+func (x *DLQErrorGroup) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *DLQErrorGroup) GetFirstSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return nil
+}
+
+func (x *DLQErrorGroup) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+func (x *DLQErrorGroup) GetSampleTaskIds() []string {
+	if x != nil {
+		return x.SampleTaskIds
+	}
+	return nil
+}
+
+func (x *DLQErrorGroup) GetSampleError() string {
+	if x != nil {
+		return x.SampleError
+	}
+	return ""
+}
+
+// Schedule Messages
+type CreateScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedule      *Schedule              `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateScheduleRequest) Reset() {
+	*x = CreateScheduleRequest{}
+	mi := &file_proto_task_executor_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateScheduleRequest) ProtoMessage() {}
+
+func (x *CreateScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateScheduleRequest.ProtoReflect.Descriptor instead.
+func (*CreateScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *CreateScheduleRequest) GetSchedule() *Schedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+type CreateScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedule      *Schedule              `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateScheduleResponse) Reset() {
+	*x = CreateScheduleResponse{}
+	mi := &file_proto_task_executor_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateScheduleResponse) ProtoMessage() {}
+
+func (x *CreateScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateScheduleResponse.ProtoReflect.Descriptor instead.
+func (*CreateScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *CreateScheduleResponse) GetSchedule() *Schedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+type ListSchedulesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Пустое значение — расписания всех обработчиков
+	ExecutorName  string `protobuf:"bytes,1,opt,name=executor_name,json=executorName,proto3" json:"executor_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchedulesRequest) Reset() {
+	*x = ListSchedulesRequest{}
+	mi := &file_proto_task_executor_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchedulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchedulesRequest) ProtoMessage() {}
+
+func (x *ListSchedulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchedulesRequest.ProtoReflect.Descriptor instead.
+func (*ListSchedulesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *ListSchedulesRequest) GetExecutorName() string {
+	if x != nil {
+		return x.ExecutorName
+	}
+	return ""
+}
+
+type ListSchedulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedules     []*Schedule            `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchedulesResponse) Reset() {
+	*x = ListSchedulesResponse{}
+	mi := &file_proto_task_executor_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchedulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchedulesResponse) ProtoMessage() {}
+
+func (x *ListSchedulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchedulesResponse.ProtoReflect.Descriptor instead.
+func (*ListSchedulesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *ListSchedulesResponse) GetSchedules() []*Schedule {
+	if x != nil {
+		return x.Schedules
+	}
+	return nil
+}
+
+type DeleteScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteScheduleRequest) Reset() {
+	*x = DeleteScheduleRequest{}
+	mi := &file_proto_task_executor_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteScheduleRequest) ProtoMessage() {}
+
+func (x *DeleteScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteScheduleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *DeleteScheduleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteScheduleResponse) Reset() {
+	*x = DeleteScheduleResponse{}
+	mi := &file_proto_task_executor_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteScheduleResponse) ProtoMessage() {}
+
+func (x *DeleteScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteScheduleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{73}
+}
+
+// Приостановка (paused = true) или возобновление (paused = false) расписания
+type PauseScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Paused        bool                   `protobuf:"varint,2,opt,name=paused,proto3" json:"paused,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseScheduleRequest) Reset() {
+	*x = PauseScheduleRequest{}
+	mi := &file_proto_task_executor_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseScheduleRequest) ProtoMessage() {}
+
+func (x *PauseScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseScheduleRequest.ProtoReflect.Descriptor instead.
+func (*PauseScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *PauseScheduleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PauseScheduleRequest) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+type PauseScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedule      *Schedule              `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseScheduleResponse) Reset() {
+	*x = PauseScheduleResponse{}
+	mi := &file_proto_task_executor_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseScheduleResponse) ProtoMessage() {}
+
+func (x *PauseScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseScheduleResponse.ProtoReflect.Descriptor instead.
+func (*PauseScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *PauseScheduleResponse) GetSchedule() *Schedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+// Common Messages
+type Executor struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Enabled   bool                   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Config    *ExecutorConfig        `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	State     ExecutorState          `protobuf:"varint,7,opt,name=state,proto3,enum=taskexecutor.ExecutorState" json:"state,omitempty"`
+	// Задано только у удалённых обработчиков (ListExecutors с deleted)
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Executor) Reset() {
+	*x = Executor{}
+	mi := &file_proto_task_executor_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Executor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Executor) ProtoMessage() {}
+
+func (x *Executor) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Executor.ProtoReflect.Descriptor instead.
+func (*Executor) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *Executor) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Executor) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Executor) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Executor) GetConfig() *ExecutorConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *Executor) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Executor) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Executor) GetState() ExecutorState {
+	if x != nil {
+		return x.State
+	}
+	return ExecutorState_EXECUTOR_STATE_UNSPECIFIED
+}
+
+func (x *Executor) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+type ExecutorConfig struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	WriteConcern  *WriteConcern          `protobuf:"bytes,3,opt,name=write_concern,json=writeConcern,proto3" json:"write_concern,omitempty"`
+	RetryPolicy   *RetryPolicy           `protobuf:"bytes,4,opt,name=retry_policy,json=retryPolicy,proto3" json:"retry_policy,omitempty"`
+	DlqConfig     *DLQConfig             `protobuf:"bytes,5,opt,name=dlq_config,json=dlqConfig,proto3" json:"dlq_config,omitempty"`
+	LeaseDuration *durationpb.Duration   `protobuf:"bytes,6,opt,name=lease_duration,json=leaseDuration,proto3" json:"lease_duration,omitempty"`
+	PriorityAging *PriorityAging         `protobuf:"bytes,7,opt,name=priority_aging,json=priorityAging,proto3" json:"priority_aging,omitempty"`
+	// Сколько помнить idempotency_key задач. Пустое значение — 24 часа
+	DedupWindow *durationpb.Duration `protobuf:"bytes,8,opt,name=dedup_window,json=dedupWindow,proto3" json:"dedup_window,omitempty"`
+	// Сколько задач обработчика может выполняться одновременно на всех процессах. 0 — без ограничения
+	MaxInFlight int32      `protobuf:"varint,9,opt,name=max_in_flight,json=maxInFlight,proto3" json:"max_in_flight,omitempty"`
+	RateLimit   *RateLimit `protobuf:"bytes,10,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit,omitempty"`
+	// Не задано — определяется по enabled: EXECUTOR_STATE_ACTIVE или
+	// EXECUTOR_STATE_DISABLED (при обновлении включённого обработчика
+	// сохраняется текущее состояние). Если задано, enabled игнорируется
+	State ExecutorState `protobuf:"varint,11,opt,name=state,proto3,enum=taskexecutor.ExecutorState" json:"state,omitempty"`
+	// Увеличивается при каждом изменении обработчика, задаётся менеджером
+	Version       int64 `protobuf:"varint,12,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecutorConfig) Reset() {
+	*x = ExecutorConfig{}
+	mi := &file_proto_task_executor_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutorConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutorConfig) ProtoMessage() {}
+
+func (x *ExecutorConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecutorConfig.ProtoReflect.Descriptor instead.
+func (*ExecutorConfig) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *ExecutorConfig) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExecutorConfig) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *ExecutorConfig) GetWriteConcern() *WriteConcern {
+	if x != nil {
+		return x.WriteConcern
+	}
+	return nil
+}
+
+func (x *ExecutorConfig) GetRetryPolicy() *RetryPolicy {
+	if x != nil {
+		return x.RetryPolicy
+	}
+	return nil
+}
+
+func (x *ExecutorConfig) GetDlqConfig() *DLQConfig {
+	if x != nil {
+		return x.DlqConfig
+	}
+	return nil
+}
+
+func (x *ExecutorConfig) GetLeaseDuration() *durationpb.Duration {
+	if x != nil {
+		return x.LeaseDuration
+	}
+	return nil
+}
+
+func (x *ExecutorConfig) GetPriorityAging() *PriorityAging {
+	if x != nil {
+		return x.PriorityAging
+	}
+	return nil
+}
+
+func (x *ExecutorConfig) GetDedupWindow() *durationpb.Duration {
+	if x != nil {
+		return x.DedupWindow
+	}
+	return nil
+}
+
+func (x *ExecutorConfig) GetMaxInFlight() int32 {
+	if x != nil {
+		return x.MaxInFlight
+	}
+	return 0
+}
+
+func (x *ExecutorConfig) GetRateLimit() *RateLimit {
+	if x != nil {
+		return x.RateLimit
+	}
+	return nil
+}
+
+func (x *ExecutorConfig) GetState() ExecutorState {
+	if x != nil {
+		return x.State
+	}
+	return ExecutorState_EXECUTOR_STATE_UNSPECIFIED
+}
+
+func (x *ExecutorConfig) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// Ограничение скорости выдачи задач (token bucket), общее для всех процессов
+// и реплик менеджера: в среднем не больше tasks_per_second задач в секунду,
+// подряд — не больше burst. Пустой burst — tasks_per_second, округлённое вверх.
+// Отключено, если tasks_per_second не задан.
+type RateLimit struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TasksPerSecond float64                `protobuf:"fixed64,1,opt,name=tasks_per_second,json=tasksPerSecond,proto3" json:"tasks_per_second,omitempty"`
+	Burst          int32                  `protobuf:"varint,2,opt,name=burst,proto3" json:"burst,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RateLimit) Reset() {
+	*x = RateLimit{}
+	mi := &file_proto_task_executor_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateLimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateLimit) ProtoMessage() {}
+
+func (x *RateLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateLimit.ProtoReflect.Descriptor instead.
+func (*RateLimit) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *RateLimit) GetTasksPerSecond() float64 {
+	if x != nil {
+		return x.TasksPerSecond
+	}
+	return 0
+}
+
+func (x *RateLimit) GetBurst() int32 {
+	if x != nil {
+		return x.Burst
+	}
+	return 0
+}
+
+// Старение приоритета: задача, ожидающая дольше threshold, получает +step
+// к приоритету (но не выше max_priority), и так каждые threshold.
+// Отключено, если threshold или step не заданы; иначе max_priority обязателен.
+type PriorityAging struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Threshold     *durationpb.Duration   `protobuf:"bytes,1,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	Step          int32                  `protobuf:"varint,2,opt,name=step,proto3" json:"step,omitempty"`
+	MaxPriority   int32                  `protobuf:"varint,3,opt,name=max_priority,json=maxPriority,proto3" json:"max_priority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriorityAging) Reset() {
+	*x = PriorityAging{}
+	mi := &file_proto_task_executor_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriorityAging) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriorityAging) ProtoMessage() {}
+
+func (x *PriorityAging) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriorityAging.ProtoReflect.Descriptor instead.
+func (*PriorityAging) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *PriorityAging) GetThreshold() *durationpb.Duration {
+	if x != nil {
+		return x.Threshold
+	}
+	return nil
+}
+
+func (x *PriorityAging) GetStep() int32 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+func (x *PriorityAging) GetMaxPriority() int32 {
+	if x != nil {
+		return x.MaxPriority
+	}
+	return 0
+}
+
+type WriteConcern struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         WriteConcernLevel      `protobuf:"varint,1,opt,name=level,proto3,enum=taskexecutor.WriteConcernLevel" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteConcern) Reset() {
+	*x = WriteConcern{}
+	mi := &file_proto_task_executor_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteConcern) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteConcern) ProtoMessage() {}
+
+func (x *WriteConcern) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteConcern.ProtoReflect.Descriptor instead.
+func (*WriteConcern) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *WriteConcern) GetLevel() WriteConcernLevel {
+	if x != nil {
+		return x.Level
+	}
+	return WriteConcernLevel_WRITE_CONCERN_LEVEL_UNSPECIFIED
+}
+
+type RetryPolicy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          RetryPolicyType        `protobuf:"varint,1,opt,name=type,proto3,enum=taskexecutor.RetryPolicyType" json:"type,omitempty"`
+	MaxAttempts   int32                  `protobuf:"varint,2,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	Interval      *durationpb.Duration   `protobuf:"bytes,3,opt,name=interval,proto3" json:"interval,omitempty"`
+	MaxInterval   *durationpb.Duration   `protobuf:"bytes,4,opt,name=max_interval,json=maxInterval,proto3" json:"max_interval,omitempty"`
+	Jitter        float64                `protobuf:"fixed64,5,opt,name=jitter,proto3" json:"jitter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryPolicy) Reset() {
+	*x = RetryPolicy{}
+	mi := &file_proto_task_executor_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryPolicy) ProtoMessage() {}
+
+func (x *RetryPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryPolicy.ProtoReflect.Descriptor instead.
+func (*RetryPolicy) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *RetryPolicy) GetType() RetryPolicyType {
+	if x != nil {
+		return x.Type
+	}
+	return RetryPolicyType_RETRY_POLICY_TYPE_UNSPECIFIED
+}
+
+func (x *RetryPolicy) GetMaxAttempts() int32 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+func (x *RetryPolicy) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *RetryPolicy) GetMaxInterval() *durationpb.Duration {
+	if x != nil {
+		return x.MaxInterval
+	}
+	return nil
+}
+
+func (x *RetryPolicy) GetJitter() float64 {
+	if x != nil {
+		return x.Jitter
+	}
+	return 0
+}
+
+type DLQConfig struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Enabled bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Имя очереди, может быть общим для нескольких обработчиков. Пустое значение — имя обработчика
+	QueueName string `protobuf:"bytes,2,opt,name=queue_name,json=queueName,proto3" json:"queue_name,omitempty"`
+	// Обработчик ошибок: получает копию каждой попавшей в DLQ задачи
+	// с метаданными dlq.task_id, dlq.executor, dlq.queue и dlq.error
+	FailureHandler string `protobuf:"bytes,3,opt,name=failure_handler,json=failureHandler,proto3" json:"failure_handler,omitempty"`
+	// Срок хранения задачи в DLQ. Пустое значение — без ограничения
+	MaxAge *durationpb.Duration `protobuf:"bytes,4,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	// Сколько задач обработчика хранить в DLQ, лишние удаляются начиная со старых. 0 — без ограничения
+	MaxEntries int64 `protobuf:"varint,5,opt,name=max_entries,json=maxEntries,proto3" json:"max_entries,omitempty"`
+	// Перед удалением по max_age или max_entries дописывать задачи в NDJSON-файл
+	// в каталоге DLQ_ARCHIVE_DIR менеджера
+	Archive       bool `protobuf:"varint,6,opt,name=archive,proto3" json:"archive,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DLQConfig) Reset() {
+	*x = DLQConfig{}
+	mi := &file_proto_task_executor_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DLQConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DLQConfig) ProtoMessage() {}
+
+func (x *DLQConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DLQConfig.ProtoReflect.Descriptor instead.
+func (*DLQConfig) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *DLQConfig) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *DLQConfig) GetQueueName() string {
+	if x != nil {
+		return x.QueueName
+	}
+	return ""
+}
+
+func (x *DLQConfig) GetFailureHandler() string {
+	if x != nil {
+		return x.FailureHandler
 	}
-	return false
+	return ""
 }
 
-func (x *ExecutorConfig) GetWriteConcern() *WriteConcern {
+func (x *DLQConfig) GetMaxAge() *durationpb.Duration {
 	if x != nil {
-		return x.WriteConcern
+		return x.MaxAge
 	}
 	return nil
 }
 
-func (x *ExecutorConfig) GetRetryPolicy() *RetryPolicy {
+func (x *DLQConfig) GetMaxEntries() int64 {
 	if x != nil {
-		return x.RetryPolicy
+		return x.MaxEntries
 	}
-	return nil
+	return 0
 }
 
-func (x *ExecutorConfig) GetDlqConfig() *DLQConfig {
+func (x *DLQConfig) GetArchive() bool {
 	if x != nil {
-		return x.DlqConfig
+		return x.Archive
 	}
-	return nil
+	return false
 }
 
-type WriteConcern struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Level         WriteConcernLevel      `protobuf:"varint,1,opt,name=level,proto3,enum=taskexecutor.WriteConcernLevel" json:"level,omitempty"`
+// Расписание, по которому задачи ставятся в очередь обработчика
+type Schedule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Cron-выражение из пяти полей (минута, час, день месяца, месяц, день недели)
+	// или дескриптор вида @hourly, @daily, @every 10m
+	CronExpression string `protobuf:"bytes,3,opt,name=cron_expression,json=cronExpression,proto3" json:"cron_expression,omitempty"`
+	// Часовой пояс IANA, например Europe/Moscow. Пустое значение — UTC
+	Timezone     string `protobuf:"bytes,4,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	ExecutorName string `protobuf:"bytes,5,opt,name=executor_name,json=executorName,proto3" json:"executor_name,omitempty"`
+	// Шаблон данных задачи (text/template), доступны {{.ScheduledAt}} и {{.ScheduleName}}
+	Data          []byte                 `protobuf:"bytes,6,opt,name=data,proto3" json:"data,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Paused        bool                   `protobuf:"varint,8,opt,name=paused,proto3" json:"paused,omitempty"`
+	NextRunAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=next_run_at,json=nextRunAt,proto3" json:"next_run_at,omitempty"`
+	LastRunAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_run_at,json=lastRunAt,proto3" json:"last_run_at,omitempty"`
+	LastTaskId    string                 `protobuf:"bytes,11,opt,name=last_task_id,json=lastTaskId,proto3" json:"last_task_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *WriteConcern) Reset() {
-	*x = WriteConcern{}
-	mi := &file_proto_task_executor_proto_msgTypes[22]
+func (x *Schedule) Reset() {
+	*x = Schedule{}
+	mi := &file_proto_task_executor_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WriteConcern) String() string {
+func (x *Schedule) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WriteConcern) ProtoMessage() {}
+func (*Schedule) ProtoMessage() {}
 
-func (x *WriteConcern) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_executor_proto_msgTypes[22]
+func (x *Schedule) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1329,150 +5408,136 @@ func (x *WriteConcern) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WriteConcern.ProtoReflect.Descriptor instead.
-func (*WriteConcern) Descriptor() ([]byte, []int) {
-	return file_proto_task_executor_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use Schedule.ProtoReflect.Descriptor instead.
+func (*Schedule) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{83}
 }
 
-func (x *WriteConcern) GetLevel() WriteConcernLevel {
+func (x *Schedule) GetId() string {
 	if x != nil {
-		return x.Level
+		return x.Id
 	}
-	return WriteConcernLevel_WRITE_CONCERN_LEVEL_UNSPECIFIED
-}
-
-type RetryPolicy struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          RetryPolicyType        `protobuf:"varint,1,opt,name=type,proto3,enum=taskexecutor.RetryPolicyType" json:"type,omitempty"`
-	MaxAttempts   int32                  `protobuf:"varint,2,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
-	Interval      *durationpb.Duration   `protobuf:"bytes,3,opt,name=interval,proto3" json:"interval,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *RetryPolicy) Reset() {
-	*x = RetryPolicy{}
-	mi := &file_proto_task_executor_proto_msgTypes[23]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *RetryPolicy) String() string {
-	return protoimpl.X.MessageStringOf(x)
+	return ""
 }
 
-func (*RetryPolicy) ProtoMessage() {}
-
-func (x *RetryPolicy) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_executor_proto_msgTypes[23]
+func (x *Schedule) GetName() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Name
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use RetryPolicy.ProtoReflect.Descriptor instead.
-func (*RetryPolicy) Descriptor() ([]byte, []int) {
-	return file_proto_task_executor_proto_rawDescGZIP(), []int{23}
+func (x *Schedule) GetCronExpression() string {
+	if x != nil {
+		return x.CronExpression
+	}
+	return ""
 }
 
-func (x *RetryPolicy) GetType() RetryPolicyType {
+func (x *Schedule) GetTimezone() string {
 	if x != nil {
-		return x.Type
+		return x.Timezone
 	}
-	return RetryPolicyType_RETRY_POLICY_TYPE_UNSPECIFIED
+	return ""
 }
 
-func (x *RetryPolicy) GetMaxAttempts() int32 {
+func (x *Schedule) GetExecutorName() string {
 	if x != nil {
-		return x.MaxAttempts
+		return x.ExecutorName
 	}
-	return 0
+	return ""
 }
 
-func (x *RetryPolicy) GetInterval() *durationpb.Duration {
+func (x *Schedule) GetData() []byte {
 	if x != nil {
-		return x.Interval
+		return x.Data
 	}
 	return nil
 }
 
-type DLQConfig struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
-	QueueName     string                 `protobuf:"bytes,2,opt,name=queue_name,json=queueName,proto3" json:"queue_name,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Schedule) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
 }
 
-func (x *DLQConfig) Reset() {
-	*x = DLQConfig{}
-	mi := &file_proto_task_executor_proto_msgTypes[24]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Schedule) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
 }
 
-func (x *DLQConfig) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Schedule) GetNextRunAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextRunAt
+	}
+	return nil
 }
 
-func (*DLQConfig) ProtoMessage() {}
-
-func (x *DLQConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_executor_proto_msgTypes[24]
+func (x *Schedule) GetLastRunAt() *timestamppb.Timestamp {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.LastRunAt
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use DLQConfig.ProtoReflect.Descriptor instead.
-func (*DLQConfig) Descriptor() ([]byte, []int) {
-	return file_proto_task_executor_proto_rawDescGZIP(), []int{24}
+func (x *Schedule) GetLastTaskId() string {
+	if x != nil {
+		return x.LastTaskId
+	}
+	return ""
 }
 
-func (x *DLQConfig) GetEnabled() bool {
+func (x *Schedule) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Enabled
+		return x.CreatedAt
 	}
-	return false
+	return nil
 }
 
-func (x *DLQConfig) GetQueueName() string {
+func (x *Schedule) GetUpdatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.QueueName
+		return x.UpdatedAt
 	}
-	return ""
+	return nil
 }
 
 type Task struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ExecutorName  string                 `protobuf:"bytes,2,opt,name=executor_name,json=executorName,proto3" json:"executor_name,omitempty"`
-	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
-	Metadata      map[string]string      `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Status        TaskStatus             `protobuf:"varint,5,opt,name=status,proto3,enum=taskexecutor.TaskStatus" json:"status,omitempty"`
-	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
-	RetryCount    int32                  `protobuf:"varint,7,opt,name=retry_count,json=retryCount,proto3" json:"retry_count,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
-	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExecutorName    string                 `protobuf:"bytes,2,opt,name=executor_name,json=executorName,proto3" json:"executor_name,omitempty"`
+	Data            []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Metadata        map[string]string      `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Status          TaskStatus             `protobuf:"varint,5,opt,name=status,proto3,enum=taskexecutor.TaskStatus" json:"status,omitempty"`
+	Error           string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	RetryCount      int32                  `protobuf:"varint,7,opt,name=retry_count,json=retryCount,proto3" json:"retry_count,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	StartedAt       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	NextAttemptAt   *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"`
+	LeaseExpiresAt  *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=lease_expires_at,json=leaseExpiresAt,proto3" json:"lease_expires_at,omitempty"`
+	Progress        *TaskProgress          `protobuf:"bytes,14,opt,name=progress,proto3" json:"progress,omitempty"`
+	RunAt           *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=run_at,json=runAt,proto3" json:"run_at,omitempty"`
+	Priority        int32                  `protobuf:"varint,16,opt,name=priority,proto3" json:"priority,omitempty"`
+	IdempotencyKey  string                 `protobuf:"bytes,17,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	CancelRequested bool                   `protobuf:"varint,18,opt,name=cancel_requested,json=cancelRequested,proto3" json:"cancel_requested,omitempty"`
+	Result          []byte                 `protobuf:"bytes,19,opt,name=result,proto3" json:"result,omitempty"`
+	History         []*TaskHistoryEntry    `protobuf:"bytes,20,rep,name=history,proto3" json:"history,omitempty"`
+	DlqName         string                 `protobuf:"bytes,21,opt,name=dlq_name,json=dlqName,proto3" json:"dlq_name,omitempty"`
+	// Нормализованная ошибка задачи в DLQ
+	ErrorGroup string `protobuf:"bytes,22,opt,name=error_group,json=errorGroup,proto3" json:"error_group,omitempty"`
+	// Текущая аренда выполняемой задачи; каждая выдача задачи получает новую
+	LeaseId       string `protobuf:"bytes,23,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Task) Reset() {
 	*x = Task{}
-	mi := &file_proto_task_executor_proto_msgTypes[25]
+	mi := &file_proto_task_executor_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1484,7 +5549,7 @@ func (x *Task) String() string {
 func (*Task) ProtoMessage() {}
 
 func (x *Task) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_executor_proto_msgTypes[25]
+	mi := &file_proto_task_executor_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1497,7 +5562,7 @@ func (x *Task) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Task.ProtoReflect.Descriptor instead.
 func (*Task) Descriptor() ([]byte, []int) {
-	return file_proto_task_executor_proto_rawDescGZIP(), []int{25}
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{84}
 }
 
 func (x *Task) GetId() string {
@@ -1577,124 +5642,182 @@ func (x *Task) GetCompletedAt() *timestamppb.Timestamp {
 	return nil
 }
 
-// Order представляет заказ
-type Order struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Items         []*OrderItem           `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
-	Total         float64                `protobuf:"fixed64,4,opt,name=total,proto3" json:"total,omitempty"`
-	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Task) GetNextAttemptAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextAttemptAt
+	}
+	return nil
 }
 
-func (x *Order) Reset() {
-	*x = Order{}
-	mi := &file_proto_task_executor_proto_msgTypes[26]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Task) GetLeaseExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LeaseExpiresAt
+	}
+	return nil
 }
 
-func (x *Order) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Task) GetProgress() *TaskProgress {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
 }
 
-func (*Order) ProtoMessage() {}
+func (x *Task) GetRunAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RunAt
+	}
+	return nil
+}
 
-func (x *Order) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_executor_proto_msgTypes[26]
+func (x *Task) GetPriority() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Priority
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use Order.ProtoReflect.Descriptor instead.
-func (*Order) Descriptor() ([]byte, []int) {
-	return file_proto_task_executor_proto_rawDescGZIP(), []int{26}
+func (x *Task) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
 }
 
-func (x *Order) GetId() string {
+func (x *Task) GetCancelRequested() bool {
 	if x != nil {
-		return x.Id
+		return x.CancelRequested
+	}
+	return false
+}
+
+func (x *Task) GetResult() []byte {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *Task) GetHistory() []*TaskHistoryEntry {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *Task) GetDlqName() string {
+	if x != nil {
+		return x.DlqName
 	}
 	return ""
 }
 
-func (x *Order) GetUserId() string {
+func (x *Task) GetErrorGroup() string {
 	if x != nil {
-		return x.UserId
+		return x.ErrorGroup
 	}
 	return ""
 }
 
-func (x *Order) GetItems() []*OrderItem {
+func (x *Task) GetLeaseId() string {
 	if x != nil {
-		return x.Items
+		return x.LeaseId
 	}
-	return nil
+	return ""
+}
+
+type TaskHistoryEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Event         string                 `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	At            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=at,proto3" json:"at,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Details       map[string]string      `protobuf:"bytes,4,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskHistoryEntry) Reset() {
+	*x = TaskHistoryEntry{}
+	mi := &file_proto_task_executor_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskHistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Order) GetTotal() float64 {
+func (*TaskHistoryEntry) ProtoMessage() {}
+
+func (x *TaskHistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[85]
 	if x != nil {
-		return x.Total
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskHistoryEntry.ProtoReflect.Descriptor instead.
+func (*TaskHistoryEntry) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{85}
 }
 
-func (x *Order) GetStatus() string {
+func (x *TaskHistoryEntry) GetEvent() string {
 	if x != nil {
-		return x.Status
+		return x.Event
 	}
 	return ""
 }
 
-func (x *Order) GetCreatedAt() *timestamppb.Timestamp {
+func (x *TaskHistoryEntry) GetAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.CreatedAt
+		return x.At
 	}
 	return nil
 }
 
-func (x *Order) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *TaskHistoryEntry) GetMessage() string {
 	if x != nil {
-		return x.UpdatedAt
+		return x.Message
+	}
+	return ""
+}
+
+func (x *TaskHistoryEntry) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
 	}
 	return nil
 }
 
-// OrderItem представляет товар в заказе
-type OrderItem struct {
+type TaskProgress struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	Percent       float64                `protobuf:"fixed64,1,opt,name=percent,proto3" json:"percent,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OrderItem) Reset() {
-	*x = OrderItem{}
-	mi := &file_proto_task_executor_proto_msgTypes[27]
+func (x *TaskProgress) Reset() {
+	*x = TaskProgress{}
+	mi := &file_proto_task_executor_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderItem) String() string {
+func (x *TaskProgress) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderItem) ProtoMessage() {}
+func (*TaskProgress) ProtoMessage() {}
 
-func (x *OrderItem) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_executor_proto_msgTypes[27]
+func (x *TaskProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_executor_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1705,89 +5828,330 @@ func (x *OrderItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
-func (*OrderItem) Descriptor() ([]byte, []int) {
-	return file_proto_task_executor_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use TaskProgress.ProtoReflect.Descriptor instead.
+func (*TaskProgress) Descriptor() ([]byte, []int) {
+	return file_proto_task_executor_proto_rawDescGZIP(), []int{86}
 }
 
-func (x *OrderItem) GetProductId() string {
+func (x *TaskProgress) GetPercent() float64 {
 	if x != nil {
-		return x.ProductId
+		return x.Percent
 	}
-	return ""
+	return 0
 }
 
-func (x *OrderItem) GetQuantity() int32 {
+func (x *TaskProgress) GetMessage() string {
 	if x != nil {
-		return x.Quantity
+		return x.Message
 	}
-	return 0
+	return ""
 }
 
-func (x *OrderItem) GetPrice() float64 {
+func (x *TaskProgress) GetUpdatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Price
+		return x.UpdatedAt
 	}
-	return 0
+	return nil
 }
 
 var File_proto_task_executor_proto protoreflect.FileDescriptor
 
 const file_proto_task_executor_proto_rawDesc = "" +
 	"\n" +
-	"\x19proto/task_executor.proto\x12\ftaskexecutor\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\"\xce\x01\n" +
+	"\x19proto/task_executor.proto\x12\ftaskexecutor\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\x1a google/protobuf/field_mask.proto\"\xf7\x02\n" +
 	"\x0eAddTaskRequest\x12#\n" +
 	"\rexecutor_name\x18\x01 \x01(\tR\fexecutorName\x12\x12\n" +
 	"\x04data\x18\x02 \x01(\fR\x04data\x12F\n" +
-	"\bmetadata\x18\x03 \x03(\v2*.taskexecutor.AddTaskRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\x03 \x03(\v2*.taskexecutor.AddTaskRequest.MetadataEntryR\bmetadata\x121\n" +
+	"\x06run_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x05runAt\x12/\n" +
+	"\x05delay\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x05delay\x12\x1a\n" +
+	"\bpriority\x18\x06 \x01(\x05R\bpriority\x12'\n" +
+	"\x0fidempotency_key\x18\a \x01(\tR\x0eidempotencyKey\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"9\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"]\n" +
 	"\x0fAddTaskResponse\x12&\n" +
-	"\x04task\x18\x01 \x01(\v2\x12.taskexecutor.TaskR\x04task\"&\n" +
+	"\x04task\x18\x01 \x01(\v2\x12.taskexecutor.TaskR\x04task\x12\"\n" +
+	"\fdeduplicated\x18\x02 \x01(\bR\fdeduplicated\"&\n" +
 	"\x14GetTaskStatusRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"_\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"w\n" +
 	"\x15GetTaskStatusResponse\x120\n" +
 	"\x06status\x18\x01 \x01(\x0e2\x18.taskexecutor.TaskStatusR\x06status\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"[\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x16\n" +
+	"\x06result\x18\x03 \x01(\fR\x06result\";\n" +
+	"\x11CancelTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"<\n" +
+	"\x12CancelTaskResponse\x12&\n" +
+	"\x04task\x18\x01 \x01(\v2\x12.taskexecutor.TaskR\x04task\"\xb8\x04\n" +
+	"\x10ListTasksRequest\x12#\n" +
+	"\rexecutor_name\x18\x01 \x01(\tR\fexecutorName\x124\n" +
+	"\bstatuses\x18\x02 \x03(\x0e2\x18.taskexecutor.TaskStatusR\bstatuses\x12?\n" +
+	"\rcreated_after\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12?\n" +
+	"\rupdated_after\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\fupdatedAfter\x12A\n" +
+	"\x0eupdated_before\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\rupdatedBefore\x12H\n" +
+	"\bmetadata\x18\a \x03(\v2,.taskexecutor.ListTasksRequest.MetadataEntryR\bmetadata\x12\x1b\n" +
+	"\tpage_size\x18\b \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\t \x01(\tR\tpageToken\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"e\n" +
+	"\x11ListTasksResponse\x12(\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x12.taskexecutor.TaskR\x05tasks\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xf4\x01\n" +
 	"\x17RegisterExecutorRequest\x12#\n" +
 	"\rexecutor_name\x18\x01 \x01(\tR\fexecutorName\x12\x1b\n" +
-	"\tleader_id\x18\x02 \x01(\tR\bleaderId\"4\n" +
+	"\tleader_id\x18\x02 \x01(\tR\bleaderId\x12\x1b\n" +
+	"\tworker_id\x18\x03 \x01(\tR\bworkerId\x12\x1a\n" +
+	"\bhostname\x18\x04 \x01(\tR\bhostname\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\tR\aversion\x12\"\n" +
+	"\fcapabilities\x18\x06 \x03(\tR\fcapabilities\x12 \n" +
+	"\vconcurrency\x18\a \x01(\x05R\vconcurrency\"\x9b\x01\n" +
 	"\x18RegisterExecutorResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"9\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1b\n" +
+	"\tworker_id\x18\x02 \x01(\tR\bworkerId\x12H\n" +
+	"\x12heartbeat_interval\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x11heartbeatInterval\"X\n" +
+	"\x16HeartbeatWorkerRequest\x12\x1b\n" +
+	"\tworker_id\x18\x01 \x01(\tR\bworkerId\x12!\n" +
+	"\factive_tasks\x18\x02 \x01(\x05R\vactiveTasks\"\x19\n" +
+	"\x17HeartbeatWorkerResponse\"\\\n" +
+	"\x12ListWorkersRequest\x12#\n" +
+	"\rexecutor_name\x18\x01 \x01(\tR\fexecutorName\x12!\n" +
+	"\finclude_dead\x18\x02 \x01(\bR\vincludeDead\"E\n" +
+	"\x13ListWorkersResponse\x12.\n" +
+	"\aworkers\x18\x01 \x03(\v2\x14.taskexecutor.WorkerR\aworkers\"\x99\x03\n" +
+	"\x06Worker\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
+	"\rexecutor_name\x18\x02 \x01(\tR\fexecutorName\x12\x1a\n" +
+	"\bhostname\x18\x03 \x01(\tR\bhostname\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\tR\aversion\x12\"\n" +
+	"\fcapabilities\x18\x05 \x03(\tR\fcapabilities\x12 \n" +
+	"\vconcurrency\x18\x06 \x01(\x05R\vconcurrency\x12!\n" +
+	"\factive_tasks\x18\a \x01(\x05R\vactiveTasks\x122\n" +
+	"\x06status\x18\b \x01(\x0e2\x1a.taskexecutor.WorkerStatusR\x06status\x12?\n" +
+	"\rregistered_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\fregisteredAt\x12F\n" +
+	"\x11last_heartbeat_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x0flastHeartbeatAt\"\x94\x01\n" +
 	"\x12GetNextTaskRequest\x12#\n" +
-	"\rexecutor_name\x18\x01 \x01(\tR\fexecutorName\"=\n" +
+	"\rexecutor_name\x18\x01 \x01(\tR\fexecutorName\x12<\n" +
+	"\fwait_timeout\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\vwaitTimeout\x12\x1b\n" +
+	"\tmax_tasks\x18\x03 \x01(\x05R\bmaxTasks\"\xa3\x01\n" +
 	"\x13GetNextTaskResponse\x12&\n" +
-	"\x04task\x18\x01 \x01(\v2\x12.taskexecutor.TaskR\x04task\"q\n" +
+	"\x04task\x18\x01 \x01(\v2\x12.taskexecutor.TaskR\x04task\x12(\n" +
+	"\x05tasks\x18\x02 \x03(\v2\x12.taskexecutor.TaskR\x05tasks\x12:\n" +
+	"\vretry_after\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\n" +
+	"retryAfter\"\xa4\x01\n" +
 	"\x17UpdateTaskStatusRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x120\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x18.taskexecutor.TaskStatusR\x06status\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error\"B\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x16\n" +
+	"\x06result\x18\x04 \x01(\fR\x06result\x12\x19\n" +
+	"\blease_id\x18\x05 \x01(\tR\aleaseId\"B\n" +
 	"\x18UpdateTaskStatusResponse\x12&\n" +
+	"\x04task\x18\x01 \x01(\v2\x12.taskexecutor.TaskR\x04task\"\\\n" +
+	"\x19UpdateTaskStatusesRequest\x12?\n" +
+	"\aupdates\x18\x01 \x03(\v2%.taskexecutor.UpdateTaskStatusRequestR\aupdates\"\\\n" +
+	"\x1aUpdateTaskStatusesResponse\x12>\n" +
+	"\aresults\x18\x01 \x03(\v2$.taskexecutor.UpdateTaskStatusResultR\aresults\"z\n" +
+	"\x16UpdateTaskStatusResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12&\n" +
+	"\x04task\x18\x02 \x01(\v2\x12.taskexecutor.TaskR\x04task\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\x05R\x04code\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"y\n" +
+	"\x14HeartbeatTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x126\n" +
+	"\bprogress\x18\x02 \x01(\v2\x1a.taskexecutor.TaskProgressR\bprogress\x12\x19\n" +
+	"\blease_id\x18\x03 \x01(\tR\aleaseId\"\x88\x01\n" +
+	"\x15HeartbeatTaskResponse\x12D\n" +
+	"\x10lease_expires_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x0eleaseExpiresAt\x12)\n" +
+	"\x10cancel_requested\x18\x02 \x01(\bR\x0fcancelRequested\"?\n" +
+	"\x12ReleaseTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\blease_id\x18\x02 \x01(\tR\aleaseId\"=\n" +
+	"\x13ReleaseTaskResponse\x12&\n" +
 	"\x04task\x18\x01 \x01(\v2\x12.taskexecutor.TaskR\x04task\"M\n" +
 	"\x15CreateExecutorRequest\x124\n" +
 	"\x06config\x18\x01 \x01(\v2\x1c.taskexecutor.ExecutorConfigR\x06config\"L\n" +
 	"\x16CreateExecutorResponse\x122\n" +
-	"\bexecutor\x18\x01 \x01(\v2\x16.taskexecutor.ExecutorR\bexecutor\"]\n" +
+	"\bexecutor\x18\x01 \x01(\v2\x16.taskexecutor.ExecutorR\bexecutor\"\xb4\x01\n" +
 	"\x15UpdateExecutorRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x124\n" +
-	"\x06config\x18\x02 \x01(\v2\x1c.taskexecutor.ExecutorConfigR\x06config\"L\n" +
+	"\x06config\x18\x02 \x01(\v2\x1c.taskexecutor.ExecutorConfigR\x06config\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\x12;\n" +
+	"\vupdate_mask\x18\x04 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"L\n" +
 	"\x16UpdateExecutorResponse\x122\n" +
 	"\bexecutor\x18\x01 \x01(\v2\x16.taskexecutor.ExecutorR\bexecutor\"$\n" +
 	"\x12GetExecutorRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"I\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"~\n" +
 	"\x13GetExecutorResponse\x122\n" +
-	"\bexecutor\x18\x01 \x01(\v2\x16.taskexecutor.ExecutorR\bexecutor\"R\n" +
+	"\bexecutor\x18\x01 \x01(\v2\x16.taskexecutor.ExecutorR\bexecutor\x123\n" +
+	"\tdlq_stats\x18\x02 \x01(\v2\x16.taskexecutor.DLQStatsR\bdlqStats\"c\n" +
+	"\bDLQStats\x12\x12\n" +
+	"\x04size\x18\x01 \x01(\x03R\x04size\x12C\n" +
+	"\x10oldest_entry_age\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x0eoldestEntryAge\"l\n" +
 	"\x14ListExecutorsRequest\x12\x1b\n" +
 	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
 	"\n" +
-	"page_token\x18\x02 \x01(\tR\tpageToken\"u\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x18\n" +
+	"\adeleted\x18\x03 \x01(\bR\adeleted\"u\n" +
 	"\x15ListExecutorsResponse\x124\n" +
 	"\texecutors\x18\x01 \x03(\v2\x16.taskexecutor.ExecutorR\texecutors\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"'\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xa6\x01\n" +
 	"\x15DeleteExecutorRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x18\n" +
-	"\x16DeleteExecutorResponse\"\xf4\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12<\n" +
+	"\x06policy\x18\x02 \x01(\x0e2$.taskexecutor.ExecutorDeletionPolicyR\x06policy\x12'\n" +
+	"\x0ftarget_executor\x18\x03 \x01(\tR\x0etargetExecutor\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"\xbb\x01\n" +
+	"\x16DeleteExecutorResponse\x12%\n" +
+	"\x0eaffected_tasks\x18\x01 \x01(\x03R\raffectedTasks\x129\n" +
+	"\n" +
+	"deleted_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12?\n" +
+	"\rrestore_until\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\frestoreUntil\"-\n" +
+	"\x17UndeleteExecutorRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"N\n" +
+	"\x18UndeleteExecutorResponse\x122\n" +
+	"\bexecutor\x18\x01 \x01(\v2\x16.taskexecutor.ExecutorR\bexecutor\"n\n" +
+	"\x1cListExecutorRevisionsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"\x85\x01\n" +
+	"\x1dListExecutorRevisionsResponse\x12<\n" +
+	"\trevisions\x18\x01 \x03(\v2\x1e.taskexecutor.ExecutorRevisionR\trevisions\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"h\n" +
+	"\x17RollbackExecutorRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\vrevision_id\x18\x02 \x01(\tR\n" +
+	"revisionId\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\"N\n" +
+	"\x18RollbackExecutorResponse\x122\n" +
+	"\bexecutor\x18\x01 \x01(\v2\x16.taskexecutor.ExecutorR\bexecutor\"\xef\x02\n" +
+	"\x10ExecutorRevision\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
+	"\rexecutor_name\x18\x02 \x01(\tR\fexecutorName\x12<\n" +
+	"\x06action\x18\x03 \x01(\x0e2$.taskexecutor.ExecutorRevisionActionR\x06action\x12\x14\n" +
+	"\x05actor\x18\x04 \x01(\tR\x05actor\x124\n" +
+	"\x06config\x18\x05 \x01(\v2\x1c.taskexecutor.ExecutorConfigR\x06config\x12;\n" +
+	"\achanges\x18\x06 \x03(\v2!.taskexecutor.ExecutorFieldChangeR\achanges\x12$\n" +
+	"\x0erolled_back_to\x18\a \x01(\tR\frolledBackTo\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"e\n" +
+	"\x13ExecutorFieldChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x1b\n" +
+	"\told_value\x18\x02 \x01(\tR\boldValue\x12\x1b\n" +
+	"\tnew_value\x18\x03 \x01(\tR\bnewValue\"*\n" +
+	"\x14PauseExecutorRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"K\n" +
+	"\x15PauseExecutorResponse\x122\n" +
+	"\bexecutor\x18\x01 \x01(\v2\x16.taskexecutor.ExecutorR\bexecutor\"+\n" +
+	"\x15ResumeExecutorRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"L\n" +
+	"\x16ResumeExecutorResponse\x122\n" +
+	"\bexecutor\x18\x01 \x01(\v2\x16.taskexecutor.ExecutorR\bexecutor\"*\n" +
+	"\x14DrainExecutorRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"t\n" +
+	"\x15DrainExecutorResponse\x122\n" +
+	"\bexecutor\x18\x01 \x01(\v2\x16.taskexecutor.ExecutorR\bexecutor\x12'\n" +
+	"\x0fremaining_tasks\x18\x02 \x01(\x03R\x0eremainingTasks\",\n" +
+	"\x16DisableExecutorRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"M\n" +
+	"\x17DisableExecutorResponse\x122\n" +
+	"\bexecutor\x18\x01 \x01(\v2\x16.taskexecutor.ExecutorR\bexecutor\"\xd3\x02\n" +
+	"\tDLQFilter\x12=\n" +
+	"\ffailed_after\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\vfailedAfter\x12?\n" +
+	"\rfailed_before\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ffailedBefore\x12%\n" +
+	"\x0eerror_contains\x18\x03 \x01(\tR\rerrorContains\x12A\n" +
+	"\bmetadata\x18\x04 \x03(\v2%.taskexecutor.DLQFilter.MetadataEntryR\bmetadata\x12\x1f\n" +
+	"\verror_group\x18\x05 \x01(\tR\n" +
+	"errorGroup\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc6\x01\n" +
+	"\x13ListDLQTasksRequest\x12#\n" +
+	"\rexecutor_name\x18\x01 \x01(\tR\fexecutorName\x12/\n" +
+	"\x06filter\x18\x02 \x01(\v2\x17.taskexecutor.DLQFilterR\x06filter\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\x12\x1d\n" +
+	"\n" +
+	"queue_name\x18\x05 \x01(\tR\tqueueName\"h\n" +
+	"\x14ListDLQTasksResponse\x12(\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x12.taskexecutor.TaskR\x05tasks\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x86\x01\n" +
+	"\x0fPurgeDLQRequest\x12#\n" +
+	"\rexecutor_name\x18\x01 \x01(\tR\fexecutorName\x12/\n" +
+	"\x06filter\x18\x02 \x01(\v2\x17.taskexecutor.DLQFilterR\x06filter\x12\x1d\n" +
+	"\n" +
+	"queue_name\x18\x03 \x01(\tR\tqueueName\"5\n" +
+	"\x10PurgeDLQResponse\x12!\n" +
+	"\fpurged_count\x18\x01 \x01(\x03R\vpurgedCount\"\xca\x02\n" +
+	"\x11RedriveDLQRequest\x12#\n" +
+	"\rexecutor_name\x18\x01 \x01(\tR\fexecutorName\x12\x19\n" +
+	"\btask_ids\x18\x02 \x03(\tR\ataskIds\x12/\n" +
+	"\x06filter\x18\x03 \x01(\v2\x17.taskexecutor.DLQFilterR\x06filter\x12\x1d\n" +
+	"\n" +
+	"data_patch\x18\x04 \x01(\tR\tdataPatch\x12'\n" +
+	"\x0ftarget_executor\x18\x05 \x01(\tR\x0etargetExecutor\x12&\n" +
+	"\x0frate_per_second\x18\x06 \x01(\x01R\rratePerSecond\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"queue_name\x18\b \x01(\tR\tqueueName\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\t \x01(\tR\tpageToken\"\x91\x01\n" +
+	"\x12RedriveDLQResponse\x12%\n" +
+	"\x0eredriven_count\x18\x01 \x01(\x03R\rredrivenCount\x12,\n" +
+	"\x12duplicate_task_ids\x18\x02 \x03(\tR\x10duplicateTaskIds\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"\x8f\x01\n" +
+	"\x03DLQ\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x1c\n" +
+	"\texecutors\x18\x03 \x03(\tR\texecutors\x12B\n" +
+	"\x0foldest_entry_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\roldestEntryAt\"\x11\n" +
+	"\x0fListDLQsRequest\"=\n" +
+	"\x10ListDLQsResponse\x12)\n" +
+	"\x06queues\x18\x01 \x03(\v2\x11.taskexecutor.DLQR\x06queues\"#\n" +
+	"\rGetDLQRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"9\n" +
+	"\x0eGetDLQResponse\x12'\n" +
+	"\x05queue\x18\x01 \x01(\v2\x11.taskexecutor.DLQR\x05queue\"\xa9\x01\n" +
+	"\x11DLQSummaryRequest\x12#\n" +
+	"\rexecutor_name\x18\x01 \x01(\tR\fexecutorName\x12\x1d\n" +
+	"\n" +
+	"queue_name\x18\x02 \x01(\tR\tqueueName\x12/\n" +
+	"\x06filter\x18\x03 \x01(\v2\x17.taskexecutor.DLQFilterR\x06filter\x12\x1f\n" +
+	"\vsample_size\x18\x04 \x01(\x05R\n" +
+	"sampleSize\"I\n" +
+	"\x12DLQSummaryResponse\x123\n" +
+	"\x06groups\x18\x01 \x03(\v2\x1b.taskexecutor.DLQErrorGroupR\x06groups\"\xaa\x02\n" +
+	"\rDLQErrorGroup\x12#\n" +
+	"\rexecutor_name\x18\x01 \x01(\tR\fexecutorName\x12\x1f\n" +
+	"\verror_group\x18\x02 \x01(\tR\n" +
+	"errorGroup\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x03R\x05count\x129\n" +
+	"\n" +
+	"first_seen\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tfirstSeen\x127\n" +
+	"\tlast_seen\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\x12&\n" +
+	"\x0fsample_task_ids\x18\x06 \x03(\tR\rsampleTaskIds\x12!\n" +
+	"\fsample_error\x18\a \x01(\tR\vsampleError\"K\n" +
+	"\x15CreateScheduleRequest\x122\n" +
+	"\bschedule\x18\x01 \x01(\v2\x16.taskexecutor.ScheduleR\bschedule\"L\n" +
+	"\x16CreateScheduleResponse\x122\n" +
+	"\bschedule\x18\x01 \x01(\v2\x16.taskexecutor.ScheduleR\bschedule\";\n" +
+	"\x14ListSchedulesRequest\x12#\n" +
+	"\rexecutor_name\x18\x01 \x01(\tR\fexecutorName\"M\n" +
+	"\x15ListSchedulesResponse\x124\n" +
+	"\tschedules\x18\x01 \x03(\v2\x16.taskexecutor.ScheduleR\tschedules\"+\n" +
+	"\x15DeleteScheduleRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x18\n" +
+	"\x16DeleteScheduleResponse\"B\n" +
+	"\x14PauseScheduleRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06paused\x18\x02 \x01(\bR\x06paused\"K\n" +
+	"\x15PauseScheduleResponse\x122\n" +
+	"\bschedule\x18\x01 \x01(\v2\x16.taskexecutor.ScheduleR\bschedule\"\xe2\x02\n" +
 	"\bExecutor\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
@@ -1796,24 +6160,71 @@ const file_proto_task_executor_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xf5\x01\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x121\n" +
+	"\x05state\x18\a \x01(\x0e2\x1b.taskexecutor.ExecutorStateR\x05state\x129\n" +
+	"\n" +
+	"deleted_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\"\xe2\x04\n" +
 	"\x0eExecutorConfig\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
 	"\aenabled\x18\x02 \x01(\bR\aenabled\x12?\n" +
 	"\rwrite_concern\x18\x03 \x01(\v2\x1a.taskexecutor.WriteConcernR\fwriteConcern\x12<\n" +
 	"\fretry_policy\x18\x04 \x01(\v2\x19.taskexecutor.RetryPolicyR\vretryPolicy\x126\n" +
 	"\n" +
-	"dlq_config\x18\x05 \x01(\v2\x17.taskexecutor.DLQConfigR\tdlqConfig\"E\n" +
+	"dlq_config\x18\x05 \x01(\v2\x17.taskexecutor.DLQConfigR\tdlqConfig\x12@\n" +
+	"\x0elease_duration\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\rleaseDuration\x12B\n" +
+	"\x0epriority_aging\x18\a \x01(\v2\x1b.taskexecutor.PriorityAgingR\rpriorityAging\x12<\n" +
+	"\fdedup_window\x18\b \x01(\v2\x19.google.protobuf.DurationR\vdedupWindow\x12\"\n" +
+	"\rmax_in_flight\x18\t \x01(\x05R\vmaxInFlight\x126\n" +
+	"\n" +
+	"rate_limit\x18\n" +
+	" \x01(\v2\x17.taskexecutor.RateLimitR\trateLimit\x121\n" +
+	"\x05state\x18\v \x01(\x0e2\x1b.taskexecutor.ExecutorStateR\x05state\x12\x18\n" +
+	"\aversion\x18\f \x01(\x03R\aversion\"K\n" +
+	"\tRateLimit\x12(\n" +
+	"\x10tasks_per_second\x18\x01 \x01(\x01R\x0etasksPerSecond\x12\x14\n" +
+	"\x05burst\x18\x02 \x01(\x05R\x05burst\"\x7f\n" +
+	"\rPriorityAging\x127\n" +
+	"\tthreshold\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\tthreshold\x12\x12\n" +
+	"\x04step\x18\x02 \x01(\x05R\x04step\x12!\n" +
+	"\fmax_priority\x18\x03 \x01(\x05R\vmaxPriority\"E\n" +
 	"\fWriteConcern\x125\n" +
-	"\x05level\x18\x01 \x01(\x0e2\x1f.taskexecutor.WriteConcernLevelR\x05level\"\x9a\x01\n" +
+	"\x05level\x18\x01 \x01(\x0e2\x1f.taskexecutor.WriteConcernLevelR\x05level\"\xf0\x01\n" +
 	"\vRetryPolicy\x121\n" +
 	"\x04type\x18\x01 \x01(\x0e2\x1d.taskexecutor.RetryPolicyTypeR\x04type\x12!\n" +
 	"\fmax_attempts\x18\x02 \x01(\x05R\vmaxAttempts\x125\n" +
-	"\binterval\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\binterval\"D\n" +
+	"\binterval\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\binterval\x12<\n" +
+	"\fmax_interval\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\vmaxInterval\x12\x16\n" +
+	"\x06jitter\x18\x05 \x01(\x01R\x06jitter\"\xdc\x01\n" +
 	"\tDLQConfig\x12\x18\n" +
 	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x1d\n" +
 	"\n" +
-	"queue_name\x18\x02 \x01(\tR\tqueueName\"\xa3\x04\n" +
+	"queue_name\x18\x02 \x01(\tR\tqueueName\x12'\n" +
+	"\x0ffailure_handler\x18\x03 \x01(\tR\x0efailureHandler\x122\n" +
+	"\amax_age\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\x06maxAge\x12\x1f\n" +
+	"\vmax_entries\x18\x05 \x01(\x03R\n" +
+	"maxEntries\x12\x18\n" +
+	"\aarchive\x18\x06 \x01(\bR\aarchive\"\xd3\x04\n" +
+	"\bSchedule\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12'\n" +
+	"\x0fcron_expression\x18\x03 \x01(\tR\x0ecronExpression\x12\x1a\n" +
+	"\btimezone\x18\x04 \x01(\tR\btimezone\x12#\n" +
+	"\rexecutor_name\x18\x05 \x01(\tR\fexecutorName\x12\x12\n" +
+	"\x04data\x18\x06 \x01(\fR\x04data\x12@\n" +
+	"\bmetadata\x18\a \x03(\v2$.taskexecutor.Schedule.MetadataEntryR\bmetadata\x12\x16\n" +
+	"\x06paused\x18\b \x01(\bR\x06paused\x12:\n" +
+	"\vnext_run_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tnextRunAt\x12:\n" +
+	"\vlast_run_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tlastRunAt\x12 \n" +
+	"\flast_task_id\x18\v \x01(\tR\n" +
+	"lastTaskId\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb1\b\n" +
 	"\x04Task\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
 	"\rexecutor_name\x18\x02 \x01(\tR\fexecutorName\x12\x12\n" +
@@ -1830,25 +6241,60 @@ const file_proto_task_executor_proto_rawDesc = "" +
 	"\n" +
 	"started_at\x18\n" +
 	" \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12=\n" +
-	"\fcompleted_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x1a;\n" +
+	"\fcompleted_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12B\n" +
+	"\x0fnext_attempt_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\rnextAttemptAt\x12D\n" +
+	"\x10lease_expires_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\x0eleaseExpiresAt\x126\n" +
+	"\bprogress\x18\x0e \x01(\v2\x1a.taskexecutor.TaskProgressR\bprogress\x121\n" +
+	"\x06run_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\x05runAt\x12\x1a\n" +
+	"\bpriority\x18\x10 \x01(\x05R\bpriority\x12'\n" +
+	"\x0fidempotency_key\x18\x11 \x01(\tR\x0eidempotencyKey\x12)\n" +
+	"\x10cancel_requested\x18\x12 \x01(\bR\x0fcancelRequested\x12\x16\n" +
+	"\x06result\x18\x13 \x01(\fR\x06result\x128\n" +
+	"\ahistory\x18\x14 \x03(\v2\x1e.taskexecutor.TaskHistoryEntryR\ahistory\x12\x19\n" +
+	"\bdlq_name\x18\x15 \x01(\tR\adlqName\x12\x1f\n" +
+	"\verror_group\x18\x16 \x01(\tR\n" +
+	"errorGroup\x12\x19\n" +
+	"\blease_id\x18\x17 \x01(\tR\aleaseId\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x83\x02\n" +
-	"\x05Order\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12-\n" +
-	"\x05items\x18\x03 \x03(\v2\x17.taskexecutor.OrderItemR\x05items\x12\x14\n" +
-	"\x05total\x18\x04 \x01(\x01R\x05total\x12\x16\n" +
-	"\x06status\x18\x05 \x01(\tR\x06status\x129\n" +
-	"\n" +
-	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
-	"\n" +
-	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\\\n" +
-	"\tOrderItem\x12\x1d\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf1\x01\n" +
+	"\x10TaskHistoryEntry\x12\x14\n" +
+	"\x05event\x18\x01 \x01(\tR\x05event\x12*\n" +
+	"\x02at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12E\n" +
+	"\adetails\x18\x04 \x03(\v2+.taskexecutor.TaskHistoryEntry.DetailsEntryR\adetails\x1a:\n" +
+	"\fDetailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"}\n" +
+	"\fTaskProgress\x12\x18\n" +
+	"\apercent\x18\x01 \x01(\x01R\apercent\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x129\n" +
 	"\n" +
-	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\x01R\x05price*\xbb\x01\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt*^\n" +
+	"\fWorkerStatus\x12\x1d\n" +
+	"\x19WORKER_STATUS_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13WORKER_STATUS_ALIVE\x10\x01\x12\x16\n" +
+	"\x12WORKER_STATUS_DEAD\x10\x02*\xd5\x01\n" +
+	"\x16ExecutorDeletionPolicy\x12(\n" +
+	"$EXECUTOR_DELETION_POLICY_UNSPECIFIED\x10\x00\x12#\n" +
+	"\x1fEXECUTOR_DELETION_POLICY_REFUSE\x10\x01\x12#\n" +
+	"\x1fEXECUTOR_DELETION_POLICY_CANCEL\x10\x02\x12!\n" +
+	"\x1dEXECUTOR_DELETION_POLICY_MOVE\x10\x03\x12$\n" +
+	" EXECUTOR_DELETION_POLICY_ARCHIVE\x10\x04*\xb2\x02\n" +
+	"\x16ExecutorRevisionAction\x12(\n" +
+	"$EXECUTOR_REVISION_ACTION_UNSPECIFIED\x10\x00\x12$\n" +
+	" EXECUTOR_REVISION_ACTION_CREATED\x10\x01\x12$\n" +
+	" EXECUTOR_REVISION_ACTION_UPDATED\x10\x02\x12*\n" +
+	"&EXECUTOR_REVISION_ACTION_STATE_CHANGED\x10\x03\x12$\n" +
+	" EXECUTOR_REVISION_ACTION_DELETED\x10\x04\x12&\n" +
+	"\"EXECUTOR_REVISION_ACTION_UNDELETED\x10\x05\x12(\n" +
+	"$EXECUTOR_REVISION_ACTION_ROLLED_BACK\x10\x06*\x9f\x01\n" +
+	"\rExecutorState\x12\x1e\n" +
+	"\x1aEXECUTOR_STATE_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15EXECUTOR_STATE_ACTIVE\x10\x01\x12\x19\n" +
+	"\x15EXECUTOR_STATE_PAUSED\x10\x02\x12\x1b\n" +
+	"\x17EXECUTOR_STATE_DRAINING\x10\x03\x12\x1b\n" +
+	"\x17EXECUTOR_STATE_DISABLED\x10\x04*\xbb\x01\n" +
 	"\x11WriteConcernLevel\x12#\n" +
 	"\x1fWRITE_CONCERN_LEVEL_UNSPECIFIED\x10\x00\x12&\n" +
 	"\"WRITE_CONCERN_REPLICA_ACKNOWLEDGED\x10\x01\x12\x1a\n" +
@@ -1859,7 +6305,7 @@ const file_proto_task_executor_proto_rawDesc = "" +
 	"\x1dRETRY_POLICY_TYPE_UNSPECIFIED\x10\x00\x12\x19\n" +
 	"\x15RETRY_POLICY_CONSTANT\x10\x01\x12\x17\n" +
 	"\x13RETRY_POLICY_LINEAR\x10\x02\x12\x1c\n" +
-	"\x18RETRY_POLICY_EXPONENTIAL\x10\x03*\xa7\x01\n" +
+	"\x18RETRY_POLICY_EXPONENTIAL\x10\x03*\xc2\x01\n" +
 	"\n" +
 	"TaskStatus\x12\x1b\n" +
 	"\x17TASK_STATUS_UNSPECIFIED\x10\x00\x12\x17\n" +
@@ -1867,18 +6313,46 @@ const file_proto_task_executor_proto_rawDesc = "" +
 	"\x17TASK_STATUS_IN_PROGRESS\x10\x02\x12\x19\n" +
 	"\x15TASK_STATUS_COMPLETED\x10\x03\x12\x16\n" +
 	"\x12TASK_STATUS_FAILED\x10\x04\x12\x13\n" +
-	"\x0fTASK_STATUS_DLQ\x10\x052\x96\a\n" +
+	"\x0fTASK_STATUS_DLQ\x10\x05\x12\x19\n" +
+	"\x15TASK_STATUS_CANCELLED\x10\x062\xeb\x17\n" +
 	"\x13TaskExecutorManager\x12F\n" +
 	"\aAddTask\x12\x1c.taskexecutor.AddTaskRequest\x1a\x1d.taskexecutor.AddTaskResponse\x12X\n" +
-	"\rGetTaskStatus\x12\".taskexecutor.GetTaskStatusRequest\x1a#.taskexecutor.GetTaskStatusResponse\x12a\n" +
+	"\rGetTaskStatus\x12\".taskexecutor.GetTaskStatusRequest\x1a#.taskexecutor.GetTaskStatusResponse\x12O\n" +
+	"\n" +
+	"CancelTask\x12\x1f.taskexecutor.CancelTaskRequest\x1a .taskexecutor.CancelTaskResponse\x12L\n" +
+	"\tListTasks\x12\x1e.taskexecutor.ListTasksRequest\x1a\x1f.taskexecutor.ListTasksResponse\x12a\n" +
 	"\x10RegisterExecutor\x12%.taskexecutor.RegisterExecutorRequest\x1a&.taskexecutor.RegisterExecutorResponse\x12R\n" +
 	"\vGetNextTask\x12 .taskexecutor.GetNextTaskRequest\x1a!.taskexecutor.GetNextTaskResponse\x12a\n" +
-	"\x10UpdateTaskStatus\x12%.taskexecutor.UpdateTaskStatusRequest\x1a&.taskexecutor.UpdateTaskStatusResponse\x12[\n" +
+	"\x10UpdateTaskStatus\x12%.taskexecutor.UpdateTaskStatusRequest\x1a&.taskexecutor.UpdateTaskStatusResponse\x12g\n" +
+	"\x12UpdateTaskStatuses\x12'.taskexecutor.UpdateTaskStatusesRequest\x1a(.taskexecutor.UpdateTaskStatusesResponse\x12X\n" +
+	"\rHeartbeatTask\x12\".taskexecutor.HeartbeatTaskRequest\x1a#.taskexecutor.HeartbeatTaskResponse\x12R\n" +
+	"\vReleaseTask\x12 .taskexecutor.ReleaseTaskRequest\x1a!.taskexecutor.ReleaseTaskResponse\x12^\n" +
+	"\x0fHeartbeatWorker\x12$.taskexecutor.HeartbeatWorkerRequest\x1a%.taskexecutor.HeartbeatWorkerResponse\x12R\n" +
+	"\vListWorkers\x12 .taskexecutor.ListWorkersRequest\x1a!.taskexecutor.ListWorkersResponse\x12[\n" +
 	"\x0eCreateExecutor\x12#.taskexecutor.CreateExecutorRequest\x1a$.taskexecutor.CreateExecutorResponse\x12[\n" +
 	"\x0eUpdateExecutor\x12#.taskexecutor.UpdateExecutorRequest\x1a$.taskexecutor.UpdateExecutorResponse\x12R\n" +
 	"\vGetExecutor\x12 .taskexecutor.GetExecutorRequest\x1a!.taskexecutor.GetExecutorResponse\x12X\n" +
 	"\rListExecutors\x12\".taskexecutor.ListExecutorsRequest\x1a#.taskexecutor.ListExecutorsResponse\x12[\n" +
-	"\x0eDeleteExecutor\x12#.taskexecutor.DeleteExecutorRequest\x1a$.taskexecutor.DeleteExecutorResponseB7Z5github.com/botashev/tasks-executor/proto/taskexecutorb\x06proto3"
+	"\x0eDeleteExecutor\x12#.taskexecutor.DeleteExecutorRequest\x1a$.taskexecutor.DeleteExecutorResponse\x12X\n" +
+	"\rPauseExecutor\x12\".taskexecutor.PauseExecutorRequest\x1a#.taskexecutor.PauseExecutorResponse\x12[\n" +
+	"\x0eResumeExecutor\x12#.taskexecutor.ResumeExecutorRequest\x1a$.taskexecutor.ResumeExecutorResponse\x12X\n" +
+	"\rDrainExecutor\x12\".taskexecutor.DrainExecutorRequest\x1a#.taskexecutor.DrainExecutorResponse\x12^\n" +
+	"\x0fDisableExecutor\x12$.taskexecutor.DisableExecutorRequest\x1a%.taskexecutor.DisableExecutorResponse\x12a\n" +
+	"\x10UndeleteExecutor\x12%.taskexecutor.UndeleteExecutorRequest\x1a&.taskexecutor.UndeleteExecutorResponse\x12p\n" +
+	"\x15ListExecutorRevisions\x12*.taskexecutor.ListExecutorRevisionsRequest\x1a+.taskexecutor.ListExecutorRevisionsResponse\x12a\n" +
+	"\x10RollbackExecutor\x12%.taskexecutor.RollbackExecutorRequest\x1a&.taskexecutor.RollbackExecutorResponse\x12U\n" +
+	"\fListDLQTasks\x12!.taskexecutor.ListDLQTasksRequest\x1a\".taskexecutor.ListDLQTasksResponse\x12I\n" +
+	"\bPurgeDLQ\x12\x1d.taskexecutor.PurgeDLQRequest\x1a\x1e.taskexecutor.PurgeDLQResponse\x12O\n" +
+	"\n" +
+	"RedriveDLQ\x12\x1f.taskexecutor.RedriveDLQRequest\x1a .taskexecutor.RedriveDLQResponse\x12I\n" +
+	"\bListDLQs\x12\x1d.taskexecutor.ListDLQsRequest\x1a\x1e.taskexecutor.ListDLQsResponse\x12C\n" +
+	"\x06GetDLQ\x12\x1b.taskexecutor.GetDLQRequest\x1a\x1c.taskexecutor.GetDLQResponse\x12O\n" +
+	"\n" +
+	"DLQSummary\x12\x1f.taskexecutor.DLQSummaryRequest\x1a .taskexecutor.DLQSummaryResponse\x12[\n" +
+	"\x0eCreateSchedule\x12#.taskexecutor.CreateScheduleRequest\x1a$.taskexecutor.CreateScheduleResponse\x12X\n" +
+	"\rListSchedules\x12\".taskexecutor.ListSchedulesRequest\x1a#.taskexecutor.ListSchedulesResponse\x12[\n" +
+	"\x0eDeleteSchedule\x12#.taskexecutor.DeleteScheduleRequest\x1a$.taskexecutor.DeleteScheduleResponse\x12X\n" +
+	"\rPauseSchedule\x12\".taskexecutor.PauseScheduleRequest\x1a#.taskexecutor.PauseScheduleResponseB*Z(github.com/botashev/tasks-executor/protob\x06proto3"
 
 var (
 	file_proto_task_executor_proto_rawDescOnce sync.Once
@@ -1892,101 +6366,296 @@ func file_proto_task_executor_proto_rawDescGZIP() []byte {
 	return file_proto_task_executor_proto_rawDescData
 }
 
-var file_proto_task_executor_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_proto_task_executor_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
+var file_proto_task_executor_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_proto_task_executor_proto_msgTypes = make([]protoimpl.MessageInfo, 93)
 var file_proto_task_executor_proto_goTypes = []any{
-	(WriteConcernLevel)(0),           // 0: taskexecutor.WriteConcernLevel
-	(RetryPolicyType)(0),             // 1: taskexecutor.RetryPolicyType
-	(TaskStatus)(0),                  // 2: taskexecutor.TaskStatus
-	(*AddTaskRequest)(nil),           // 3: taskexecutor.AddTaskRequest
-	(*AddTaskResponse)(nil),          // 4: taskexecutor.AddTaskResponse
-	(*GetTaskStatusRequest)(nil),     // 5: taskexecutor.GetTaskStatusRequest
-	(*GetTaskStatusResponse)(nil),    // 6: taskexecutor.GetTaskStatusResponse
-	(*RegisterExecutorRequest)(nil),  // 7: taskexecutor.RegisterExecutorRequest
-	(*RegisterExecutorResponse)(nil), // 8: taskexecutor.RegisterExecutorResponse
-	(*GetNextTaskRequest)(nil),       // 9: taskexecutor.GetNextTaskRequest
-	(*GetNextTaskResponse)(nil),      // 10: taskexecutor.GetNextTaskResponse
-	(*UpdateTaskStatusRequest)(nil),  // 11: taskexecutor.UpdateTaskStatusRequest
-	(*UpdateTaskStatusResponse)(nil), // 12: taskexecutor.UpdateTaskStatusResponse
-	(*CreateExecutorRequest)(nil),    // 13: taskexecutor.CreateExecutorRequest
-	(*CreateExecutorResponse)(nil),   // 14: taskexecutor.CreateExecutorResponse
-	(*UpdateExecutorRequest)(nil),    // 15: taskexecutor.UpdateExecutorRequest
-	(*UpdateExecutorResponse)(nil),   // 16: taskexecutor.UpdateExecutorResponse
-	(*GetExecutorRequest)(nil),       // 17: taskexecutor.GetExecutorRequest
-	(*GetExecutorResponse)(nil),      // 18: taskexecutor.GetExecutorResponse
-	(*ListExecutorsRequest)(nil),     // 19: taskexecutor.ListExecutorsRequest
-	(*ListExecutorsResponse)(nil),    // 20: taskexecutor.ListExecutorsResponse
-	(*DeleteExecutorRequest)(nil),    // 21: taskexecutor.DeleteExecutorRequest
-	(*DeleteExecutorResponse)(nil),   // 22: taskexecutor.DeleteExecutorResponse
-	(*Executor)(nil),                 // 23: taskexecutor.Executor
-	(*ExecutorConfig)(nil),           // 24: taskexecutor.ExecutorConfig
-	(*WriteConcern)(nil),             // 25: taskexecutor.WriteConcern
-	(*RetryPolicy)(nil),              // 26: taskexecutor.RetryPolicy
-	(*DLQConfig)(nil),                // 27: taskexecutor.DLQConfig
-	(*Task)(nil),                     // 28: taskexecutor.Task
-	(*Order)(nil),                    // 29: taskexecutor.Order
-	(*OrderItem)(nil),                // 30: taskexecutor.OrderItem
-	nil,                              // 31: taskexecutor.AddTaskRequest.MetadataEntry
-	nil,                              // 32: taskexecutor.Task.MetadataEntry
-	(*timestamppb.Timestamp)(nil),    // 33: google.protobuf.Timestamp
-	(*durationpb.Duration)(nil),      // 34: google.protobuf.Duration
+	(WorkerStatus)(0),                     // 0: taskexecutor.WorkerStatus
+	(ExecutorDeletionPolicy)(0),           // 1: taskexecutor.ExecutorDeletionPolicy
+	(ExecutorRevisionAction)(0),           // 2: taskexecutor.ExecutorRevisionAction
+	(ExecutorState)(0),                    // 3: taskexecutor.ExecutorState
+	(WriteConcernLevel)(0),                // 4: taskexecutor.WriteConcernLevel
+	(RetryPolicyType)(0),                  // 5: taskexecutor.RetryPolicyType
+	(TaskStatus)(0),                       // 6: taskexecutor.TaskStatus
+	(*AddTaskRequest)(nil),                // 7: taskexecutor.AddTaskRequest
+	(*AddTaskResponse)(nil),               // 8: taskexecutor.AddTaskResponse
+	(*GetTaskStatusRequest)(nil),          // 9: taskexecutor.GetTaskStatusRequest
+	(*GetTaskStatusResponse)(nil),         // 10: taskexecutor.GetTaskStatusResponse
+	(*CancelTaskRequest)(nil),             // 11: taskexecutor.CancelTaskRequest
+	(*CancelTaskResponse)(nil),            // 12: taskexecutor.CancelTaskResponse
+	(*ListTasksRequest)(nil),              // 13: taskexecutor.ListTasksRequest
+	(*ListTasksResponse)(nil),             // 14: taskexecutor.ListTasksResponse
+	(*RegisterExecutorRequest)(nil),       // 15: taskexecutor.RegisterExecutorRequest
+	(*RegisterExecutorResponse)(nil),      // 16: taskexecutor.RegisterExecutorResponse
+	(*HeartbeatWorkerRequest)(nil),        // 17: taskexecutor.HeartbeatWorkerRequest
+	(*HeartbeatWorkerResponse)(nil),       // 18: taskexecutor.HeartbeatWorkerResponse
+	(*ListWorkersRequest)(nil),            // 19: taskexecutor.ListWorkersRequest
+	(*ListWorkersResponse)(nil),           // 20: taskexecutor.ListWorkersResponse
+	(*Worker)(nil),                        // 21: taskexecutor.Worker
+	(*GetNextTaskRequest)(nil),            // 22: taskexecutor.GetNextTaskRequest
+	(*GetNextTaskResponse)(nil),           // 23: taskexecutor.GetNextTaskResponse
+	(*UpdateTaskStatusRequest)(nil),       // 24: taskexecutor.UpdateTaskStatusRequest
+	(*UpdateTaskStatusResponse)(nil),      // 25: taskexecutor.UpdateTaskStatusResponse
+	(*UpdateTaskStatusesRequest)(nil),     // 26: taskexecutor.UpdateTaskStatusesRequest
+	(*UpdateTaskStatusesResponse)(nil),    // 27: taskexecutor.UpdateTaskStatusesResponse
+	(*UpdateTaskStatusResult)(nil),        // 28: taskexecutor.UpdateTaskStatusResult
+	(*HeartbeatTaskRequest)(nil),          // 29: taskexecutor.HeartbeatTaskRequest
+	(*HeartbeatTaskResponse)(nil),         // 30: taskexecutor.HeartbeatTaskResponse
+	(*ReleaseTaskRequest)(nil),            // 31: taskexecutor.ReleaseTaskRequest
+	(*ReleaseTaskResponse)(nil),           // 32: taskexecutor.ReleaseTaskResponse
+	(*CreateExecutorRequest)(nil),         // 33: taskexecutor.CreateExecutorRequest
+	(*CreateExecutorResponse)(nil),        // 34: taskexecutor.CreateExecutorResponse
+	(*UpdateExecutorRequest)(nil),         // 35: taskexecutor.UpdateExecutorRequest
+	(*UpdateExecutorResponse)(nil),        // 36: taskexecutor.UpdateExecutorResponse
+	(*GetExecutorRequest)(nil),            // 37: taskexecutor.GetExecutorRequest
+	(*GetExecutorResponse)(nil),           // 38: taskexecutor.GetExecutorResponse
+	(*DLQStats)(nil),                      // 39: taskexecutor.DLQStats
+	(*ListExecutorsRequest)(nil),          // 40: taskexecutor.ListExecutorsRequest
+	(*ListExecutorsResponse)(nil),         // 41: taskexecutor.ListExecutorsResponse
+	(*DeleteExecutorRequest)(nil),         // 42: taskexecutor.DeleteExecutorRequest
+	(*DeleteExecutorResponse)(nil),        // 43: taskexecutor.DeleteExecutorResponse
+	(*UndeleteExecutorRequest)(nil),       // 44: taskexecutor.UndeleteExecutorRequest
+	(*UndeleteExecutorResponse)(nil),      // 45: taskexecutor.UndeleteExecutorResponse
+	(*ListExecutorRevisionsRequest)(nil),  // 46: taskexecutor.ListExecutorRevisionsRequest
+	(*ListExecutorRevisionsResponse)(nil), // 47: taskexecutor.ListExecutorRevisionsResponse
+	(*RollbackExecutorRequest)(nil),       // 48: taskexecutor.RollbackExecutorRequest
+	(*RollbackExecutorResponse)(nil),      // 49: taskexecutor.RollbackExecutorResponse
+	(*ExecutorRevision)(nil),              // 50: taskexecutor.ExecutorRevision
+	(*ExecutorFieldChange)(nil),           // 51: taskexecutor.ExecutorFieldChange
+	(*PauseExecutorRequest)(nil),          // 52: taskexecutor.PauseExecutorRequest
+	(*PauseExecutorResponse)(nil),         // 53: taskexecutor.PauseExecutorResponse
+	(*ResumeExecutorRequest)(nil),         // 54: taskexecutor.ResumeExecutorRequest
+	(*ResumeExecutorResponse)(nil),        // 55: taskexecutor.ResumeExecutorResponse
+	(*DrainExecutorRequest)(nil),          // 56: taskexecutor.DrainExecutorRequest
+	(*DrainExecutorResponse)(nil),         // 57: taskexecutor.DrainExecutorResponse
+	(*DisableExecutorRequest)(nil),        // 58: taskexecutor.DisableExecutorRequest
+	(*DisableExecutorResponse)(nil),       // 59: taskexecutor.DisableExecutorResponse
+	(*DLQFilter)(nil),                     // 60: taskexecutor.DLQFilter
+	(*ListDLQTasksRequest)(nil),           // 61: taskexecutor.ListDLQTasksRequest
+	(*ListDLQTasksResponse)(nil),          // 62: taskexecutor.ListDLQTasksResponse
+	(*PurgeDLQRequest)(nil),               // 63: taskexecutor.PurgeDLQRequest
+	(*PurgeDLQResponse)(nil),              // 64: taskexecutor.PurgeDLQResponse
+	(*RedriveDLQRequest)(nil),             // 65: taskexecutor.RedriveDLQRequest
+	(*RedriveDLQResponse)(nil),            // 66: taskexecutor.RedriveDLQResponse
+	(*DLQ)(nil),                           // 67: taskexecutor.DLQ
+	(*ListDLQsRequest)(nil),               // 68: taskexecutor.ListDLQsRequest
+	(*ListDLQsResponse)(nil),              // 69: taskexecutor.ListDLQsResponse
+	(*GetDLQRequest)(nil),                 // 70: taskexecutor.GetDLQRequest
+	(*GetDLQResponse)(nil),                // 71: taskexecutor.GetDLQResponse
+	(*DLQSummaryRequest)(nil),             // 72: taskexecutor.DLQSummaryRequest
+	(*DLQSummaryResponse)(nil),            // 73: taskexecutor.DLQSummaryResponse
+	(*DLQErrorGroup)(nil),                 // 74: taskexecutor.DLQErrorGroup
+	(*CreateScheduleRequest)(nil),         // 75: taskexecutor.CreateScheduleRequest
+	(*CreateScheduleResponse)(nil),        // 76: taskexecutor.CreateScheduleResponse
+	(*ListSchedulesRequest)(nil),          // 77: taskexecutor.ListSchedulesRequest
+	(*ListSchedulesResponse)(nil),         // 78: taskexecutor.ListSchedulesResponse
+	(*DeleteScheduleRequest)(nil),         // 79: taskexecutor.DeleteScheduleRequest
+	(*DeleteScheduleResponse)(nil),        // 80: taskexecutor.DeleteScheduleResponse
+	(*PauseScheduleRequest)(nil),          // 81: taskexecutor.PauseScheduleRequest
+	(*PauseScheduleResponse)(nil),         // 82: taskexecutor.PauseScheduleResponse
+	(*Executor)(nil),                      // 83: taskexecutor.Executor
+	(*ExecutorConfig)(nil),                // 84: taskexecutor.ExecutorConfig
+	(*RateLimit)(nil),                     // 85: taskexecutor.RateLimit
+	(*PriorityAging)(nil),                 // 86: taskexecutor.PriorityAging
+	(*WriteConcern)(nil),                  // 87: taskexecutor.WriteConcern
+	(*RetryPolicy)(nil),                   // 88: taskexecutor.RetryPolicy
+	(*DLQConfig)(nil),                     // 89: taskexecutor.DLQConfig
+	(*Schedule)(nil),                      // 90: taskexecutor.Schedule
+	(*Task)(nil),                          // 91: taskexecutor.Task
+	(*TaskHistoryEntry)(nil),              // 92: taskexecutor.TaskHistoryEntry
+	(*TaskProgress)(nil),                  // 93: taskexecutor.TaskProgress
+	nil,                                   // 94: taskexecutor.AddTaskRequest.MetadataEntry
+	nil,                                   // 95: taskexecutor.ListTasksRequest.MetadataEntry
+	nil,                                   // 96: taskexecutor.DLQFilter.MetadataEntry
+	nil,                                   // 97: taskexecutor.Schedule.MetadataEntry
+	nil,                                   // 98: taskexecutor.Task.MetadataEntry
+	nil,                                   // 99: taskexecutor.TaskHistoryEntry.DetailsEntry
+	(*timestamppb.Timestamp)(nil),         // 100: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),           // 101: google.protobuf.Duration
+	(*fieldmaskpb.FieldMask)(nil),         // 102: google.protobuf.FieldMask
 }
 var file_proto_task_executor_proto_depIdxs = []int32{
-	31, // 0: taskexecutor.AddTaskRequest.metadata:type_name -> taskexecutor.AddTaskRequest.MetadataEntry
-	28, // 1: taskexecutor.AddTaskResponse.task:type_name -> taskexecutor.Task
-	2,  // 2: taskexecutor.GetTaskStatusResponse.status:type_name -> taskexecutor.TaskStatus
-	28, // 3: taskexecutor.GetNextTaskResponse.task:type_name -> taskexecutor.Task
-	2,  // 4: taskexecutor.UpdateTaskStatusRequest.status:type_name -> taskexecutor.TaskStatus
-	28, // 5: taskexecutor.UpdateTaskStatusResponse.task:type_name -> taskexecutor.Task
-	24, // 6: taskexecutor.CreateExecutorRequest.config:type_name -> taskexecutor.ExecutorConfig
-	23, // 7: taskexecutor.CreateExecutorResponse.executor:type_name -> taskexecutor.Executor
-	24, // 8: taskexecutor.UpdateExecutorRequest.config:type_name -> taskexecutor.ExecutorConfig
-	23, // 9: taskexecutor.UpdateExecutorResponse.executor:type_name -> taskexecutor.Executor
-	23, // 10: taskexecutor.GetExecutorResponse.executor:type_name -> taskexecutor.Executor
-	23, // 11: taskexecutor.ListExecutorsResponse.executors:type_name -> taskexecutor.Executor
-	24, // 12: taskexecutor.Executor.config:type_name -> taskexecutor.ExecutorConfig
-	33, // 13: taskexecutor.Executor.created_at:type_name -> google.protobuf.Timestamp
-	33, // 14: taskexecutor.Executor.updated_at:type_name -> google.protobuf.Timestamp
-	25, // 15: taskexecutor.ExecutorConfig.write_concern:type_name -> taskexecutor.WriteConcern
-	26, // 16: taskexecutor.ExecutorConfig.retry_policy:type_name -> taskexecutor.RetryPolicy
-	27, // 17: taskexecutor.ExecutorConfig.dlq_config:type_name -> taskexecutor.DLQConfig
-	0,  // 18: taskexecutor.WriteConcern.level:type_name -> taskexecutor.WriteConcernLevel
-	1,  // 19: taskexecutor.RetryPolicy.type:type_name -> taskexecutor.RetryPolicyType
-	34, // 20: taskexecutor.RetryPolicy.interval:type_name -> google.protobuf.Duration
-	32, // 21: taskexecutor.Task.metadata:type_name -> taskexecutor.Task.MetadataEntry
-	2,  // 22: taskexecutor.Task.status:type_name -> taskexecutor.TaskStatus
-	33, // 23: taskexecutor.Task.created_at:type_name -> google.protobuf.Timestamp
-	33, // 24: taskexecutor.Task.updated_at:type_name -> google.protobuf.Timestamp
-	33, // 25: taskexecutor.Task.started_at:type_name -> google.protobuf.Timestamp
-	33, // 26: taskexecutor.Task.completed_at:type_name -> google.protobuf.Timestamp
-	30, // 27: taskexecutor.Order.items:type_name -> taskexecutor.OrderItem
-	33, // 28: taskexecutor.Order.created_at:type_name -> google.protobuf.Timestamp
-	33, // 29: taskexecutor.Order.updated_at:type_name -> google.protobuf.Timestamp
-	3,  // 30: taskexecutor.TaskExecutorManager.AddTask:input_type -> taskexecutor.AddTaskRequest
-	5,  // 31: taskexecutor.TaskExecutorManager.GetTaskStatus:input_type -> taskexecutor.GetTaskStatusRequest
-	7,  // 32: taskexecutor.TaskExecutorManager.RegisterExecutor:input_type -> taskexecutor.RegisterExecutorRequest
-	9,  // 33: taskexecutor.TaskExecutorManager.GetNextTask:input_type -> taskexecutor.GetNextTaskRequest
-	11, // 34: taskexecutor.TaskExecutorManager.UpdateTaskStatus:input_type -> taskexecutor.UpdateTaskStatusRequest
-	13, // 35: taskexecutor.TaskExecutorManager.CreateExecutor:input_type -> taskexecutor.CreateExecutorRequest
-	15, // 36: taskexecutor.TaskExecutorManager.UpdateExecutor:input_type -> taskexecutor.UpdateExecutorRequest
-	17, // 37: taskexecutor.TaskExecutorManager.GetExecutor:input_type -> taskexecutor.GetExecutorRequest
-	19, // 38: taskexecutor.TaskExecutorManager.ListExecutors:input_type -> taskexecutor.ListExecutorsRequest
-	21, // 39: taskexecutor.TaskExecutorManager.DeleteExecutor:input_type -> taskexecutor.DeleteExecutorRequest
-	4,  // 40: taskexecutor.TaskExecutorManager.AddTask:output_type -> taskexecutor.AddTaskResponse
-	6,  // 41: taskexecutor.TaskExecutorManager.GetTaskStatus:output_type -> taskexecutor.GetTaskStatusResponse
-	8,  // 42: taskexecutor.TaskExecutorManager.RegisterExecutor:output_type -> taskexecutor.RegisterExecutorResponse
-	10, // 43: taskexecutor.TaskExecutorManager.GetNextTask:output_type -> taskexecutor.GetNextTaskResponse
-	12, // 44: taskexecutor.TaskExecutorManager.UpdateTaskStatus:output_type -> taskexecutor.UpdateTaskStatusResponse
-	14, // 45: taskexecutor.TaskExecutorManager.CreateExecutor:output_type -> taskexecutor.CreateExecutorResponse
-	16, // 46: taskexecutor.TaskExecutorManager.UpdateExecutor:output_type -> taskexecutor.UpdateExecutorResponse
-	18, // 47: taskexecutor.TaskExecutorManager.GetExecutor:output_type -> taskexecutor.GetExecutorResponse
-	20, // 48: taskexecutor.TaskExecutorManager.ListExecutors:output_type -> taskexecutor.ListExecutorsResponse
-	22, // 49: taskexecutor.TaskExecutorManager.DeleteExecutor:output_type -> taskexecutor.DeleteExecutorResponse
-	40, // [40:50] is the sub-list for method output_type
-	30, // [30:40] is the sub-list for method input_type
-	30, // [30:30] is the sub-list for extension type_name
-	30, // [30:30] is the sub-list for extension extendee
-	0,  // [0:30] is the sub-list for field type_name
+	94,  // 0: taskexecutor.AddTaskRequest.metadata:type_name -> taskexecutor.AddTaskRequest.MetadataEntry
+	100, // 1: taskexecutor.AddTaskRequest.run_at:type_name -> google.protobuf.Timestamp
+	101, // 2: taskexecutor.AddTaskRequest.delay:type_name -> google.protobuf.Duration
+	91,  // 3: taskexecutor.AddTaskResponse.task:type_name -> taskexecutor.Task
+	6,   // 4: taskexecutor.GetTaskStatusResponse.status:type_name -> taskexecutor.TaskStatus
+	91,  // 5: taskexecutor.CancelTaskResponse.task:type_name -> taskexecutor.Task
+	6,   // 6: taskexecutor.ListTasksRequest.statuses:type_name -> taskexecutor.TaskStatus
+	100, // 7: taskexecutor.ListTasksRequest.created_after:type_name -> google.protobuf.Timestamp
+	100, // 8: taskexecutor.ListTasksRequest.created_before:type_name -> google.protobuf.Timestamp
+	100, // 9: taskexecutor.ListTasksRequest.updated_after:type_name -> google.protobuf.Timestamp
+	100, // 10: taskexecutor.ListTasksRequest.updated_before:type_name -> google.protobuf.Timestamp
+	95,  // 11: taskexecutor.ListTasksRequest.metadata:type_name -> taskexecutor.ListTasksRequest.MetadataEntry
+	91,  // 12: taskexecutor.ListTasksResponse.tasks:type_name -> taskexecutor.Task
+	101, // 13: taskexecutor.RegisterExecutorResponse.heartbeat_interval:type_name -> google.protobuf.Duration
+	21,  // 14: taskexecutor.ListWorkersResponse.workers:type_name -> taskexecutor.Worker
+	0,   // 15: taskexecutor.Worker.status:type_name -> taskexecutor.WorkerStatus
+	100, // 16: taskexecutor.Worker.registered_at:type_name -> google.protobuf.Timestamp
+	100, // 17: taskexecutor.Worker.last_heartbeat_at:type_name -> google.protobuf.Timestamp
+	101, // 18: taskexecutor.GetNextTaskRequest.wait_timeout:type_name -> google.protobuf.Duration
+	91,  // 19: taskexecutor.GetNextTaskResponse.task:type_name -> taskexecutor.Task
+	91,  // 20: taskexecutor.GetNextTaskResponse.tasks:type_name -> taskexecutor.Task
+	101, // 21: taskexecutor.GetNextTaskResponse.retry_after:type_name -> google.protobuf.Duration
+	6,   // 22: taskexecutor.UpdateTaskStatusRequest.status:type_name -> taskexecutor.TaskStatus
+	91,  // 23: taskexecutor.UpdateTaskStatusResponse.task:type_name -> taskexecutor.Task
+	24,  // 24: taskexecutor.UpdateTaskStatusesRequest.updates:type_name -> taskexecutor.UpdateTaskStatusRequest
+	28,  // 25: taskexecutor.UpdateTaskStatusesResponse.results:type_name -> taskexecutor.UpdateTaskStatusResult
+	91,  // 26: taskexecutor.UpdateTaskStatusResult.task:type_name -> taskexecutor.Task
+	93,  // 27: taskexecutor.HeartbeatTaskRequest.progress:type_name -> taskexecutor.TaskProgress
+	100, // 28: taskexecutor.HeartbeatTaskResponse.lease_expires_at:type_name -> google.protobuf.Timestamp
+	91,  // 29: taskexecutor.ReleaseTaskResponse.task:type_name -> taskexecutor.Task
+	84,  // 30: taskexecutor.CreateExecutorRequest.config:type_name -> taskexecutor.ExecutorConfig
+	83,  // 31: taskexecutor.CreateExecutorResponse.executor:type_name -> taskexecutor.Executor
+	84,  // 32: taskexecutor.UpdateExecutorRequest.config:type_name -> taskexecutor.ExecutorConfig
+	102, // 33: taskexecutor.UpdateExecutorRequest.update_mask:type_name -> google.protobuf.FieldMask
+	83,  // 34: taskexecutor.UpdateExecutorResponse.executor:type_name -> taskexecutor.Executor
+	83,  // 35: taskexecutor.GetExecutorResponse.executor:type_name -> taskexecutor.Executor
+	39,  // 36: taskexecutor.GetExecutorResponse.dlq_stats:type_name -> taskexecutor.DLQStats
+	101, // 37: taskexecutor.DLQStats.oldest_entry_age:type_name -> google.protobuf.Duration
+	83,  // 38: taskexecutor.ListExecutorsResponse.executors:type_name -> taskexecutor.Executor
+	1,   // 39: taskexecutor.DeleteExecutorRequest.policy:type_name -> taskexecutor.ExecutorDeletionPolicy
+	100, // 40: taskexecutor.DeleteExecutorResponse.deleted_at:type_name -> google.protobuf.Timestamp
+	100, // 41: taskexecutor.DeleteExecutorResponse.restore_until:type_name -> google.protobuf.Timestamp
+	83,  // 42: taskexecutor.UndeleteExecutorResponse.executor:type_name -> taskexecutor.Executor
+	50,  // 43: taskexecutor.ListExecutorRevisionsResponse.revisions:type_name -> taskexecutor.ExecutorRevision
+	83,  // 44: taskexecutor.RollbackExecutorResponse.executor:type_name -> taskexecutor.Executor
+	2,   // 45: taskexecutor.ExecutorRevision.action:type_name -> taskexecutor.ExecutorRevisionAction
+	84,  // 46: taskexecutor.ExecutorRevision.config:type_name -> taskexecutor.ExecutorConfig
+	51,  // 47: taskexecutor.ExecutorRevision.changes:type_name -> taskexecutor.ExecutorFieldChange
+	100, // 48: taskexecutor.ExecutorRevision.created_at:type_name -> google.protobuf.Timestamp
+	83,  // 49: taskexecutor.PauseExecutorResponse.executor:type_name -> taskexecutor.Executor
+	83,  // 50: taskexecutor.ResumeExecutorResponse.executor:type_name -> taskexecutor.Executor
+	83,  // 51: taskexecutor.DrainExecutorResponse.executor:type_name -> taskexecutor.Executor
+	83,  // 52: taskexecutor.DisableExecutorResponse.executor:type_name -> taskexecutor.Executor
+	100, // 53: taskexecutor.DLQFilter.failed_after:type_name -> google.protobuf.Timestamp
+	100, // 54: taskexecutor.DLQFilter.failed_before:type_name -> google.protobuf.Timestamp
+	96,  // 55: taskexecutor.DLQFilter.metadata:type_name -> taskexecutor.DLQFilter.MetadataEntry
+	60,  // 56: taskexecutor.ListDLQTasksRequest.filter:type_name -> taskexecutor.DLQFilter
+	91,  // 57: taskexecutor.ListDLQTasksResponse.tasks:type_name -> taskexecutor.Task
+	60,  // 58: taskexecutor.PurgeDLQRequest.filter:type_name -> taskexecutor.DLQFilter
+	60,  // 59: taskexecutor.RedriveDLQRequest.filter:type_name -> taskexecutor.DLQFilter
+	100, // 60: taskexecutor.DLQ.oldest_entry_at:type_name -> google.protobuf.Timestamp
+	67,  // 61: taskexecutor.ListDLQsResponse.queues:type_name -> taskexecutor.DLQ
+	67,  // 62: taskexecutor.GetDLQResponse.queue:type_name -> taskexecutor.DLQ
+	60,  // 63: taskexecutor.DLQSummaryRequest.filter:type_name -> taskexecutor.DLQFilter
+	74,  // 64: taskexecutor.DLQSummaryResponse.groups:type_name -> taskexecutor.DLQErrorGroup
+	100, // 65: taskexecutor.DLQErrorGroup.first_seen:type_name -> google.protobuf.Timestamp
+	100, // 66: taskexecutor.DLQErrorGroup.last_seen:type_name -> google.protobuf.Timestamp
+	90,  // 67: taskexecutor.CreateScheduleRequest.schedule:type_name -> taskexecutor.Schedule
+	90,  // 68: taskexecutor.CreateScheduleResponse.schedule:type_name -> taskexecutor.Schedule
+	90,  // 69: taskexecutor.ListSchedulesResponse.schedules:type_name -> taskexecutor.Schedule
+	90,  // 70: taskexecutor.PauseScheduleResponse.schedule:type_name -> taskexecutor.Schedule
+	84,  // 71: taskexecutor.Executor.config:type_name -> taskexecutor.ExecutorConfig
+	100, // 72: taskexecutor.Executor.created_at:type_name -> google.protobuf.Timestamp
+	100, // 73: taskexecutor.Executor.updated_at:type_name -> google.protobuf.Timestamp
+	3,   // 74: taskexecutor.Executor.state:type_name -> taskexecutor.ExecutorState
+	100, // 75: taskexecutor.Executor.deleted_at:type_name -> google.protobuf.Timestamp
+	87,  // 76: taskexecutor.ExecutorConfig.write_concern:type_name -> taskexecutor.WriteConcern
+	88,  // 77: taskexecutor.ExecutorConfig.retry_policy:type_name -> taskexecutor.RetryPolicy
+	89,  // 78: taskexecutor.ExecutorConfig.dlq_config:type_name -> taskexecutor.DLQConfig
+	101, // 79: taskexecutor.ExecutorConfig.lease_duration:type_name -> google.protobuf.Duration
+	86,  // 80: taskexecutor.ExecutorConfig.priority_aging:type_name -> taskexecutor.PriorityAging
+	101, // 81: taskexecutor.ExecutorConfig.dedup_window:type_name -> google.protobuf.Duration
+	85,  // 82: taskexecutor.ExecutorConfig.rate_limit:type_name -> taskexecutor.RateLimit
+	3,   // 83: taskexecutor.ExecutorConfig.state:type_name -> taskexecutor.ExecutorState
+	101, // 84: taskexecutor.PriorityAging.threshold:type_name -> google.protobuf.Duration
+	4,   // 85: taskexecutor.WriteConcern.level:type_name -> taskexecutor.WriteConcernLevel
+	5,   // 86: taskexecutor.RetryPolicy.type:type_name -> taskexecutor.RetryPolicyType
+	101, // 87: taskexecutor.RetryPolicy.interval:type_name -> google.protobuf.Duration
+	101, // 88: taskexecutor.RetryPolicy.max_interval:type_name -> google.protobuf.Duration
+	101, // 89: taskexecutor.DLQConfig.max_age:type_name -> google.protobuf.Duration
+	97,  // 90: taskexecutor.Schedule.metadata:type_name -> taskexecutor.Schedule.MetadataEntry
+	100, // 91: taskexecutor.Schedule.next_run_at:type_name -> google.protobuf.Timestamp
+	100, // 92: taskexecutor.Schedule.last_run_at:type_name -> google.protobuf.Timestamp
+	100, // 93: taskexecutor.Schedule.created_at:type_name -> google.protobuf.Timestamp
+	100, // 94: taskexecutor.Schedule.updated_at:type_name -> google.protobuf.Timestamp
+	98,  // 95: taskexecutor.Task.metadata:type_name -> taskexecutor.Task.MetadataEntry
+	6,   // 96: taskexecutor.Task.status:type_name -> taskexecutor.TaskStatus
+	100, // 97: taskexecutor.Task.created_at:type_name -> google.protobuf.Timestamp
+	100, // 98: taskexecutor.Task.updated_at:type_name -> google.protobuf.Timestamp
+	100, // 99: taskexecutor.Task.started_at:type_name -> google.protobuf.Timestamp
+	100, // 100: taskexecutor.Task.completed_at:type_name -> google.protobuf.Timestamp
+	100, // 101: taskexecutor.Task.next_attempt_at:type_name -> google.protobuf.Timestamp
+	100, // 102: taskexecutor.Task.lease_expires_at:type_name -> google.protobuf.Timestamp
+	93,  // 103: taskexecutor.Task.progress:type_name -> taskexecutor.TaskProgress
+	100, // 104: taskexecutor.Task.run_at:type_name -> google.protobuf.Timestamp
+	92,  // 105: taskexecutor.Task.history:type_name -> taskexecutor.TaskHistoryEntry
+	100, // 106: taskexecutor.TaskHistoryEntry.at:type_name -> google.protobuf.Timestamp
+	99,  // 107: taskexecutor.TaskHistoryEntry.details:type_name -> taskexecutor.TaskHistoryEntry.DetailsEntry
+	100, // 108: taskexecutor.TaskProgress.updated_at:type_name -> google.protobuf.Timestamp
+	7,   // 109: taskexecutor.TaskExecutorManager.AddTask:input_type -> taskexecutor.AddTaskRequest
+	9,   // 110: taskexecutor.TaskExecutorManager.GetTaskStatus:input_type -> taskexecutor.GetTaskStatusRequest
+	11,  // 111: taskexecutor.TaskExecutorManager.CancelTask:input_type -> taskexecutor.CancelTaskRequest
+	13,  // 112: taskexecutor.TaskExecutorManager.ListTasks:input_type -> taskexecutor.ListTasksRequest
+	15,  // 113: taskexecutor.TaskExecutorManager.RegisterExecutor:input_type -> taskexecutor.RegisterExecutorRequest
+	22,  // 114: taskexecutor.TaskExecutorManager.GetNextTask:input_type -> taskexecutor.GetNextTaskRequest
+	24,  // 115: taskexecutor.TaskExecutorManager.UpdateTaskStatus:input_type -> taskexecutor.UpdateTaskStatusRequest
+	26,  // 116: taskexecutor.TaskExecutorManager.UpdateTaskStatuses:input_type -> taskexecutor.UpdateTaskStatusesRequest
+	29,  // 117: taskexecutor.TaskExecutorManager.HeartbeatTask:input_type -> taskexecutor.HeartbeatTaskRequest
+	31,  // 118: taskexecutor.TaskExecutorManager.ReleaseTask:input_type -> taskexecutor.ReleaseTaskRequest
+	17,  // 119: taskexecutor.TaskExecutorManager.HeartbeatWorker:input_type -> taskexecutor.HeartbeatWorkerRequest
+	19,  // 120: taskexecutor.TaskExecutorManager.ListWorkers:input_type -> taskexecutor.ListWorkersRequest
+	33,  // 121: taskexecutor.TaskExecutorManager.CreateExecutor:input_type -> taskexecutor.CreateExecutorRequest
+	35,  // 122: taskexecutor.TaskExecutorManager.UpdateExecutor:input_type -> taskexecutor.UpdateExecutorRequest
+	37,  // 123: taskexecutor.TaskExecutorManager.GetExecutor:input_type -> taskexecutor.GetExecutorRequest
+	40,  // 124: taskexecutor.TaskExecutorManager.ListExecutors:input_type -> taskexecutor.ListExecutorsRequest
+	42,  // 125: taskexecutor.TaskExecutorManager.DeleteExecutor:input_type -> taskexecutor.DeleteExecutorRequest
+	52,  // 126: taskexecutor.TaskExecutorManager.PauseExecutor:input_type -> taskexecutor.PauseExecutorRequest
+	54,  // 127: taskexecutor.TaskExecutorManager.ResumeExecutor:input_type -> taskexecutor.ResumeExecutorRequest
+	56,  // 128: taskexecutor.TaskExecutorManager.DrainExecutor:input_type -> taskexecutor.DrainExecutorRequest
+	58,  // 129: taskexecutor.TaskExecutorManager.DisableExecutor:input_type -> taskexecutor.DisableExecutorRequest
+	44,  // 130: taskexecutor.TaskExecutorManager.UndeleteExecutor:input_type -> taskexecutor.UndeleteExecutorRequest
+	46,  // 131: taskexecutor.TaskExecutorManager.ListExecutorRevisions:input_type -> taskexecutor.ListExecutorRevisionsRequest
+	48,  // 132: taskexecutor.TaskExecutorManager.RollbackExecutor:input_type -> taskexecutor.RollbackExecutorRequest
+	61,  // 133: taskexecutor.TaskExecutorManager.ListDLQTasks:input_type -> taskexecutor.ListDLQTasksRequest
+	63,  // 134: taskexecutor.TaskExecutorManager.PurgeDLQ:input_type -> taskexecutor.PurgeDLQRequest
+	65,  // 135: taskexecutor.TaskExecutorManager.RedriveDLQ:input_type -> taskexecutor.RedriveDLQRequest
+	68,  // 136: taskexecutor.TaskExecutorManager.ListDLQs:input_type -> taskexecutor.ListDLQsRequest
+	70,  // 137: taskexecutor.TaskExecutorManager.GetDLQ:input_type -> taskexecutor.GetDLQRequest
+	72,  // 138: taskexecutor.TaskExecutorManager.DLQSummary:input_type -> taskexecutor.DLQSummaryRequest
+	75,  // 139: taskexecutor.TaskExecutorManager.CreateSchedule:input_type -> taskexecutor.CreateScheduleRequest
+	77,  // 140: taskexecutor.TaskExecutorManager.ListSchedules:input_type -> taskexecutor.ListSchedulesRequest
+	79,  // 141: taskexecutor.TaskExecutorManager.DeleteSchedule:input_type -> taskexecutor.DeleteScheduleRequest
+	81,  // 142: taskexecutor.TaskExecutorManager.PauseSchedule:input_type -> taskexecutor.PauseScheduleRequest
+	8,   // 143: taskexecutor.TaskExecutorManager.AddTask:output_type -> taskexecutor.AddTaskResponse
+	10,  // 144: taskexecutor.TaskExecutorManager.GetTaskStatus:output_type -> taskexecutor.GetTaskStatusResponse
+	12,  // 145: taskexecutor.TaskExecutorManager.CancelTask:output_type -> taskexecutor.CancelTaskResponse
+	14,  // 146: taskexecutor.TaskExecutorManager.ListTasks:output_type -> taskexecutor.ListTasksResponse
+	16,  // 147: taskexecutor.TaskExecutorManager.RegisterExecutor:output_type -> taskexecutor.RegisterExecutorResponse
+	23,  // 148: taskexecutor.TaskExecutorManager.GetNextTask:output_type -> taskexecutor.GetNextTaskResponse
+	25,  // 149: taskexecutor.TaskExecutorManager.UpdateTaskStatus:output_type -> taskexecutor.UpdateTaskStatusResponse
+	27,  // 150: taskexecutor.TaskExecutorManager.UpdateTaskStatuses:output_type -> taskexecutor.UpdateTaskStatusesResponse
+	30,  // 151: taskexecutor.TaskExecutorManager.HeartbeatTask:output_type -> taskexecutor.HeartbeatTaskResponse
+	32,  // 152: taskexecutor.TaskExecutorManager.ReleaseTask:output_type -> taskexecutor.ReleaseTaskResponse
+	18,  // 153: taskexecutor.TaskExecutorManager.HeartbeatWorker:output_type -> taskexecutor.HeartbeatWorkerResponse
+	20,  // 154: taskexecutor.TaskExecutorManager.ListWorkers:output_type -> taskexecutor.ListWorkersResponse
+	34,  // 155: taskexecutor.TaskExecutorManager.CreateExecutor:output_type -> taskexecutor.CreateExecutorResponse
+	36,  // 156: taskexecutor.TaskExecutorManager.UpdateExecutor:output_type -> taskexecutor.UpdateExecutorResponse
+	38,  // 157: taskexecutor.TaskExecutorManager.GetExecutor:output_type -> taskexecutor.GetExecutorResponse
+	41,  // 158: taskexecutor.TaskExecutorManager.ListExecutors:output_type -> taskexecutor.ListExecutorsResponse
+	43,  // 159: taskexecutor.TaskExecutorManager.DeleteExecutor:output_type -> taskexecutor.DeleteExecutorResponse
+	53,  // 160: taskexecutor.TaskExecutorManager.PauseExecutor:output_type -> taskexecutor.PauseExecutorResponse
+	55,  // 161: taskexecutor.TaskExecutorManager.ResumeExecutor:output_type -> taskexecutor.ResumeExecutorResponse
+	57,  // 162: taskexecutor.TaskExecutorManager.DrainExecutor:output_type -> taskexecutor.DrainExecutorResponse
+	59,  // 163: taskexecutor.TaskExecutorManager.DisableExecutor:output_type -> taskexecutor.DisableExecutorResponse
+	45,  // 164: taskexecutor.TaskExecutorManager.UndeleteExecutor:output_type -> taskexecutor.UndeleteExecutorResponse
+	47,  // 165: taskexecutor.TaskExecutorManager.ListExecutorRevisions:output_type -> taskexecutor.ListExecutorRevisionsResponse
+	49,  // 166: taskexecutor.TaskExecutorManager.RollbackExecutor:output_type -> taskexecutor.RollbackExecutorResponse
+	62,  // 167: taskexecutor.TaskExecutorManager.ListDLQTasks:output_type -> taskexecutor.ListDLQTasksResponse
+	64,  // 168: taskexecutor.TaskExecutorManager.PurgeDLQ:output_type -> taskexecutor.PurgeDLQResponse
+	66,  // 169: taskexecutor.TaskExecutorManager.RedriveDLQ:output_type -> taskexecutor.RedriveDLQResponse
+	69,  // 170: taskexecutor.TaskExecutorManager.ListDLQs:output_type -> taskexecutor.ListDLQsResponse
+	71,  // 171: taskexecutor.TaskExecutorManager.GetDLQ:output_type -> taskexecutor.GetDLQResponse
+	73,  // 172: taskexecutor.TaskExecutorManager.DLQSummary:output_type -> taskexecutor.DLQSummaryResponse
+	76,  // 173: taskexecutor.TaskExecutorManager.CreateSchedule:output_type -> taskexecutor.CreateScheduleResponse
+	78,  // 174: taskexecutor.TaskExecutorManager.ListSchedules:output_type -> taskexecutor.ListSchedulesResponse
+	80,  // 175: taskexecutor.TaskExecutorManager.DeleteSchedule:output_type -> taskexecutor.DeleteScheduleResponse
+	82,  // 176: taskexecutor.TaskExecutorManager.PauseSchedule:output_type -> taskexecutor.PauseScheduleResponse
+	143, // [143:177] is the sub-list for method output_type
+	109, // [109:143] is the sub-list for method input_type
+	109, // [109:109] is the sub-list for extension type_name
+	109, // [109:109] is the sub-list for extension extendee
+	0,   // [0:109] is the sub-list for field type_name
 }
 
 func init() { file_proto_task_executor_proto_init() }
@@ -1999,8 +6668,8 @@ func file_proto_task_executor_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_task_executor_proto_rawDesc), len(file_proto_task_executor_proto_rawDesc)),
-			NumEnums:      3,
-			NumMessages:   30,
+			NumEnums:      7,
+			NumMessages:   93,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,16 +19,40 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TaskExecutorManager_AddTask_FullMethodName          = "/taskexecutor.TaskExecutorManager/AddTask"
-	TaskExecutorManager_GetTaskStatus_FullMethodName    = "/taskexecutor.TaskExecutorManager/GetTaskStatus"
-	TaskExecutorManager_RegisterExecutor_FullMethodName = "/taskexecutor.TaskExecutorManager/RegisterExecutor"
-	TaskExecutorManager_GetNextTask_FullMethodName      = "/taskexecutor.TaskExecutorManager/GetNextTask"
-	TaskExecutorManager_UpdateTaskStatus_FullMethodName = "/taskexecutor.TaskExecutorManager/UpdateTaskStatus"
-	TaskExecutorManager_CreateExecutor_FullMethodName   = "/taskexecutor.TaskExecutorManager/CreateExecutor"
-	TaskExecutorManager_UpdateExecutor_FullMethodName   = "/taskexecutor.TaskExecutorManager/UpdateExecutor"
-	TaskExecutorManager_GetExecutor_FullMethodName      = "/taskexecutor.TaskExecutorManager/GetExecutor"
-	TaskExecutorManager_ListExecutors_FullMethodName    = "/taskexecutor.TaskExecutorManager/ListExecutors"
-	TaskExecutorManager_DeleteExecutor_FullMethodName   = "/taskexecutor.TaskExecutorManager/DeleteExecutor"
+	TaskExecutorManager_AddTask_FullMethodName               = "/taskexecutor.TaskExecutorManager/AddTask"
+	TaskExecutorManager_GetTaskStatus_FullMethodName         = "/taskexecutor.TaskExecutorManager/GetTaskStatus"
+	TaskExecutorManager_CancelTask_FullMethodName            = "/taskexecutor.TaskExecutorManager/CancelTask"
+	TaskExecutorManager_ListTasks_FullMethodName             = "/taskexecutor.TaskExecutorManager/ListTasks"
+	TaskExecutorManager_RegisterExecutor_FullMethodName      = "/taskexecutor.TaskExecutorManager/RegisterExecutor"
+	TaskExecutorManager_GetNextTask_FullMethodName           = "/taskexecutor.TaskExecutorManager/GetNextTask"
+	TaskExecutorManager_UpdateTaskStatus_FullMethodName      = "/taskexecutor.TaskExecutorManager/UpdateTaskStatus"
+	TaskExecutorManager_UpdateTaskStatuses_FullMethodName    = "/taskexecutor.TaskExecutorManager/UpdateTaskStatuses"
+	TaskExecutorManager_HeartbeatTask_FullMethodName         = "/taskexecutor.TaskExecutorManager/HeartbeatTask"
+	TaskExecutorManager_ReleaseTask_FullMethodName           = "/taskexecutor.TaskExecutorManager/ReleaseTask"
+	TaskExecutorManager_HeartbeatWorker_FullMethodName       = "/taskexecutor.TaskExecutorManager/HeartbeatWorker"
+	TaskExecutorManager_ListWorkers_FullMethodName           = "/taskexecutor.TaskExecutorManager/ListWorkers"
+	TaskExecutorManager_CreateExecutor_FullMethodName        = "/taskexecutor.TaskExecutorManager/CreateExecutor"
+	TaskExecutorManager_UpdateExecutor_FullMethodName        = "/taskexecutor.TaskExecutorManager/UpdateExecutor"
+	TaskExecutorManager_GetExecutor_FullMethodName           = "/taskexecutor.TaskExecutorManager/GetExecutor"
+	TaskExecutorManager_ListExecutors_FullMethodName         = "/taskexecutor.TaskExecutorManager/ListExecutors"
+	TaskExecutorManager_DeleteExecutor_FullMethodName        = "/taskexecutor.TaskExecutorManager/DeleteExecutor"
+	TaskExecutorManager_PauseExecutor_FullMethodName         = "/taskexecutor.TaskExecutorManager/PauseExecutor"
+	TaskExecutorManager_ResumeExecutor_FullMethodName        = "/taskexecutor.TaskExecutorManager/ResumeExecutor"
+	TaskExecutorManager_DrainExecutor_FullMethodName         = "/taskexecutor.TaskExecutorManager/DrainExecutor"
+	TaskExecutorManager_DisableExecutor_FullMethodName       = "/taskexecutor.TaskExecutorManager/DisableExecutor"
+	TaskExecutorManager_UndeleteExecutor_FullMethodName      = "/taskexecutor.TaskExecutorManager/UndeleteExecutor"
+	TaskExecutorManager_ListExecutorRevisions_FullMethodName = "/taskexecutor.TaskExecutorManager/ListExecutorRevisions"
+	TaskExecutorManager_RollbackExecutor_FullMethodName      = "/taskexecutor.TaskExecutorManager/RollbackExecutor"
+	TaskExecutorManager_ListDLQTasks_FullMethodName          = "/taskexecutor.TaskExecutorManager/ListDLQTasks"
+	TaskExecutorManager_PurgeDLQ_FullMethodName              = "/taskexecutor.TaskExecutorManager/PurgeDLQ"
+	TaskExecutorManager_RedriveDLQ_FullMethodName            = "/taskexecutor.TaskExecutorManager/RedriveDLQ"
+	TaskExecutorManager_ListDLQs_FullMethodName              = "/taskexecutor.TaskExecutorManager/ListDLQs"
+	TaskExecutorManager_GetDLQ_FullMethodName                = "/taskexecutor.TaskExecutorManager/GetDLQ"
+	TaskExecutorManager_DLQSummary_FullMethodName            = "/taskexecutor.TaskExecutorManager/DLQSummary"
+	TaskExecutorManager_CreateSchedule_FullMethodName        = "/taskexecutor.TaskExecutorManager/CreateSchedule"
+	TaskExecutorManager_ListSchedules_FullMethodName         = "/taskexecutor.TaskExecutorManager/ListSchedules"
+	TaskExecutorManager_DeleteSchedule_FullMethodName        = "/taskexecutor.TaskExecutorManager/DeleteSchedule"
+	TaskExecutorManager_PauseSchedule_FullMethodName         = "/taskexecutor.TaskExecutorManager/PauseSchedule"
 )
 
 // TaskExecutorManagerClient is the client API for TaskExecutorManager service.
@@ -40,16 +64,42 @@ type TaskExecutorManagerClient interface {
 	// Task Management
 	AddTask(ctx context.Context, in *AddTaskRequest, opts ...grpc.CallOption) (*AddTaskResponse, error)
 	GetTaskStatus(ctx context.Context, in *GetTaskStatusRequest, opts ...grpc.CallOption) (*GetTaskStatusResponse, error)
+	CancelTask(ctx context.Context, in *CancelTaskRequest, opts ...grpc.CallOption) (*CancelTaskResponse, error)
+	ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error)
 	// Executor Management
 	RegisterExecutor(ctx context.Context, in *RegisterExecutorRequest, opts ...grpc.CallOption) (*RegisterExecutorResponse, error)
 	GetNextTask(ctx context.Context, in *GetNextTaskRequest, opts ...grpc.CallOption) (*GetNextTaskResponse, error)
 	UpdateTaskStatus(ctx context.Context, in *UpdateTaskStatusRequest, opts ...grpc.CallOption) (*UpdateTaskStatusResponse, error)
+	UpdateTaskStatuses(ctx context.Context, in *UpdateTaskStatusesRequest, opts ...grpc.CallOption) (*UpdateTaskStatusesResponse, error)
+	HeartbeatTask(ctx context.Context, in *HeartbeatTaskRequest, opts ...grpc.CallOption) (*HeartbeatTaskResponse, error)
+	ReleaseTask(ctx context.Context, in *ReleaseTaskRequest, opts ...grpc.CallOption) (*ReleaseTaskResponse, error)
+	HeartbeatWorker(ctx context.Context, in *HeartbeatWorkerRequest, opts ...grpc.CallOption) (*HeartbeatWorkerResponse, error)
+	ListWorkers(ctx context.Context, in *ListWorkersRequest, opts ...grpc.CallOption) (*ListWorkersResponse, error)
 	// Executor Configuration
 	CreateExecutor(ctx context.Context, in *CreateExecutorRequest, opts ...grpc.CallOption) (*CreateExecutorResponse, error)
 	UpdateExecutor(ctx context.Context, in *UpdateExecutorRequest, opts ...grpc.CallOption) (*UpdateExecutorResponse, error)
 	GetExecutor(ctx context.Context, in *GetExecutorRequest, opts ...grpc.CallOption) (*GetExecutorResponse, error)
 	ListExecutors(ctx context.Context, in *ListExecutorsRequest, opts ...grpc.CallOption) (*ListExecutorsResponse, error)
 	DeleteExecutor(ctx context.Context, in *DeleteExecutorRequest, opts ...grpc.CallOption) (*DeleteExecutorResponse, error)
+	PauseExecutor(ctx context.Context, in *PauseExecutorRequest, opts ...grpc.CallOption) (*PauseExecutorResponse, error)
+	ResumeExecutor(ctx context.Context, in *ResumeExecutorRequest, opts ...grpc.CallOption) (*ResumeExecutorResponse, error)
+	DrainExecutor(ctx context.Context, in *DrainExecutorRequest, opts ...grpc.CallOption) (*DrainExecutorResponse, error)
+	DisableExecutor(ctx context.Context, in *DisableExecutorRequest, opts ...grpc.CallOption) (*DisableExecutorResponse, error)
+	UndeleteExecutor(ctx context.Context, in *UndeleteExecutorRequest, opts ...grpc.CallOption) (*UndeleteExecutorResponse, error)
+	ListExecutorRevisions(ctx context.Context, in *ListExecutorRevisionsRequest, opts ...grpc.CallOption) (*ListExecutorRevisionsResponse, error)
+	RollbackExecutor(ctx context.Context, in *RollbackExecutorRequest, opts ...grpc.CallOption) (*RollbackExecutorResponse, error)
+	// Dead Letter Queue
+	ListDLQTasks(ctx context.Context, in *ListDLQTasksRequest, opts ...grpc.CallOption) (*ListDLQTasksResponse, error)
+	PurgeDLQ(ctx context.Context, in *PurgeDLQRequest, opts ...grpc.CallOption) (*PurgeDLQResponse, error)
+	RedriveDLQ(ctx context.Context, in *RedriveDLQRequest, opts ...grpc.CallOption) (*RedriveDLQResponse, error)
+	ListDLQs(ctx context.Context, in *ListDLQsRequest, opts ...grpc.CallOption) (*ListDLQsResponse, error)
+	GetDLQ(ctx context.Context, in *GetDLQRequest, opts ...grpc.CallOption) (*GetDLQResponse, error)
+	DLQSummary(ctx context.Context, in *DLQSummaryRequest, opts ...grpc.CallOption) (*DLQSummaryResponse, error)
+	// Schedules
+	CreateSchedule(ctx context.Context, in *CreateScheduleRequest, opts ...grpc.CallOption) (*CreateScheduleResponse, error)
+	ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error)
+	DeleteSchedule(ctx context.Context, in *DeleteScheduleRequest, opts ...grpc.CallOption) (*DeleteScheduleResponse, error)
+	PauseSchedule(ctx context.Context, in *PauseScheduleRequest, opts ...grpc.CallOption) (*PauseScheduleResponse, error)
 }
 
 type taskExecutorManagerClient struct {
@@ -80,6 +130,26 @@ func (c *taskExecutorManagerClient) GetTaskStatus(ctx context.Context, in *GetTa
 	return out, nil
 }
 
+func (c *taskExecutorManagerClient) CancelTask(ctx context.Context, in *CancelTaskRequest, opts ...grpc.CallOption) (*CancelTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelTaskResponse)
+	err := c.cc.Invoke(ctx, TaskExecutorManager_CancelTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskExecutorManagerClient) ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTasksResponse)
+	err := c.cc.Invoke(ctx, TaskExecutorManager_ListTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *taskExecutorManagerClient) RegisterExecutor(ctx context.Context, in *RegisterExecutorRequest, opts ...grpc.CallOption) (*RegisterExecutorResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(RegisterExecutorResponse)
@@ -110,6 +180,56 @@ func (c *taskExecutorManagerClient) UpdateTaskStatus(ctx context.Context, in *Up
 	return out, nil
 }
 
+func (c *taskExecutorManagerClient) UpdateTaskStatuses(ctx context.Context, in *UpdateTaskStatusesRequest, opts ...grpc.CallOption) (*UpdateTaskStatusesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateTaskStatusesResponse)
+	err := c.cc.Invoke(ctx, TaskExecutorManager_UpdateTaskStatuses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskExecutorManagerClient) HeartbeatTask(ctx context.Context, in *HeartbeatTaskRequest, opts ...grpc.CallOption) (*HeartbeatTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HeartbeatTaskResponse)
+	err := c.cc.Invoke(ctx, TaskExecutorManager_HeartbeatTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskExecutorManagerClient) ReleaseTask(ctx context.Context, in *ReleaseTaskRequest, opts ...grpc.CallOption) (*ReleaseTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseTaskResponse)
+	err := c.cc.Invoke(ctx, TaskExecutorManager_ReleaseTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskExecutorManagerClient) HeartbeatWorker(ctx context.Context, in *HeartbeatWorkerRequest, opts ...grpc.CallOption) (*HeartbeatWorkerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HeartbeatWorkerResponse)
+	err := c.cc.Invoke(ctx, TaskExecutorManager_HeartbeatWorker_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskExecutorManagerClient) ListWorkers(ctx context.Context, in *ListWorkersRequest, opts ...grpc.CallOption) (*ListWorkersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWorkersResponse)
+	err := c.cc.Invoke(ctx, TaskExecutorManager_ListWorkers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *taskExecutorManagerClient) CreateExecutor(ctx context.Context, in *CreateExecutorRequest, opts ...grpc.CallOption) (*CreateExecutorResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CreateExecutorResponse)
@@ -160,6 +280,176 @@ func (c *taskExecutorManagerClient) DeleteExecutor(ctx context.Context, in *Dele
 	return out, nil
 }
 
+func (c *taskExecutorManagerClient) PauseExecutor(ctx context.Context, in *PauseExecutorRequest, opts ...grpc.CallOption) (*PauseExecutorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PauseExecutorResponse)
+	err := c.cc.Invoke(ctx, TaskExecutorManager_PauseExecutor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskExecutorManagerClient) ResumeExecutor(ctx context.Context, in *ResumeExecutorRequest, opts ...grpc.CallOption) (*ResumeExecutorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResumeExecutorResponse)
+	err := c.cc.Invoke(ctx, TaskExecutorManager_ResumeExecutor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskExecutorManagerClient) DrainExecutor(ctx context.Context, in *DrainExecutorRequest, opts ...grpc.CallOption) (*DrainExecutorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DrainExecutorResponse)
+	err := c.cc.Invoke(ctx, TaskExecutorManager_DrainExecutor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskExecutorManagerClient) DisableExecutor(ctx context.Context, in *DisableExecutorRequest, opts ...grpc.CallOption) (*DisableExecutorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DisableExecutorResponse)
+	err := c.cc.Invoke(ctx, TaskExecutorManager_DisableExecutor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskExecutorManagerClient) UndeleteExecutor(ctx context.Context, in *UndeleteExecutorRequest, opts ...grpc.CallOption) (*UndeleteExecutorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UndeleteExecutorResponse)
+	err := c.cc.Invoke(ctx, TaskExecutorManager_UndeleteExecutor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskExecutorManagerClient) ListExecutorRevisions(ctx context.Context, in *ListExecutorRevisionsRequest, opts ...grpc.CallOption) (*ListExecutorRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListExecutorRevisionsResponse)
+	err := c.cc.Invoke(ctx, TaskExecutorManager_ListExecutorRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskExecutorManagerClient) RollbackExecutor(ctx context.Context, in *RollbackExecutorRequest, opts ...grpc.CallOption) (*RollbackExecutorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RollbackExecutorResponse)
+	err := c.cc.Invoke(ctx, TaskExecutorManager_RollbackExecutor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskExecutorManagerClient) ListDLQTasks(ctx context.Context, in *ListDLQTasksRequest, opts ...grpc.CallOption) (*ListDLQTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDLQTasksResponse)
+	err := c.cc.Invoke(ctx, TaskExecutorManager_ListDLQTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskExecutorManagerClient) PurgeDLQ(ctx context.Context, in *PurgeDLQRequest, opts ...grpc.CallOption) (*PurgeDLQResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeDLQResponse)
+	err := c.cc.Invoke(ctx, TaskExecutorManager_PurgeDLQ_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskExecutorManagerClient) RedriveDLQ(ctx context.Context, in *RedriveDLQRequest, opts ...grpc.CallOption) (*RedriveDLQResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RedriveDLQResponse)
+	err := c.cc.Invoke(ctx, TaskExecutorManager_RedriveDLQ_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskExecutorManagerClient) ListDLQs(ctx context.Context, in *ListDLQsRequest, opts ...grpc.CallOption) (*ListDLQsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDLQsResponse)
+	err := c.cc.Invoke(ctx, TaskExecutorManager_ListDLQs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskExecutorManagerClient) GetDLQ(ctx context.Context, in *GetDLQRequest, opts ...grpc.CallOption) (*GetDLQResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDLQResponse)
+	err := c.cc.Invoke(ctx, TaskExecutorManager_GetDLQ_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskExecutorManagerClient) DLQSummary(ctx context.Context, in *DLQSummaryRequest, opts ...grpc.CallOption) (*DLQSummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DLQSummaryResponse)
+	err := c.cc.Invoke(ctx, TaskExecutorManager_DLQSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskExecutorManagerClient) CreateSchedule(ctx context.Context, in *CreateScheduleRequest, opts ...grpc.CallOption) (*CreateScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateScheduleResponse)
+	err := c.cc.Invoke(ctx, TaskExecutorManager_CreateSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskExecutorManagerClient) ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSchedulesResponse)
+	err := c.cc.Invoke(ctx, TaskExecutorManager_ListSchedules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskExecutorManagerClient) DeleteSchedule(ctx context.Context, in *DeleteScheduleRequest, opts ...grpc.CallOption) (*DeleteScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteScheduleResponse)
+	err := c.cc.Invoke(ctx, TaskExecutorManager_DeleteSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskExecutorManagerClient) PauseSchedule(ctx context.Context, in *PauseScheduleRequest, opts ...grpc.CallOption) (*PauseScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PauseScheduleResponse)
+	err := c.cc.Invoke(ctx, TaskExecutorManager_PauseSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TaskExecutorManagerServer is the server API for TaskExecutorManager service.
 // All implementations must embed UnimplementedTaskExecutorManagerServer
 // for forward compatibility.
@@ -169,16 +459,42 @@ type TaskExecutorManagerServer interface {
 	// Task Management
 	AddTask(context.Context, *AddTaskRequest) (*AddTaskResponse, error)
 	GetTaskStatus(context.Context, *GetTaskStatusRequest) (*GetTaskStatusResponse, error)
+	CancelTask(context.Context, *CancelTaskRequest) (*CancelTaskResponse, error)
+	ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error)
 	// Executor Management
 	RegisterExecutor(context.Context, *RegisterExecutorRequest) (*RegisterExecutorResponse, error)
 	GetNextTask(context.Context, *GetNextTaskRequest) (*GetNextTaskResponse, error)
 	UpdateTaskStatus(context.Context, *UpdateTaskStatusRequest) (*UpdateTaskStatusResponse, error)
+	UpdateTaskStatuses(context.Context, *UpdateTaskStatusesRequest) (*UpdateTaskStatusesResponse, error)
+	HeartbeatTask(context.Context, *HeartbeatTaskRequest) (*HeartbeatTaskResponse, error)
+	ReleaseTask(context.Context, *ReleaseTaskRequest) (*ReleaseTaskResponse, error)
+	HeartbeatWorker(context.Context, *HeartbeatWorkerRequest) (*HeartbeatWorkerResponse, error)
+	ListWorkers(context.Context, *ListWorkersRequest) (*ListWorkersResponse, error)
 	// Executor Configuration
 	CreateExecutor(context.Context, *CreateExecutorRequest) (*CreateExecutorResponse, error)
 	UpdateExecutor(context.Context, *UpdateExecutorRequest) (*UpdateExecutorResponse, error)
 	GetExecutor(context.Context, *GetExecutorRequest) (*GetExecutorResponse, error)
 	ListExecutors(context.Context, *ListExecutorsRequest) (*ListExecutorsResponse, error)
 	DeleteExecutor(context.Context, *DeleteExecutorRequest) (*DeleteExecutorResponse, error)
+	PauseExecutor(context.Context, *PauseExecutorRequest) (*PauseExecutorResponse, error)
+	ResumeExecutor(context.Context, *ResumeExecutorRequest) (*ResumeExecutorResponse, error)
+	DrainExecutor(context.Context, *DrainExecutorRequest) (*DrainExecutorResponse, error)
+	DisableExecutor(context.Context, *DisableExecutorRequest) (*DisableExecutorResponse, error)
+	UndeleteExecutor(context.Context, *UndeleteExecutorRequest) (*UndeleteExecutorResponse, error)
+	ListExecutorRevisions(context.Context, *ListExecutorRevisionsRequest) (*ListExecutorRevisionsResponse, error)
+	RollbackExecutor(context.Context, *RollbackExecutorRequest) (*RollbackExecutorResponse, error)
+	// Dead Letter Queue
+	ListDLQTasks(context.Context, *ListDLQTasksRequest) (*ListDLQTasksResponse, error)
+	PurgeDLQ(context.Context, *PurgeDLQRequest) (*PurgeDLQResponse, error)
+	RedriveDLQ(context.Context, *RedriveDLQRequest) (*RedriveDLQResponse, error)
+	ListDLQs(context.Context, *ListDLQsRequest) (*ListDLQsResponse, error)
+	GetDLQ(context.Context, *GetDLQRequest) (*GetDLQResponse, error)
+	DLQSummary(context.Context, *DLQSummaryRequest) (*DLQSummaryResponse, error)
+	// Schedules
+	CreateSchedule(context.Context, *CreateScheduleRequest) (*CreateScheduleResponse, error)
+	ListSchedules(context.Context, *ListSchedulesRequest) (*ListSchedulesResponse, error)
+	DeleteSchedule(context.Context, *DeleteScheduleRequest) (*DeleteScheduleResponse, error)
+	PauseSchedule(context.Context, *PauseScheduleRequest) (*PauseScheduleResponse, error)
 	mustEmbedUnimplementedTaskExecutorManagerServer()
 }
 
@@ -195,6 +511,12 @@ func (UnimplementedTaskExecutorManagerServer) AddTask(context.Context, *AddTaskR
 func (UnimplementedTaskExecutorManagerServer) GetTaskStatus(context.Context, *GetTaskStatusRequest) (*GetTaskStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTaskStatus not implemented")
 }
+func (UnimplementedTaskExecutorManagerServer) CancelTask(context.Context, *CancelTaskRequest) (*CancelTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelTask not implemented")
+}
+func (UnimplementedTaskExecutorManagerServer) ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTasks not implemented")
+}
 func (UnimplementedTaskExecutorManagerServer) RegisterExecutor(context.Context, *RegisterExecutorRequest) (*RegisterExecutorResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RegisterExecutor not implemented")
 }
@@ -204,6 +526,21 @@ func (UnimplementedTaskExecutorManagerServer) GetNextTask(context.Context, *GetN
 func (UnimplementedTaskExecutorManagerServer) UpdateTaskStatus(context.Context, *UpdateTaskStatusRequest) (*UpdateTaskStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateTaskStatus not implemented")
 }
+func (UnimplementedTaskExecutorManagerServer) UpdateTaskStatuses(context.Context, *UpdateTaskStatusesRequest) (*UpdateTaskStatusesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTaskStatuses not implemented")
+}
+func (UnimplementedTaskExecutorManagerServer) HeartbeatTask(context.Context, *HeartbeatTaskRequest) (*HeartbeatTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HeartbeatTask not implemented")
+}
+func (UnimplementedTaskExecutorManagerServer) ReleaseTask(context.Context, *ReleaseTaskRequest) (*ReleaseTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseTask not implemented")
+}
+func (UnimplementedTaskExecutorManagerServer) HeartbeatWorker(context.Context, *HeartbeatWorkerRequest) (*HeartbeatWorkerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HeartbeatWorker not implemented")
+}
+func (UnimplementedTaskExecutorManagerServer) ListWorkers(context.Context, *ListWorkersRequest) (*ListWorkersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWorkers not implemented")
+}
 func (UnimplementedTaskExecutorManagerServer) CreateExecutor(context.Context, *CreateExecutorRequest) (*CreateExecutorResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateExecutor not implemented")
 }
@@ -219,6 +556,57 @@ func (UnimplementedTaskExecutorManagerServer) ListExecutors(context.Context, *Li
 func (UnimplementedTaskExecutorManagerServer) DeleteExecutor(context.Context, *DeleteExecutorRequest) (*DeleteExecutorResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteExecutor not implemented")
 }
+func (UnimplementedTaskExecutorManagerServer) PauseExecutor(context.Context, *PauseExecutorRequest) (*PauseExecutorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseExecutor not implemented")
+}
+func (UnimplementedTaskExecutorManagerServer) ResumeExecutor(context.Context, *ResumeExecutorRequest) (*ResumeExecutorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeExecutor not implemented")
+}
+func (UnimplementedTaskExecutorManagerServer) DrainExecutor(context.Context, *DrainExecutorRequest) (*DrainExecutorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DrainExecutor not implemented")
+}
+func (UnimplementedTaskExecutorManagerServer) DisableExecutor(context.Context, *DisableExecutorRequest) (*DisableExecutorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableExecutor not implemented")
+}
+func (UnimplementedTaskExecutorManagerServer) UndeleteExecutor(context.Context, *UndeleteExecutorRequest) (*UndeleteExecutorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndeleteExecutor not implemented")
+}
+func (UnimplementedTaskExecutorManagerServer) ListExecutorRevisions(context.Context, *ListExecutorRevisionsRequest) (*ListExecutorRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExecutorRevisions not implemented")
+}
+func (UnimplementedTaskExecutorManagerServer) RollbackExecutor(context.Context, *RollbackExecutorRequest) (*RollbackExecutorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackExecutor not implemented")
+}
+func (UnimplementedTaskExecutorManagerServer) ListDLQTasks(context.Context, *ListDLQTasksRequest) (*ListDLQTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDLQTasks not implemented")
+}
+func (UnimplementedTaskExecutorManagerServer) PurgeDLQ(context.Context, *PurgeDLQRequest) (*PurgeDLQResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeDLQ not implemented")
+}
+func (UnimplementedTaskExecutorManagerServer) RedriveDLQ(context.Context, *RedriveDLQRequest) (*RedriveDLQResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedriveDLQ not implemented")
+}
+func (UnimplementedTaskExecutorManagerServer) ListDLQs(context.Context, *ListDLQsRequest) (*ListDLQsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDLQs not implemented")
+}
+func (UnimplementedTaskExecutorManagerServer) GetDLQ(context.Context, *GetDLQRequest) (*GetDLQResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDLQ not implemented")
+}
+func (UnimplementedTaskExecutorManagerServer) DLQSummary(context.Context, *DLQSummaryRequest) (*DLQSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DLQSummary not implemented")
+}
+func (UnimplementedTaskExecutorManagerServer) CreateSchedule(context.Context, *CreateScheduleRequest) (*CreateScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSchedule not implemented")
+}
+func (UnimplementedTaskExecutorManagerServer) ListSchedules(context.Context, *ListSchedulesRequest) (*ListSchedulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSchedules not implemented")
+}
+func (UnimplementedTaskExecutorManagerServer) DeleteSchedule(context.Context, *DeleteScheduleRequest) (*DeleteScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSchedule not implemented")
+}
+func (UnimplementedTaskExecutorManagerServer) PauseSchedule(context.Context, *PauseScheduleRequest) (*PauseScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseSchedule not implemented")
+}
 func (UnimplementedTaskExecutorManagerServer) mustEmbedUnimplementedTaskExecutorManagerServer() {}
 func (UnimplementedTaskExecutorManagerServer) testEmbeddedByValue()                             {}
 
@@ -276,6 +664,42 @@ func _TaskExecutorManager_GetTaskStatus_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TaskExecutorManager_CancelTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskExecutorManagerServer).CancelTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskExecutorManager_CancelTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskExecutorManagerServer).CancelTask(ctx, req.(*CancelTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskExecutorManager_ListTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskExecutorManagerServer).ListTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskExecutorManager_ListTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskExecutorManagerServer).ListTasks(ctx, req.(*ListTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TaskExecutorManager_RegisterExecutor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RegisterExecutorRequest)
 	if err := dec(in); err != nil {
@@ -330,111 +754,515 @@ func _TaskExecutorManager_UpdateTaskStatus_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TaskExecutorManager_CreateExecutor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateExecutorRequest)
+func _TaskExecutorManager_UpdateTaskStatuses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTaskStatusesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TaskExecutorManagerServer).CreateExecutor(ctx, in)
+		return srv.(TaskExecutorManagerServer).UpdateTaskStatuses(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: TaskExecutorManager_CreateExecutor_FullMethodName,
+		FullMethod: TaskExecutorManager_UpdateTaskStatuses_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TaskExecutorManagerServer).CreateExecutor(ctx, req.(*CreateExecutorRequest))
+		return srv.(TaskExecutorManagerServer).UpdateTaskStatuses(ctx, req.(*UpdateTaskStatusesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TaskExecutorManager_UpdateExecutor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateExecutorRequest)
+func _TaskExecutorManager_HeartbeatTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatTaskRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TaskExecutorManagerServer).UpdateExecutor(ctx, in)
+		return srv.(TaskExecutorManagerServer).HeartbeatTask(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: TaskExecutorManager_UpdateExecutor_FullMethodName,
+		FullMethod: TaskExecutorManager_HeartbeatTask_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TaskExecutorManagerServer).UpdateExecutor(ctx, req.(*UpdateExecutorRequest))
+		return srv.(TaskExecutorManagerServer).HeartbeatTask(ctx, req.(*HeartbeatTaskRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TaskExecutorManager_GetExecutor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetExecutorRequest)
+func _TaskExecutorManager_ReleaseTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseTaskRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TaskExecutorManagerServer).GetExecutor(ctx, in)
+		return srv.(TaskExecutorManagerServer).ReleaseTask(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: TaskExecutorManager_GetExecutor_FullMethodName,
+		FullMethod: TaskExecutorManager_ReleaseTask_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TaskExecutorManagerServer).GetExecutor(ctx, req.(*GetExecutorRequest))
+		return srv.(TaskExecutorManagerServer).ReleaseTask(ctx, req.(*ReleaseTaskRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TaskExecutorManager_ListExecutors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListExecutorsRequest)
+func _TaskExecutorManager_HeartbeatWorker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatWorkerRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TaskExecutorManagerServer).ListExecutors(ctx, in)
+		return srv.(TaskExecutorManagerServer).HeartbeatWorker(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: TaskExecutorManager_ListExecutors_FullMethodName,
+		FullMethod: TaskExecutorManager_HeartbeatWorker_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TaskExecutorManagerServer).ListExecutors(ctx, req.(*ListExecutorsRequest))
+		return srv.(TaskExecutorManagerServer).HeartbeatWorker(ctx, req.(*HeartbeatWorkerRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TaskExecutorManager_DeleteExecutor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteExecutorRequest)
+func _TaskExecutorManager_ListWorkers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWorkersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TaskExecutorManagerServer).DeleteExecutor(ctx, in)
+		return srv.(TaskExecutorManagerServer).ListWorkers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: TaskExecutorManager_DeleteExecutor_FullMethodName,
+		FullMethod: TaskExecutorManager_ListWorkers_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TaskExecutorManagerServer).DeleteExecutor(ctx, req.(*DeleteExecutorRequest))
+		return srv.(TaskExecutorManagerServer).ListWorkers(ctx, req.(*ListWorkersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// TaskExecutorManager_ServiceDesc is the grpc.ServiceDesc for TaskExecutorManager service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var TaskExecutorManager_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "taskexecutor.TaskExecutorManager",
-	HandlerType: (*TaskExecutorManagerServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "AddTask",
-			Handler:    _TaskExecutorManager_AddTask_Handler,
-		},
-		{
-			MethodName: "GetTaskStatus",
+func _TaskExecutorManager_CreateExecutor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateExecutorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskExecutorManagerServer).CreateExecutor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskExecutorManager_CreateExecutor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskExecutorManagerServer).CreateExecutor(ctx, req.(*CreateExecutorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskExecutorManager_UpdateExecutor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateExecutorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskExecutorManagerServer).UpdateExecutor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskExecutorManager_UpdateExecutor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskExecutorManagerServer).UpdateExecutor(ctx, req.(*UpdateExecutorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskExecutorManager_GetExecutor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExecutorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskExecutorManagerServer).GetExecutor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskExecutorManager_GetExecutor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskExecutorManagerServer).GetExecutor(ctx, req.(*GetExecutorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskExecutorManager_ListExecutors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExecutorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskExecutorManagerServer).ListExecutors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskExecutorManager_ListExecutors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskExecutorManagerServer).ListExecutors(ctx, req.(*ListExecutorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskExecutorManager_DeleteExecutor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteExecutorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskExecutorManagerServer).DeleteExecutor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskExecutorManager_DeleteExecutor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskExecutorManagerServer).DeleteExecutor(ctx, req.(*DeleteExecutorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskExecutorManager_PauseExecutor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseExecutorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskExecutorManagerServer).PauseExecutor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskExecutorManager_PauseExecutor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskExecutorManagerServer).PauseExecutor(ctx, req.(*PauseExecutorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskExecutorManager_ResumeExecutor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeExecutorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskExecutorManagerServer).ResumeExecutor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskExecutorManager_ResumeExecutor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskExecutorManagerServer).ResumeExecutor(ctx, req.(*ResumeExecutorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskExecutorManager_DrainExecutor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainExecutorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskExecutorManagerServer).DrainExecutor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskExecutorManager_DrainExecutor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskExecutorManagerServer).DrainExecutor(ctx, req.(*DrainExecutorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskExecutorManager_DisableExecutor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableExecutorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskExecutorManagerServer).DisableExecutor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskExecutorManager_DisableExecutor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskExecutorManagerServer).DisableExecutor(ctx, req.(*DisableExecutorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskExecutorManager_UndeleteExecutor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeleteExecutorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskExecutorManagerServer).UndeleteExecutor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskExecutorManager_UndeleteExecutor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskExecutorManagerServer).UndeleteExecutor(ctx, req.(*UndeleteExecutorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskExecutorManager_ListExecutorRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExecutorRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskExecutorManagerServer).ListExecutorRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskExecutorManager_ListExecutorRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskExecutorManagerServer).ListExecutorRevisions(ctx, req.(*ListExecutorRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskExecutorManager_RollbackExecutor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackExecutorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskExecutorManagerServer).RollbackExecutor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskExecutorManager_RollbackExecutor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskExecutorManagerServer).RollbackExecutor(ctx, req.(*RollbackExecutorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskExecutorManager_ListDLQTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDLQTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskExecutorManagerServer).ListDLQTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskExecutorManager_ListDLQTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskExecutorManagerServer).ListDLQTasks(ctx, req.(*ListDLQTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskExecutorManager_PurgeDLQ_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeDLQRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskExecutorManagerServer).PurgeDLQ(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskExecutorManager_PurgeDLQ_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskExecutorManagerServer).PurgeDLQ(ctx, req.(*PurgeDLQRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskExecutorManager_RedriveDLQ_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedriveDLQRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskExecutorManagerServer).RedriveDLQ(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskExecutorManager_RedriveDLQ_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskExecutorManagerServer).RedriveDLQ(ctx, req.(*RedriveDLQRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskExecutorManager_ListDLQs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDLQsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskExecutorManagerServer).ListDLQs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskExecutorManager_ListDLQs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskExecutorManagerServer).ListDLQs(ctx, req.(*ListDLQsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskExecutorManager_GetDLQ_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDLQRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskExecutorManagerServer).GetDLQ(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskExecutorManager_GetDLQ_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskExecutorManagerServer).GetDLQ(ctx, req.(*GetDLQRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskExecutorManager_DLQSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DLQSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskExecutorManagerServer).DLQSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskExecutorManager_DLQSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskExecutorManagerServer).DLQSummary(ctx, req.(*DLQSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskExecutorManager_CreateSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskExecutorManagerServer).CreateSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskExecutorManager_CreateSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskExecutorManagerServer).CreateSchedule(ctx, req.(*CreateScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskExecutorManager_ListSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskExecutorManagerServer).ListSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskExecutorManager_ListSchedules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskExecutorManagerServer).ListSchedules(ctx, req.(*ListSchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskExecutorManager_DeleteSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskExecutorManagerServer).DeleteSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskExecutorManager_DeleteSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskExecutorManagerServer).DeleteSchedule(ctx, req.(*DeleteScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskExecutorManager_PauseSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskExecutorManagerServer).PauseSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskExecutorManager_PauseSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskExecutorManagerServer).PauseSchedule(ctx, req.(*PauseScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TaskExecutorManager_ServiceDesc is the grpc.ServiceDesc for TaskExecutorManager service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TaskExecutorManager_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "taskexecutor.TaskExecutorManager",
+	HandlerType: (*TaskExecutorManagerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddTask",
+			Handler:    _TaskExecutorManager_AddTask_Handler,
+		},
+		{
+			MethodName: "GetTaskStatus",
 			Handler:    _TaskExecutorManager_GetTaskStatus_Handler,
 		},
+		{
+			MethodName: "CancelTask",
+			Handler:    _TaskExecutorManager_CancelTask_Handler,
+		},
+		{
+			MethodName: "ListTasks",
+			Handler:    _TaskExecutorManager_ListTasks_Handler,
+		},
 		{
 			MethodName: "RegisterExecutor",
 			Handler:    _TaskExecutorManager_RegisterExecutor_Handler,
@@ -447,6 +1275,26 @@ var TaskExecutorManager_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateTaskStatus",
 			Handler:    _TaskExecutorManager_UpdateTaskStatus_Handler,
 		},
+		{
+			MethodName: "UpdateTaskStatuses",
+			Handler:    _TaskExecutorManager_UpdateTaskStatuses_Handler,
+		},
+		{
+			MethodName: "HeartbeatTask",
+			Handler:    _TaskExecutorManager_HeartbeatTask_Handler,
+		},
+		{
+			MethodName: "ReleaseTask",
+			Handler:    _TaskExecutorManager_ReleaseTask_Handler,
+		},
+		{
+			MethodName: "HeartbeatWorker",
+			Handler:    _TaskExecutorManager_HeartbeatWorker_Handler,
+		},
+		{
+			MethodName: "ListWorkers",
+			Handler:    _TaskExecutorManager_ListWorkers_Handler,
+		},
 		{
 			MethodName: "CreateExecutor",
 			Handler:    _TaskExecutorManager_CreateExecutor_Handler,
@@ -467,6 +1315,74 @@ var TaskExecutorManager_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteExecutor",
 			Handler:    _TaskExecutorManager_DeleteExecutor_Handler,
 		},
+		{
+			MethodName: "PauseExecutor",
+			Handler:    _TaskExecutorManager_PauseExecutor_Handler,
+		},
+		{
+			MethodName: "ResumeExecutor",
+			Handler:    _TaskExecutorManager_ResumeExecutor_Handler,
+		},
+		{
+			MethodName: "DrainExecutor",
+			Handler:    _TaskExecutorManager_DrainExecutor_Handler,
+		},
+		{
+			MethodName: "DisableExecutor",
+			Handler:    _TaskExecutorManager_DisableExecutor_Handler,
+		},
+		{
+			MethodName: "UndeleteExecutor",
+			Handler:    _TaskExecutorManager_UndeleteExecutor_Handler,
+		},
+		{
+			MethodName: "ListExecutorRevisions",
+			Handler:    _TaskExecutorManager_ListExecutorRevisions_Handler,
+		},
+		{
+			MethodName: "RollbackExecutor",
+			Handler:    _TaskExecutorManager_RollbackExecutor_Handler,
+		},
+		{
+			MethodName: "ListDLQTasks",
+			Handler:    _TaskExecutorManager_ListDLQTasks_Handler,
+		},
+		{
+			MethodName: "PurgeDLQ",
+			Handler:    _TaskExecutorManager_PurgeDLQ_Handler,
+		},
+		{
+			MethodName: "RedriveDLQ",
+			Handler:    _TaskExecutorManager_RedriveDLQ_Handler,
+		},
+		{
+			MethodName: "ListDLQs",
+			Handler:    _TaskExecutorManager_ListDLQs_Handler,
+		},
+		{
+			MethodName: "GetDLQ",
+			Handler:    _TaskExecutorManager_GetDLQ_Handler,
+		},
+		{
+			MethodName: "DLQSummary",
+			Handler:    _TaskExecutorManager_DLQSummary_Handler,
+		},
+		{
+			MethodName: "CreateSchedule",
+			Handler:    _TaskExecutorManager_CreateSchedule_Handler,
+		},
+		{
+			MethodName: "ListSchedules",
+			Handler:    _TaskExecutorManager_ListSchedules_Handler,
+		},
+		{
+			MethodName: "DeleteSchedule",
+			Handler:    _TaskExecutorManager_DeleteSchedule_Handler,
+		},
+		{
+			MethodName: "PauseSchedule",
+			Handler:    _TaskExecutorManager_PauseSchedule_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/task_executor.proto",